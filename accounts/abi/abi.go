@@ -19,8 +19,11 @@ package abi
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+
+	"github.com/seeleteam/go-seele/crypto"
 )
 
 // The ABI holds information about a contract's context and available
@@ -144,3 +147,27 @@ func (abi *ABI) MethodById(sigdata []byte) (*Method, error) {
 	}
 	return nil, fmt.Errorf("no method with id: %#x", sigdata[:4])
 }
+
+// revertSelector is a special function selector for revert reason unpacking.
+var revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+// UnpackRevert resolves the abi-encoded revert reason. According to the solidity
+// spec, the provided revert reason is abi-encoded as if it were a call to a function
+// `Error(string)`.
+func UnpackRevert(data []byte) (string, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], revertSelector) {
+		return "", errors.New("abi: invalid data for revert reason unpacking")
+	}
+
+	typ, err := NewType("string")
+	if err != nil {
+		return "", err
+	}
+
+	unpacked, err := Arguments{{Type: typ}}.UnpackValues(data[4:])
+	if err != nil {
+		return "", err
+	}
+
+	return unpacked[0].(string), nil
+}
@@ -713,3 +713,20 @@ func TestABI_MethodById(t *testing.T) {
 	}
 
 }
+
+func TestUnpackRevert(t *testing.T) {
+	// revert("revert reason")
+	data := common.FromHex("08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000d72657665727420726561736f6e00000000000000000000000000000000000000")
+	reason, err := UnpackRevert(data)
+	if err != nil || reason != "revert reason" {
+		t.Fatalf("unexpected revert reason %v, error %v", reason, err)
+	}
+
+	if _, err = UnpackRevert(data[4:]); err == nil {
+		t.Fatal("expected error for data without selector")
+	}
+
+	if _, err = UnpackRevert(data[:40]); err == nil {
+		t.Fatal("expected error for truncated data")
+	}
+}
@@ -37,7 +37,7 @@ func printReceiptByABI(api *PublicSeeleAPI, receipt *types.Receipt, abiJSON stri
 				return result, nil
 			}
 
-			logOut, err := printLogByABI(log, parsed)
+			logOut, err := PrintableLogByABI(log, parsed)
 			if err != nil {
 				api.s.Log().Warn("err: %s", err)
 				return result, nil
@@ -52,7 +52,8 @@ func printReceiptByABI(api *PublicSeeleAPI, receipt *types.Receipt, abiJSON stri
 	return result, nil
 }
 
-func printLogByABI(log *types.Log, parsed abi.ABI) (string, error) {
+// PrintableLogByABI decodes the event of the given log by ABI into JSON.
+func PrintableLogByABI(log *types.Log, parsed abi.ABI) (string, error) {
 	seelelog := &seeleLog{}
 	if len(log.Topics) < 1 {
 		return "", nil
@@ -0,0 +1,54 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package api
+
+import (
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// PublicFilterAPI provides filters installed on server side, whose changes are polled
+// by client, e.g. via HTTP. Filters are uninstalled automatically if not polled in 5 minutes.
+type PublicFilterAPI struct {
+	fm *filterManager
+}
+
+// NewPublicFilterAPI creates a new PublicFilterAPI object for filter rpc service.
+func NewPublicFilterAPI(s Backend) *PublicFilterAPI {
+	return &PublicFilterAPI{newFilterManager(s.ChainBackend(), s.Log())}
+}
+
+// NewFilter installs a filter of logs that match the criteria in new canonical blocks, and returns the filter id.
+// Note, the block range of criteria only applies to GetFilterLogs.
+func (api *PublicFilterAPI) NewFilter(criteria LogFilter) (rpc.ID, error) {
+	return api.fm.install(logFilter, &criteria)
+}
+
+// NewBlockFilter installs a filter of new canonical blocks, and returns the filter id.
+func (api *PublicFilterAPI) NewBlockFilter() (rpc.ID, error) {
+	return api.fm.install(blockFilter, nil)
+}
+
+// NewPendingTransactionFilter installs a filter of txs inserted into tx pool, and returns the filter id.
+func (api *PublicFilterAPI) NewPendingTransactionFilter() (rpc.ID, error) {
+	return api.fm.install(pendingTxFilter, nil)
+}
+
+// GetFilterChanges returns the changes since the last poll of the specified filter, which are block
+// hashes for block filter, logs for log filter (including the logs removed due to chain reorganization),
+// or tx hashes for pending tx filter.
+func (api *PublicFilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
+	return api.fm.changes(id)
+}
+
+// GetFilterLogs returns all the logs that match the criteria of the specified log filter.
+func (api *PublicFilterAPI) GetFilterLogs(id rpc.ID) ([]*FilteredLog, error) {
+	return api.fm.logs(id)
+}
+
+// UninstallFilter uninstalls the specified filter, and returns false if the filter not found.
+func (api *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
+	return api.fm.uninstall(id)
+}
@@ -21,7 +21,12 @@ import (
 // ErrInvalidAccount the account is invalid
 var ErrInvalidAccount = errors.New("invalid account")
 
-const maxSizeLimit = 64
+const (
+	maxSizeLimit = 64
+
+	// maxAccountHistoryLimit is the max number of account history entries returned in one query
+	maxAccountHistoryLimit = 1000
+)
 
 // PublicSeeleAPI provides an API to access full node-related information.
 type PublicSeeleAPI struct {
@@ -126,7 +131,7 @@ func (api *PublicSeeleAPI) GetBlockByHeight(height int64, fulltx bool) (map[stri
 	if err != nil {
 		return nil, err
 	}
-	return rpcOutputBlock(block, fulltx, totalDifficulty)
+	return PrintableOutputBlock(block, fulltx, totalDifficulty)
 }
 
 // GetBlocks returns the size of requested block. When the blockNr is -1 the chain head is returned.
@@ -191,11 +196,11 @@ func (api *PublicSeeleAPI) GetBlockByHash(hashHex string, fulltx bool) (map[stri
 	if err != nil {
 		return nil, err
 	}
-	return rpcOutputBlock(block, fulltx, totalDifficulty)
+	return PrintableOutputBlock(block, fulltx, totalDifficulty)
 }
 
-// rpcOutputBlock converts the given block to the RPC output which depends on fullTx
-func rpcOutputBlock(b *types.Block, fullTx bool, totalDifficulty *big.Int) (map[string]interface{}, error) {
+// PrintableOutputBlock converts the given block to the RPC output which depends on fullTx
+func PrintableOutputBlock(b *types.Block, fullTx bool, totalDifficulty *big.Int) (map[string]interface{}, error) {
 	head := b.Header
 	fields := map[string]interface{}{
 		"header": head,
@@ -238,7 +243,7 @@ func rpcOutputBlocks(b []*types.Block, fullTx bool, d []*big.Int) ([]map[string]
 	fields := make([]map[string]interface{}, 0)
 
 	for i := range b {
-		if field, err := rpcOutputBlock(b[i], fullTx, d[i]); err == nil {
+		if field, err := PrintableOutputBlock(b[i], fullTx, d[i]); err == nil {
 			fields = append(fields, field)
 		}
 	}
@@ -275,7 +280,7 @@ func (api *PublicSeeleAPI) AddTx(tx types.Transaction) (bool, error) {
 			api.s.ProtocolBackend().SendDifferentShardTx(&tx, shard)
 		}
 	} else {
-		err = api.s.TxPoolBackend().AddTransaction(&tx)
+		err = api.s.TxPoolBackend().AddLocalTransaction(&tx)
 	}
 
 	if err != nil {
@@ -634,6 +639,40 @@ func (api *PublicSeeleAPI) GetAccountTransactionsByHeight(account common.Address
 	return result, nil
 }
 
+// GetAccountHistory returns the txs and debts that the specified account involved in canonical blocks
+// with height in range [fromHeight, toHeight], in ascending order. The first offset entries are skipped,
+// and the limit will be set to 1000 if it's 0 or greater than 1000. Note, the blocks written before the
+// account history index enabled are not indexed, and an error is returned if fromHeight is lower than
+// the height that the index started from.
+func (api *PublicSeeleAPI) GetAccountHistory(account common.Address, fromHeight, toHeight uint64, offset, limit uint) ([]map[string]interface{}, error) {
+	if limit == 0 || limit > maxAccountHistoryLimit {
+		limit = maxAccountHistoryLimit
+	}
+
+	entries, err := api.s.ChainBackend().GetStore().GetAccountHistory(account, fromHeight, toHeight, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		kind := "tx"
+		if entry.IsDebt {
+			kind = "debt"
+		}
+
+		result = append(result, map[string]interface{}{
+			"hash":      entry.Hash.Hex(),
+			"blockHash": entry.BlockHash.Hex(),
+			"height":    entry.Height,
+			"index":     entry.Index,
+			"type":      kind,
+		})
+	}
+
+	return result, nil
+}
+
 // GetBlockTransactions get all txs in the block with heigth or blockhash
 func (api *PublicSeeleAPI) GetBlockTransactions(blockHash string, height int64) (result []map[string]interface{}, err error) {
 	if len(blockHash) > 0 {
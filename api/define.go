@@ -7,6 +7,7 @@ import (
 	"github.com/seeleteam/go-seele/core/state"
 	"github.com/seeleteam/go-seele/core/store"
 	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/event"
 	"github.com/seeleteam/go-seele/log"
 	"github.com/seeleteam/go-seele/p2p"
 	"github.com/seeleteam/go-seele/rpc"
@@ -53,6 +54,12 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 			Service:   NewPrivateNetworkAPI(apiBackend),
 			Public:    true,
 		},
+		{
+			Namespace: "filter",
+			Version:   "1.0",
+			Service:   NewPublicFilterAPI(apiBackend),
+			Public:    true,
+		},
 	}
 }
 
@@ -89,6 +96,7 @@ type PoolCore interface {
 
 type Pool interface {
 	PoolCore
+	AddLocalTransaction(tx *types.Transaction) error
 	GetTransactions(processing, pending bool) []*types.Transaction
 	GetTxCount() int
 }
@@ -98,9 +106,92 @@ type Chain interface {
 	GetCurrentState() (*state.Statedb, error)
 	GetState(blockHash common.Hash) (*state.Statedb, error)
 	GetStore() store.BlockchainStore
+	GetHeadRollbackEventManager() *event.EventManager
 }
 
 type Protocol interface {
 	SendDifferentShardTx(tx *types.Transaction, shard uint)
 	GetProtocolVersion() (uint, error)
 }
+
+// TpsInfo tps detail info
+type TpsInfo struct {
+	StartHeight uint64
+	EndHeight   uint64
+	Count       uint64
+	Duration    uint64
+}
+
+// StateDump is a page of accounts in state dump.
+type StateDump struct {
+	Accounts []*state.DumpAccount `json:"accounts"`
+	Next     *common.Hash         `json:"next"` // address hash of the first account in next page, nil if no more
+}
+
+// TraceConfig is the config to trace the execution of transaction.
+type TraceConfig struct {
+	DisableMemory  bool   `json:"disableMemory"`  // disable memory capture
+	DisableStack   bool   `json:"disableStack"`   // disable stack capture
+	DisableStorage bool   `json:"disableStorage"` // disable storage capture
+	Limit          int    `json:"limit"`          // maximum number of opcode traces, but zero means unlimited
+	Tracer         string `json:"tracer"`         // empty for per-opcode traces, or "callTracer" for call frames
+}
+
+// ExecutionResult is the result of traced transaction, including the per-opcode traces.
+type ExecutionResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []StructLogRes `json:"structLogs"`
+}
+
+// StructLogRes is the trace of an opcode, where the stack items, memory words and storage
+// slots are in 32 bytes hex format.
+type StructLogRes struct {
+	Pc      uint64            `json:"pc"`
+	Op      string            `json:"op"`
+	Gas     uint64            `json:"gas"`
+	GasCost uint64            `json:"gasCost"`
+	Depth   int               `json:"depth"`
+	Error   string            `json:"error,omitempty"`
+	Stack   []string          `json:"stack,omitempty"`
+	Memory  []string          `json:"memory,omitempty"`
+	Storage map[string]string `json:"storage,omitempty"`
+}
+
+// SimulateArgs is the call object to simulate.
+type SimulateArgs struct {
+	From     common.Address `json:"from"`     // random account with enough balance if empty
+	To       common.Address `json:"to"`       // empty to create contract
+	Amount   *big.Int       `json:"amount"`   // 0 if not specified
+	GasPrice *big.Int       `json:"gasPrice"` // 1 if not specified
+	GasLimit uint64         `json:"gasLimit"` // max gas affordable by sender if 0
+	Payload  common.Bytes   `json:"payload"`
+}
+
+// AccountOverride is the state override of an account, which applies before simulation.
+type AccountOverride struct {
+	Balance *big.Int                    `json:"balance"`
+	Nonce   *uint64                     `json:"nonce"`
+	Code    *common.Bytes               `json:"code"`
+	Storage map[common.Hash]common.Hash `json:"storage"` // only the specified slots are overridden
+}
+
+// PriceBucket is the number of txs at the same gas price in tx pool.
+type PriceBucket struct {
+	Price *big.Int `json:"price"`
+	Count int      `json:"count"`
+}
+
+// ReplacedTransaction is the notification of replaced txs subscription.
+type ReplacedTransaction struct {
+	Hash        common.Hash `json:"hash"`        // hash of the replaced tx
+	Replacement common.Hash `json:"replacement"` // hash of the tx that replaced it
+}
+
+// SyncingStatus is the notification of syncing subscription.
+type SyncingStatus struct {
+	Syncing      bool   `json:"syncing"`
+	Status       string `json:"status"` // started, done or failed
+	CurrentBlock uint64 `json:"currentBlock"`
+}
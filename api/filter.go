@@ -0,0 +1,362 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/seeleteam/go-seele/accounts/abi"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+// maxLogFilterBlockRange is the max number of blocks to filter logs in one query.
+const maxLogFilterBlockRange = 10000
+
+var (
+	errInvalidLogFilterRange  = errors.New("invalid block range, fromBlock is greater than toBlock")
+	errLogFilterRangeTooLarge = fmt.Errorf("block range too large, at most %v blocks", maxLogFilterBlockRange)
+)
+
+// LogFilter is the criteria to filter logs in the canonical chain.
+type LogFilter struct {
+	// FromBlock and ToBlock are the block height range [FromBlock, ToBlock]
+	// to filter logs, and negative value represents the HEAD block.
+	FromBlock int64 `json:"fromBlock"`
+	ToBlock   int64 `json:"toBlock"`
+
+	// Addresses are the contract addresses that generated logs, empty to match any address.
+	Addresses []common.Address `json:"addresses"`
+
+	// Topics are the topics to match by position, and each position is a set of topics
+	// in OR relationship. Empty set (or null) in a position matches any topic. E.g.
+	//   {}                matches any topics
+	//   {{A}}             matches A in first position
+	//   {{}, {B}}         matches any topic in first position, and B in second position
+	//   {{A, B}, {C, D}}  matches A or B in first position, and C or D in second position
+	Topics [][]common.Hash `json:"topics"`
+
+	// AbiJSON is optional to decode the logs of events defined in ABI.
+	AbiJSON string `json:"abi"`
+}
+
+// blockRange returns the block height range to filter logs according to the specified HEAD height.
+// Note, the range is empty (from > to) if fromBlock is greater than HEAD height.
+func (filter *LogFilter) blockRange(head uint64) (uint64, uint64, error) {
+	from, to := head, head
+	if filter.FromBlock >= 0 {
+		from = uint64(filter.FromBlock)
+	}
+
+	if filter.ToBlock >= 0 {
+		if from > uint64(filter.ToBlock) {
+			return 0, 0, errInvalidLogFilterRange
+		}
+
+		if uint64(filter.ToBlock) < head {
+			to = uint64(filter.ToBlock)
+		}
+	}
+
+	if from <= to && to-from >= maxLogFilterBlockRange {
+		return 0, 0, errLogFilterRangeTooLarge
+	}
+
+	return from, to, nil
+}
+
+// matchBloom returns false if the logs in block with the specified bloom definitely not match the filter.
+func (filter *LogFilter) matchBloom(bloom *types.Bloom) bool {
+	if len(filter.Addresses) > 0 {
+		matched := false
+		for _, addr := range filter.Addresses {
+			if bloom.TestAddress(addr) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	for _, topics := range filter.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+
+		matched := false
+		for _, topic := range topics {
+			if bloom.TestTopic(topic) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchLog returns true if the specified log matches the filter.
+func (filter *LogFilter) matchLog(log *types.Log) bool {
+	if len(filter.Addresses) > 0 {
+		matched := false
+		for _, addr := range filter.Addresses {
+			if addr.Equal(log.Address) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if len(filter.Topics) > len(log.Topics) {
+		return false
+	}
+
+	for i, topics := range filter.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+
+		matched := false
+		for _, topic := range topics {
+			if topic.Equal(log.Topics[i]) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilteredLog is the log matched by filter along with its position in the canonical chain.
+type FilteredLog struct {
+	*types.Log
+	BlockHash common.Hash
+	TxHash    common.Hash
+	LogIndex  uint // index of log in block
+
+	// Event and Args are decoded by ABI if provided in filter and the event found in ABI.
+	Event string
+	Args  interface{}
+
+	// Removed is true if the log was reverted due to chain reorganization.
+	Removed bool
+}
+
+// MarshalJSON marshals the filtered log with hex strings.
+func (log *FilteredLog) MarshalJSON() ([]byte, error) {
+	var o struct {
+		Address     string      `json:"address"`
+		Topics      []string    `json:"topics"`
+		Data        string      `json:"data"`
+		BlockNumber uint64      `json:"blockNumber"`
+		BlockHash   string      `json:"blockHash"`
+		TxHash      string      `json:"transactionHash"`
+		TxIndex     uint        `json:"transactionIndex"`
+		LogIndex    uint        `json:"logIndex"`
+		Event       string      `json:"event,omitempty"`
+		Args        interface{} `json:"args,omitempty"`
+		Removed     bool        `json:"removed"`
+	}
+
+	o.Address = log.Address.Hex()
+	o.Topics = make([]string, len(log.Topics))
+	for i, topic := range log.Topics {
+		o.Topics[i] = topic.Hex()
+	}
+	o.Data = hexutil.BytesToHex(log.Data)
+	o.BlockNumber = log.BlockNumber
+	o.BlockHash = log.BlockHash.Hex()
+	o.TxHash = log.TxHash.Hex()
+	o.TxIndex = log.TxIndex
+	o.LogIndex = log.LogIndex
+	o.Event = log.Event
+	o.Args = log.Args
+	o.Removed = log.Removed
+
+	return json.Marshal(&o)
+}
+
+// UnmarshalJSON unmarshals the filtered log with hex strings, and the decoded args are left
+// as generic JSON values.
+func (log *FilteredLog) UnmarshalJSON(input []byte) error {
+	var o struct {
+		Address     common.Address `json:"address"`
+		Topics      []common.Hash  `json:"topics"`
+		Data        string         `json:"data"`
+		BlockNumber uint64         `json:"blockNumber"`
+		BlockHash   common.Hash    `json:"blockHash"`
+		TxHash      common.Hash    `json:"transactionHash"`
+		TxIndex     uint           `json:"transactionIndex"`
+		LogIndex    uint           `json:"logIndex"`
+		Event       string         `json:"event"`
+		Args        interface{}    `json:"args"`
+		Removed     bool           `json:"removed"`
+	}
+
+	if err := json.Unmarshal(input, &o); err != nil {
+		return err
+	}
+
+	data, err := hexutil.HexToBytes(o.Data)
+	if err != nil {
+		return err
+	}
+
+	log.Log = &types.Log{
+		Address:     o.Address,
+		Topics:      o.Topics,
+		Data:        data,
+		BlockNumber: o.BlockNumber,
+		TxIndex:     o.TxIndex,
+	}
+	log.BlockHash = o.BlockHash
+	log.TxHash = o.TxHash
+	log.LogIndex = o.LogIndex
+	log.Event = o.Event
+	log.Args = o.Args
+	log.Removed = o.Removed
+
+	return nil
+}
+
+// LogDecoder decodes the logs of events defined in ABI.
+type LogDecoder map[common.Hash]abi.Event
+
+// NewLogDecoder returns a decoder of the events defined in the specified ABI, or nil if ABI is empty.
+func NewLogDecoder(abiJSON string) (LogDecoder, error) {
+	if len(abiJSON) == 0 {
+		return nil, nil
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, errors.NewStackedError(err, "get abi parser failed")
+	}
+
+	decoder := make(LogDecoder)
+	for _, event := range parsed.Events {
+		decoder[event.Id()] = event
+	}
+
+	return decoder, nil
+}
+
+// decode decodes the event arguments of the specified log. Note, the log is left
+// undecoded if the event not found in ABI or failed to unpack the log data, since
+// different contracts may define events with the same signature.
+func (decoder LogDecoder) decode(log *FilteredLog) {
+	if len(decoder) == 0 || len(log.Topics) == 0 {
+		return
+	}
+
+	event, found := decoder[log.Topics[0]]
+	if !found {
+		return
+	}
+
+	if args, err := event.Inputs.UnpackValues(log.Data); err == nil {
+		log.Event, log.Args = event.Name, args
+	}
+}
+
+// BlockLogs returns the logs that match the filter in the block of the specified hash and height.
+func BlockLogs(bcStore store.BlockchainStore, hash common.Hash, height uint64, filter *LogFilter, decoder LogDecoder) ([]*FilteredLog, error) {
+	// check receipts directly if bloom not found
+	if bloom, err := bcStore.GetBlockBloom(hash); err == nil && !filter.matchBloom(&bloom) {
+		return nil, nil
+	}
+
+	receipts, err := bcStore.GetReceiptsByBlockHash(hash)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to get receipts by block hash %v", hash.Hex())
+	}
+
+	var logs []*FilteredLog
+	logIndex := uint(0)
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			if filter.matchLog(log) {
+				// copy the log to not modify the receipts that may be shared, e.g. cached in store
+				copied := *log
+				copied.BlockNumber = height
+				filtered := &FilteredLog{
+					Log:       &copied,
+					BlockHash: hash,
+					TxHash:    receipt.TxHash,
+					LogIndex:  logIndex,
+				}
+
+				decoder.decode(filtered)
+				logs = append(logs, filtered)
+			}
+
+			logIndex++
+		}
+	}
+
+	return logs, nil
+}
+
+// FilterLogs returns the logs that match the filter in the canonical chain with the specified HEAD height.
+func FilterLogs(bcStore store.BlockchainStore, head uint64, filter *LogFilter) ([]*FilteredLog, error) {
+	from, to, err := filter.blockRange(head)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterLogs(bcStore, from, to, filter)
+}
+
+// filterLogs returns the logs that match the filter in canonical blocks of height range [from, to].
+func filterLogs(bcStore store.BlockchainStore, from, to uint64, filter *LogFilter) ([]*FilteredLog, error) {
+	decoder, err := NewLogDecoder(filter.AbiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// genesis block has no txs and receipts
+	if from == 0 {
+		from = 1
+	}
+
+	logs := make([]*FilteredLog, 0)
+	for height := from; height <= to; height++ {
+		hash, err := bcStore.GetBlockHash(height)
+		if err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get block hash by height %v", height)
+		}
+
+		blockLogs, err := BlockLogs(bcStore, hash, height, filter, decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, blockLogs...)
+	}
+
+	return logs, nil
+}
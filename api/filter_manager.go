@@ -0,0 +1,326 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/event"
+	"github.com/seeleteam/go-seele/log"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+const (
+	// filterTimeout is the duration that a filter is uninstalled automatically if not polled.
+	filterTimeout = 5 * time.Minute
+
+	// maxFilterReorgDepth is the max number of recently polled blocks tracked by a filter
+	// to rewind when these blocks are removed from the canonical chain.
+	maxFilterReorgDepth = 128
+
+	// maxFilterPendingTxs is the max number of pending tx hashes buffered by a filter between
+	// polls, and new tx hashes are dropped if the filter is not polled in time.
+	maxFilterPendingTxs = 4096
+)
+
+var errFilterNotFound = errors.New("filter not found")
+
+type filterKind byte
+
+const (
+	blockFilter filterKind = iota
+	logFilter
+	pendingTxFilter
+)
+
+// pollFilter is a filter installed on server side, whose changes are polled by client.
+type pollFilter struct {
+	kind     filterKind
+	criteria *LogFilter // only for log filter
+	decoder  LogDecoder
+	lastPoll time.Time
+
+	height   uint64                 // height of the last canonical block polled
+	polled   map[common.Hash]uint64 // recently polled canonical blocks, block hash => height
+	reverted []*FilteredLog         // logs removed from the canonical chain but not polled yet
+	txHashes []common.Hash          // pending txs not polled yet
+}
+
+// filterManager manages the filters installed by client, and accumulates the chain
+// and txpool changes for the filters until polled or expired.
+type filterManager struct {
+	chain Chain
+	log   *log.SeeleLog
+
+	lock    sync.Mutex
+	filters map[rpc.ID]*pollFilter
+
+	listenOnce sync.Once
+}
+
+func newFilterManager(chain Chain, log *log.SeeleLog) *filterManager {
+	return &filterManager{
+		chain:   chain,
+		log:     log,
+		filters: make(map[rpc.ID]*pollFilter),
+	}
+}
+
+// install installs a filter of the specified kind, and the criteria is only for log filter.
+func (fm *filterManager) install(kind filterKind, criteria *LogFilter) (rpc.ID, error) {
+	f := &pollFilter{
+		kind:     kind,
+		criteria: criteria,
+		lastPoll: time.Now(),
+		height:   fm.chain.CurrentHeader().Height,
+		polled:   make(map[common.Hash]uint64),
+	}
+
+	if criteria != nil {
+		decoder, err := NewLogDecoder(criteria.AbiJSON)
+		if err != nil {
+			return "", err
+		}
+
+		f.decoder = decoder
+	}
+
+	// listeners are registered until the first filter installed, since the API
+	// may be created more than once while only the one registered in RPC is used.
+	fm.listenOnce.Do(func() {
+		fm.chain.GetHeadRollbackEventManager().AddListener(fm.onHeadRollback)
+		event.TransactionInsertedEventManager.AddListener(fm.onTxInserted)
+	})
+
+	id := rpc.NewID()
+
+	fm.lock.Lock()
+	defer fm.lock.Unlock()
+
+	fm.expire(time.Now())
+	fm.filters[id] = f
+
+	return id, nil
+}
+
+// uninstall removes the filter of the specified id, and returns false if not found.
+func (fm *filterManager) uninstall(id rpc.ID) bool {
+	fm.lock.Lock()
+	defer fm.lock.Unlock()
+
+	_, found := fm.filters[id]
+	delete(fm.filters, id)
+
+	return found
+}
+
+// get returns the filter of the specified id, and resets its expiration.
+func (fm *filterManager) get(id rpc.ID) (*pollFilter, error) {
+	now := time.Now()
+	fm.expire(now)
+
+	f := fm.filters[id]
+	if f == nil {
+		return nil, errFilterNotFound
+	}
+
+	f.lastPoll = now
+
+	return f, nil
+}
+
+// expire uninstalls the filters that not polled within timeout.
+// Filters expire lazily when filters accessed or events received, so no
+// goroutine is required to clean up filters.
+func (fm *filterManager) expire(now time.Time) {
+	for id, f := range fm.filters {
+		if now.Sub(f.lastPoll) > filterTimeout {
+			delete(fm.filters, id)
+			fm.log.Debug("filter %v expired", id)
+		}
+	}
+}
+
+// changes returns the changes of the specified filter since the last poll:
+// block hashes for block filter, logs for log filter, and tx hashes for pending tx filter.
+func (fm *filterManager) changes(id rpc.ID) (interface{}, error) {
+	// get the HEAD before lock to avoid dead lock with event listeners
+	head := fm.chain.CurrentHeader().Height
+
+	fm.lock.Lock()
+	defer fm.lock.Unlock()
+
+	f, err := fm.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch f.kind {
+	case blockFilter:
+		hashes := make([]common.Hash, 0)
+		err = fm.pollBlocks(f, head, func(hash common.Hash, height uint64) error {
+			hashes = append(hashes, hash)
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return hashes, nil
+	case logFilter:
+		logs := append(make([]*FilteredLog, 0), f.reverted...)
+		err = fm.pollBlocks(f, head, func(hash common.Hash, height uint64) error {
+			blockLogs, err := BlockLogs(fm.chain.GetStore(), hash, height, f.criteria, f.decoder)
+			logs = append(logs, blockLogs...)
+			return err
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		f.reverted = nil
+
+		return logs, nil
+	default:
+		hashes := append(make([]common.Hash, 0), f.txHashes...)
+		f.txHashes = nil
+
+		return hashes, nil
+	}
+}
+
+// pollBlocks visits the canonical blocks after the last polled block in ascending order, at
+// most maxLogFilterBlockRange blocks in one poll, and the rest are visited in next poll.
+// Note, the filter is not updated if failed to visit any block, so that the blocks could be
+// polled again.
+func (fm *filterManager) pollBlocks(f *pollFilter, head uint64, visit func(hash common.Hash, height uint64) error) error {
+	bcStore := fm.chain.GetStore()
+	to := head
+	if head > f.height+maxLogFilterBlockRange {
+		to = f.height + maxLogFilterBlockRange
+	}
+
+	var hashes []common.Hash
+	for height := f.height + 1; height <= to; height++ {
+		hash, err := bcStore.GetBlockHash(height)
+		if err != nil {
+			return errors.NewStackedErrorf(err, "failed to get block hash by height %v", height)
+		}
+
+		if err = visit(hash, height); err != nil {
+			return err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	for i, hash := range hashes {
+		f.polled[hash] = f.height + uint64(i) + 1
+	}
+
+	if len(hashes) > 0 {
+		f.height = to
+	}
+
+	for hash, height := range f.polled {
+		if height+maxFilterReorgDepth <= f.height {
+			delete(f.polled, hash)
+		}
+	}
+
+	return nil
+}
+
+// logs returns all the logs that match the criteria of the specified log filter.
+func (fm *filterManager) logs(id rpc.ID) ([]*FilteredLog, error) {
+	fm.lock.Lock()
+	f, err := fm.get(id)
+	fm.lock.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if f.kind != logFilter {
+		return nil, errFilterNotFound
+	}
+
+	return FilterLogs(fm.chain.GetStore(), fm.chain.CurrentHeader().Height, f.criteria)
+}
+
+// onHeadRollback rewinds the block and log filters that have polled the blocks removed
+// from the canonical chain, so that the new canonical blocks will be polled again. Besides,
+// the polled logs in removed blocks are returned as removed logs in the next poll.
+func (fm *filterManager) onHeadRollback(e event.Event) {
+	hashes := e.([]common.Hash)
+
+	fm.lock.Lock()
+	defer fm.lock.Unlock()
+
+	fm.expire(time.Now())
+
+	for _, f := range fm.filters {
+		if f.kind == pendingTxFilter {
+			continue
+		}
+
+		for _, hash := range hashes {
+			height, found := f.polled[hash]
+			if !found {
+				continue
+			}
+
+			delete(f.polled, hash)
+			if height <= f.height {
+				f.height = height - 1
+			}
+
+			if f.kind == logFilter {
+				fm.revertLogs(f, hash, height)
+			}
+		}
+	}
+}
+
+func (fm *filterManager) revertLogs(f *pollFilter, hash common.Hash, height uint64) {
+	logs, err := BlockLogs(fm.chain.GetStore(), hash, height, f.criteria, f.decoder)
+	if err != nil {
+		fm.log.Debug("failed to get logs of removed block %v, %v", hash.Hex(), err)
+		return
+	}
+
+	for _, log := range logs {
+		log.Removed = true
+	}
+
+	f.reverted = append(f.reverted, logs...)
+}
+
+// onTxInserted buffers the tx hash for all pending tx filters.
+func (fm *filterManager) onTxInserted(e event.Event) {
+	tx := e.(*types.Transaction)
+
+	fm.lock.Lock()
+	defer fm.lock.Unlock()
+
+	fm.expire(time.Now())
+
+	for _, f := range fm.filters {
+		if f.kind != pendingTxFilter {
+			continue
+		}
+
+		if len(f.txHashes) < maxFilterPendingTxs {
+			f.txHashes = append(f.txHashes, tx.Hash)
+		}
+	}
+}
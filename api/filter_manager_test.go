@@ -0,0 +1,130 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestFilterManager returns a filter manager with chain of NewTestFilterChain, and HEAD at height 2.
+func newTestFilterManager() (*filterManager, *TestFilterChain, func()) {
+	chain, dispose := NewTestFilterChain(2)
+	return newFilterManager(chain, log.GetLogger("filter")), chain, dispose
+}
+
+func Test_FilterManager_BlockAndLogFilters(t *testing.T) {
+	fm, chain, dispose := newTestFilterManager()
+	defer dispose()
+
+	blockID, err := fm.install(blockFilter, nil)
+	assert.Equal(t, err, nil)
+	logID, err := fm.install(logFilter, &LogFilter{ToBlock: -1, Addresses: []common.Address{TestFilterAddr1}})
+	assert.Equal(t, err, nil)
+
+	// no changes before new blocks
+	hashes, err := fm.changes(blockID)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, hashes, make([]common.Hash, 0))
+
+	// new blocks 3 and 4
+	chain.SetHead(4)
+	hash3, _ := chain.GetStore().GetBlockHash(3)
+	hash4, _ := chain.GetStore().GetBlockHash(4)
+
+	hashes, err = fm.changes(blockID)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, hashes, []common.Hash{hash3, hash4})
+
+	logs, err := fm.changes(logID)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(logs.([]*FilteredLog)), 1)
+	assert.Equal(t, logs.([]*FilteredLog)[0].BlockHash, hash3)
+
+	// delta only since the last poll
+	hashes, _ = fm.changes(blockID)
+	assert.Equal(t, len(hashes.([]common.Hash)), 0)
+
+	// all logs of criteria in [1, HEAD]
+	logs, err = fm.logs(logID)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(logs.([]*FilteredLog)), 2)
+
+	_, err = fm.logs(blockID)
+	assert.Equal(t, err, errFilterNotFound)
+}
+
+func Test_FilterManager_Reorg(t *testing.T) {
+	fm, chain, dispose := newTestFilterManager()
+	defer dispose()
+
+	blockID, _ := fm.install(blockFilter, nil)
+	logID, _ := fm.install(logFilter, &LogFilter{Addresses: []common.Address{TestFilterAddr1}})
+	chain.GetHeadRollbackEventManager().AddListener(fm.onHeadRollback)
+
+	chain.SetHead(4)
+	fm.changes(blockID)
+	fm.changes(logID)
+
+	// fork at block 2, and the new canonical block 3' becomes HEAD
+	hash3 := chain.GetBlock(3).HeaderHash
+	reverted := chain.Fork(2, &types.Log{Address: TestFilterAddr1})
+	header := chain.CurrentHeader()
+	chain.GetHeadRollbackEventManager().Fire(reverted)
+
+	hashes, err := fm.changes(blockID)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, hashes, []common.Hash{header.Hash()})
+
+	logs, err := fm.changes(logID)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(logs.([]*FilteredLog)), 2)
+	assert.Equal(t, logs.([]*FilteredLog)[0].BlockHash, hash3)
+	assert.Equal(t, logs.([]*FilteredLog)[0].Removed, true)
+	assert.Equal(t, logs.([]*FilteredLog)[1].BlockHash, header.Hash())
+	assert.Equal(t, logs.([]*FilteredLog)[1].Removed, false)
+}
+
+func Test_FilterManager_PendingTxsAndExpiration(t *testing.T) {
+	fm, _, dispose := newTestFilterManager()
+	defer dispose()
+
+	txID, _ := fm.install(pendingTxFilter, nil)
+	blockID, _ := fm.install(blockFilter, nil)
+
+	tx := types.NewTestTransaction()
+	fm.onTxInserted(tx)
+
+	hashes, err := fm.changes(txID)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, hashes, []common.Hash{tx.Hash})
+
+	hashes, _ = fm.changes(txID)
+	assert.Equal(t, len(hashes.([]common.Hash)), 0)
+
+	// invalid ABI
+	_, err = fm.install(logFilter, &LogFilter{AbiJSON: "invalid"})
+	assert.Error(t, err)
+
+	// uninstalled
+	assert.Equal(t, fm.uninstall(blockID), true)
+	assert.Equal(t, fm.uninstall(blockID), false)
+	_, err = fm.changes(blockID)
+	assert.Equal(t, err, errFilterNotFound)
+
+	// expired if not polled in time
+	fm.lock.Lock()
+	fm.expire(time.Now().Add(filterTimeout + time.Second))
+	fm.lock.Unlock()
+
+	_, err = fm.changes(txID)
+	assert.Equal(t, err, errFilterNotFound)
+}
@@ -0,0 +1,180 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LogFilter_BlockRange(t *testing.T) {
+	cases := []struct {
+		fromBlock, toBlock int64
+		from, to           uint64
+		err                error
+	}{
+		{-1, -1, 10, 10, nil},
+		{3, -1, 3, 10, nil},
+		{3, 5, 3, 5, nil},
+		{3, 20, 3, 10, nil},
+		{20, -1, 20, 10, nil},
+		{5, 3, 0, 0, errInvalidLogFilterRange},
+		{-1, 5, 0, 0, errInvalidLogFilterRange},
+	}
+
+	for _, c := range cases {
+		filter := LogFilter{FromBlock: c.fromBlock, ToBlock: c.toBlock}
+		from, to, err := filter.blockRange(10)
+		assert.Equal(t, err, c.err)
+		assert.Equal(t, from, c.from)
+		assert.Equal(t, to, c.to)
+	}
+
+	filter := LogFilter{FromBlock: 0, ToBlock: -1}
+	_, _, err := filter.blockRange(maxLogFilterBlockRange)
+	assert.Equal(t, err, errLogFilterRangeTooLarge)
+}
+
+func Test_LogFilter_MatchLog(t *testing.T) {
+	log := &types.Log{Address: TestFilterAddr1, Topics: []common.Hash{TestFilterTopicA, TestFilterTopicB}}
+
+	cases := []struct {
+		filter  LogFilter
+		matched bool
+	}{
+		{LogFilter{}, true},
+		{LogFilter{Addresses: []common.Address{TestFilterAddr2, TestFilterAddr1}}, true},
+		{LogFilter{Addresses: []common.Address{TestFilterAddr2}}, false},
+		{LogFilter{Topics: [][]common.Hash{{TestFilterTopicA}}}, true},
+		{LogFilter{Topics: [][]common.Hash{{TestFilterTopicB}}}, false},
+		{LogFilter{Topics: [][]common.Hash{nil, {TestFilterTopicB}}}, true},
+		{LogFilter{Topics: [][]common.Hash{{TestFilterTopicC, TestFilterTopicA}, {TestFilterTopicC, TestFilterTopicB}}}, true},
+		{LogFilter{Topics: [][]common.Hash{{TestFilterTopicA}, {TestFilterTopicC}}}, false},
+		{LogFilter{Topics: [][]common.Hash{nil, nil, nil}}, false},
+	}
+
+	for i, c := range cases {
+		assert.Equal(t, c.filter.matchLog(log), c.matched, "case %v", i)
+	}
+}
+
+func Test_FilterLogs(t *testing.T) {
+	chain, dispose := NewTestFilterChain(4)
+	defer dispose()
+	bcStore := chain.GetStore()
+
+	// all logs
+	logs, err := filterLogs(bcStore, 0, 4, &LogFilter{})
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(logs), 4)
+	for i, log := range logs {
+		hash, _ := bcStore.GetBlockHash(uint64(i + 1))
+		assert.Equal(t, log.BlockNumber, uint64(i+1))
+		assert.Equal(t, log.BlockHash, hash)
+		assert.Equal(t, log.TxHash, common.BigToHash(big.NewInt(int64(i+1))))
+		assert.Equal(t, log.TxIndex, uint(1))
+		assert.Equal(t, log.LogIndex, uint(0))
+	}
+
+	// filter by address
+	logs, err = filterLogs(bcStore, 1, 4, &LogFilter{Addresses: []common.Address{TestFilterAddr2}})
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(logs), 2)
+	assert.Equal(t, logs[0].BlockNumber, uint64(2))
+	assert.Equal(t, logs[1].BlockNumber, uint64(4))
+
+	// filter by topics in range
+	logs, err = filterLogs(bcStore, 2, 4, &LogFilter{Topics: [][]common.Hash{nil, {TestFilterTopicB}}})
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(logs), 1)
+	assert.Equal(t, logs[0].BlockNumber, uint64(3))
+
+	// no logs matched
+	logs, err = filterLogs(bcStore, 1, 4, &LogFilter{Addresses: []common.Address{TestFilterAddr1}, Topics: [][]common.Hash{{TestFilterTopicC}}})
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(logs), 0)
+
+	// invalid ABI
+	_, err = filterLogs(bcStore, 1, 4, &LogFilter{AbiJSON: "invalid"})
+	assert.Error(t, err)
+}
+
+func Test_BlockLogs_Copied(t *testing.T) {
+	bcStore := store.NewMemStore()
+	header := &types.BlockHeader{Height: 5, Difficulty: big.NewInt(1), CreateTimestamp: big.NewInt(1)}
+	log := &types.Log{Address: TestFilterAddr1}
+	assert.Nil(t, bcStore.PutBlockHeader(header.Hash(), header, big.NewInt(1), true))
+	assert.Nil(t, bcStore.PutReceipts(header.Hash(), []*types.Receipt{&types.Receipt{Logs: []*types.Log{log}}}))
+
+	logs, err := BlockLogs(bcStore, header.Hash(), header.Height, &LogFilter{}, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(logs), 1)
+	assert.Equal(t, logs[0].BlockNumber, uint64(5))
+
+	// the log in store receipts is not modified
+	assert.Equal(t, log.BlockNumber, uint64(0))
+	logs[0].Data = []byte{1}
+	assert.Equal(t, log.Data, []byte(nil))
+}
+
+func Test_FilteredLog_JSON(t *testing.T) {
+	log := &FilteredLog{
+		Log: &types.Log{
+			Address:     TestFilterAddr1,
+			Topics:      []common.Hash{TestFilterTopicA, TestFilterTopicB},
+			Data:        []byte{1, 2, 3},
+			BlockNumber: 5,
+			TxIndex:     2,
+		},
+		BlockHash: common.StringToHash("block"),
+		TxHash:    common.StringToHash("tx"),
+		LogIndex:  1,
+		Removed:   true,
+	}
+
+	encoded, err := json.Marshal(log)
+	assert.Equal(t, err, nil)
+
+	var decoded FilteredLog
+	assert.Equal(t, json.Unmarshal(encoded, &decoded), nil)
+	assert.Equal(t, &decoded, log)
+}
+
+func Test_LogDecoder(t *testing.T) {
+	abiJSON := `[{ "anonymous": false, "inputs": [ { "indexed": false, "name": "", "type": "uint256" }, { "indexed": false, "name": "", "type": "uint256" } ], "name": "getX", "type": "event" }]`
+	decoder, err := NewLogDecoder(abiJSON)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(decoder), 1)
+
+	var eventID common.Hash
+	for id := range decoder {
+		eventID = id
+	}
+
+	data := append(common.BigToHash(big.NewInt(1)).Bytes(), common.BigToHash(big.NewInt(2)).Bytes()...)
+	log := &FilteredLog{Log: &types.Log{Topics: []common.Hash{eventID}, Data: data}}
+	decoder.decode(log)
+	assert.Equal(t, log.Event, "getX")
+	assert.Equal(t, log.Args, []interface{}{big.NewInt(1), big.NewInt(2)})
+
+	// event not found in ABI
+	log = &FilteredLog{Log: &types.Log{Topics: []common.Hash{TestFilterTopicA}, Data: data}}
+	decoder.decode(log)
+	assert.Equal(t, log.Event, "")
+	assert.Equal(t, log.Args, nil)
+
+	// ABI is optional
+	decoder, err = NewLogDecoder("")
+	assert.Equal(t, err, nil)
+	decoder.decode(log)
+	assert.Equal(t, log.Event, "")
+}
@@ -0,0 +1,117 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package api
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/crypto"
+)
+
+// ProofResult is the response of seele_getProof, which includes the account state and the
+// storage values with merkle proofs against the state root of block.
+type ProofResult struct {
+	Address      common.Address       `json:"address"`
+	BlockHash    common.Hash          `json:"blockHash"`
+	StateHash    common.Hash          `json:"stateHash"`
+	Balance      *big.Int             `json:"balance"`
+	Nonce        uint64               `json:"nonce"`
+	CodeHash     common.Hash          `json:"codeHash"`
+	AccountProof []string             `json:"accountProof"`
+	StorageProof []StorageProofResult `json:"storageProof"`
+}
+
+// StorageProofResult is the storage value with merkle proof of a storage key.
+type StorageProofResult struct {
+	Key   common.Hash `json:"key"`
+	Value string      `json:"value"` // hex encoded, and "0x" if not set
+	Proof []string    `json:"proof"`
+}
+
+// EncodeProof encodes the merkle proof into hex encoded trie nodes, which are sorted
+// to be deterministic.
+func EncodeProof(proof map[string][]byte) []string {
+	nodes := make([]string, 0, len(proof))
+	for _, node := range proof {
+		nodes = append(nodes, hexutil.BytesToHex(node))
+	}
+
+	sort.Strings(nodes)
+
+	return nodes
+}
+
+// DecodeProof decodes the hex encoded trie nodes into merkle proof, which is keyed by node hash.
+func DecodeProof(nodes []string) (map[string][]byte, error) {
+	proof := make(map[string][]byte)
+	for _, hex := range nodes {
+		node, err := hexutil.HexToBytes(hex)
+		if err != nil {
+			return nil, errors.NewStackedError(err, "invalid proof node")
+		}
+
+		proof[string(crypto.HashBytes(node).Bytes())] = node
+	}
+
+	return proof, nil
+}
+
+// Verify verifies the account state and storage values with merkle proofs against the
+// specified state root, which should be retrieved from a trusted block header.
+func (result *ProofResult) Verify(stateHash common.Hash) error {
+	if result.StateHash != stateHash {
+		return fmt.Errorf("state hash mismatch, expected %v, got %v", stateHash.Hex(), result.StateHash.Hex())
+	}
+
+	proof, err := DecodeProof(result.AccountProof)
+	if err != nil {
+		return err
+	}
+
+	account, err := state.VerifyAccountProof(stateHash, result.Address, proof)
+	if err != nil {
+		return errors.NewStackedError(err, "failed to verify account proof")
+	}
+
+	if account == nil {
+		account = &state.ProvedAccount{Balance: big.NewInt(0)}
+	}
+
+	if result.Balance == nil || account.Balance.Cmp(result.Balance) != 0 {
+		return fmt.Errorf("balance mismatch, proved %v, got %v", account.Balance, result.Balance)
+	}
+
+	if account.Nonce != result.Nonce {
+		return fmt.Errorf("nonce mismatch, proved %v, got %v", account.Nonce, result.Nonce)
+	}
+
+	if account.CodeHash != result.CodeHash {
+		return fmt.Errorf("code hash mismatch, proved %v, got %v", account.CodeHash.Hex(), result.CodeHash.Hex())
+	}
+
+	for _, storage := range result.StorageProof {
+		if proof, err = DecodeProof(storage.Proof); err != nil {
+			return err
+		}
+
+		value, err := state.VerifyStorageProof(stateHash, result.Address, storage.Key, proof)
+		if err != nil {
+			return errors.NewStackedErrorf(err, "failed to verify storage proof of key %v", storage.Key.Hex())
+		}
+
+		if hex := hexutil.BytesToHex(value); hex != storage.Value {
+			return fmt.Errorf("storage value mismatch of key %v, proved %v, got %v", storage.Key.Hex(), hex, storage.Value)
+		}
+	}
+
+	return nil
+}
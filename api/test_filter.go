@@ -0,0 +1,147 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package api
+
+import (
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/seeleteam/go-seele/event"
+)
+
+var (
+	TestFilterAddr1  = common.BytesToAddress([]byte("contract 1"))
+	TestFilterAddr2  = common.BytesToAddress([]byte("contract 2"))
+	TestFilterTopicA = common.StringToHash("topic A")
+	TestFilterTopicB = common.StringToHash("topic B")
+	TestFilterTopicC = common.StringToHash("topic C")
+)
+
+// TestFilterChain is the chain for filter tests, which has canonical blocks of height [1, 4],
+// and block i has a receipt with log of contract 1 and topics [A, B] if i is odd, or contract 2
+// and topics [C] if i is even.
+type TestFilterChain struct {
+	bcStore        store.BlockchainStore
+	head           *types.BlockHeader
+	rollbackEvents *event.EventManager
+}
+
+// NewTestFilterChain returns a TestFilterChain with HEAD at the specified height, and a func to dispose it.
+func NewTestFilterChain(head uint64) (*TestFilterChain, func()) {
+	db, dispose := leveldb.NewTestDatabase()
+	chain := &TestFilterChain{
+		bcStore:        store.NewBlockchainDatabase(db),
+		rollbackEvents: event.NewEventManager(),
+	}
+
+	var parent *types.BlockHeader
+	for height := uint64(1); height <= 4; height++ {
+		log := &types.Log{Address: TestFilterAddr1, Topics: []common.Hash{TestFilterTopicA, TestFilterTopicB}, TxIndex: 1}
+		if height%2 == 0 {
+			log = &types.Log{Address: TestFilterAddr2, Topics: []common.Hash{TestFilterTopicC}, TxIndex: 1}
+		}
+
+		parent = chain.putBlock(parent, height, 1, log)
+	}
+
+	chain.SetHead(head)
+
+	return chain, dispose
+}
+
+func (chain *TestFilterChain) CurrentHeader() *types.BlockHeader        { return chain.head }
+func (chain *TestFilterChain) GetCurrentState() (*state.Statedb, error) { return nil, nil }
+func (chain *TestFilterChain) GetState(blockHash common.Hash) (*state.Statedb, error) {
+	return nil, nil
+}
+func (chain *TestFilterChain) GetStore() store.BlockchainStore { return chain.bcStore }
+func (chain *TestFilterChain) GetHeadRollbackEventManager() *event.EventManager {
+	return chain.rollbackEvents
+}
+
+// SetHead updates the HEAD to the canonical block of the specified height.
+func (chain *TestFilterChain) SetHead(height uint64) {
+	chain.head = chain.GetBlock(height).Header
+}
+
+// GetBlock returns the canonical block of the specified height without body.
+func (chain *TestFilterChain) GetBlock(height uint64) *types.Block {
+	hash, err := chain.bcStore.GetBlockHash(height)
+	if err != nil {
+		panic(err)
+	}
+
+	header, err := chain.bcStore.GetBlockHeader(hash)
+	if err != nil {
+		panic(err)
+	}
+
+	return &types.Block{HeaderHash: hash, Header: header}
+}
+
+// Fork appends a new branch of blocks with the specified logs to the canonical block of the ancestor
+// height, and the new branch becomes the canonical chain with the last block as HEAD. Returns the
+// hashes of the blocks reverted from the canonical chain in ascending order of height.
+func (chain *TestFilterChain) Fork(ancestor uint64, logs ...*types.Log) []common.Hash {
+	var reverted []common.Hash
+	for height := ancestor + 1; height <= chain.head.Height; height++ {
+		reverted = append(reverted, chain.GetBlock(height).HeaderHash)
+	}
+
+	parent := chain.GetBlock(ancestor).Header
+	for _, log := range logs {
+		parent = chain.putBlock(parent, parent.Height+1, 2, log)
+	}
+
+	for height := parent.Height + 1; height <= chain.head.Height; height++ {
+		if _, err := chain.bcStore.DeleteBlockHash(height); err != nil {
+			panic(err)
+		}
+	}
+
+	chain.head = parent
+
+	return reverted
+}
+
+// putBlock puts a canonical block header with receipts of a reward tx and a tx with the specified log.
+func (chain *TestFilterChain) putBlock(parent *types.BlockHeader, height uint64, difficulty int64, log *types.Log) *types.BlockHeader {
+	header := &types.BlockHeader{
+		Height:          height,
+		Difficulty:      big.NewInt(difficulty),
+		CreateTimestamp: big.NewInt(difficulty),
+	}
+
+	td := big.NewInt(difficulty)
+	if parent != nil {
+		header.PreviousBlockHash = parent.Hash()
+		parentTD, err := chain.bcStore.GetBlockTotalDifficulty(header.PreviousBlockHash)
+		if err != nil {
+			panic(err)
+		}
+
+		td.Add(td, parentTD)
+	}
+
+	receipts := []*types.Receipt{
+		&types.Receipt{TxHash: common.StringToHash("reward")},
+		&types.Receipt{TxHash: common.BigToHash(new(big.Int).SetUint64(height)), Logs: []*types.Log{log}},
+	}
+
+	if err := chain.bcStore.PutBlockHeader(header.Hash(), header, td, true); err != nil {
+		panic(err)
+	}
+
+	if err := chain.bcStore.PutReceipts(header.Hash(), receipts); err != nil {
+		panic(err)
+	}
+
+	return header
+}
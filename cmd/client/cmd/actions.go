@@ -0,0 +1,420 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/seeleteam/go-seele/accounts/abi"
+	"github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/seeleclient"
+)
+
+// parseHash returns the hash in hex, or empty hash if not specified, e.g. to specify block by height.
+func parseHash(hexHash string) (common.Hash, error) {
+	if len(hexHash) == 0 {
+		return common.EmptyHash, nil
+	}
+
+	hash, err := common.HexToHash(hexHash)
+	if err != nil {
+		return common.EmptyHash, fmt.Errorf("invalid hash: %s", err)
+	}
+
+	return hash, nil
+}
+
+// parseAddress returns the address in hex, or empty address if not specified.
+func parseAddress(hexAddress string) (common.Address, error) {
+	if len(hexAddress) == 0 {
+		return common.EmptyAddress, nil
+	}
+
+	address, err := common.HexToAddress(hexAddress)
+	if err != nil {
+		return common.EmptyAddress, fmt.Errorf("invalid address: %s", err)
+	}
+
+	return address, nil
+}
+
+// parseAccountAndBlock returns the account and block hash in flags.
+func parseAccountAndBlock() (common.Address, common.Hash, error) {
+	account, err := parseAddress(accountValue)
+	if err != nil {
+		return common.EmptyAddress, common.EmptyHash, err
+	}
+
+	blockHash, err := parseHash(hashValue)
+	if err != nil {
+		return common.EmptyAddress, common.EmptyHash, err
+	}
+
+	return account, blockHash, nil
+}
+
+func printableTxs(txs []*types.Transaction) []map[string]interface{} {
+	output := make([]map[string]interface{}, len(txs))
+	for i, tx := range txs {
+		output[i] = api.PrintableOutputTx(tx)
+	}
+
+	return output
+}
+
+func getBalance(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	account, blockHash, err := parseAccountAndBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := client.GetBalance(ctx, account, blockHash, heightValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetBalanceResponse{Account: account, Balance: balance}, nil
+}
+
+func getAccountNonce(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	account, blockHash, err := parseAccountAndBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetAccountNonce(ctx, account, blockHash, heightValue)
+}
+
+func getBlockHeight(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetBlockHeight(ctx)
+}
+
+func getBlock(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	blockHash, err := parseHash(hashValue)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := client.GetBlock(ctx, blockHash, heightValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.PrintableOutputBlock(block.Block, fulltxValue, block.TotalDifficulty)
+}
+
+func getBlockTransactionCount(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	blockHash, err := parseHash(hashValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetBlockTransactionCount(ctx, blockHash, heightValue)
+}
+
+func getTransactionByBlockIndex(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	blockHash, err := parseHash(hashValue)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := client.GetTransactionByBlockIndex(ctx, blockHash, heightValue, indexValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.PrintableOutputTx(tx), nil
+}
+
+// accountTxsCall returns the call to get txs of the account in flag via the typed client method get.
+func accountTxsCall(get func(*seeleclient.Client, context.Context, common.Address, common.Hash, int64) ([]*types.Transaction, error)) clientCall {
+	return func(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+		account, blockHash, err := parseAccountAndBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		txs, err := get(client, ctx, account, blockHash, heightValue)
+		if err != nil {
+			return nil, err
+		}
+
+		return printableTxs(txs), nil
+	}
+}
+
+func getAccountHistory(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	account, err := parseAddress(accountValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetAccountHistory(ctx, account, fromHeightValue, toHeightValue, offsetValue, limitValue)
+}
+
+func getBlockTransactions(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	blockHash, err := parseHash(hashValue)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := client.GetBlockTransactions(ctx, blockHash, heightValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return printableTxs(txs), nil
+}
+
+func getTransactionByHash(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	txHash, err := common.HexToHash(hashValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %s", err)
+	}
+
+	info, err := client.GetTransactionByHash(ctx, txHash)
+	if err != nil || info == nil {
+		return nil, err
+	}
+
+	output := map[string]interface{}{
+		"transaction": api.PrintableOutputTx(info.Transaction),
+		"status":      info.Status,
+	}
+
+	if info.Debt != nil {
+		output["debt"] = info.Debt
+	}
+
+	if info.Status == "block" {
+		output["blockHash"] = info.BlockHash.Hex()
+		output["blockHeight"] = info.BlockHeight
+		output["txIndex"] = info.TxIndex
+	}
+
+	return output, nil
+}
+
+func getGasPrice(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	txHash, err := common.HexToHash(hashValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %s", err)
+	}
+
+	info, err := client.GetTransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if info == nil {
+		return nil, fmt.Errorf("transaction %v not found", txHash.Hex())
+	}
+
+	return info.Transaction.Data.GasPrice, nil
+}
+
+func getReceipt(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	txHash, err := common.HexToHash(hashValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %s", err)
+	}
+
+	receipt, err := client.GetReceiptByTxHash(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := api.PrintableReceipt(receipt)
+	if err != nil || len(abiFile) == 0 || len(receipt.Logs) == 0 {
+		return output, err
+	}
+
+	// decode logs by ABI if specified
+	abiJSON, err := readABIFile(abiFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read abi file, err: %s", err)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the abi, err: %s", err)
+	}
+
+	logs := make([]string, len(receipt.Logs))
+	for i, log := range receipt.Logs {
+		if logs[i], err = api.PrintableLogByABI(log, parsed); err != nil {
+			return nil, fmt.Errorf("failed to decode log by abi, err: %s", err)
+		}
+	}
+	output["logs"] = logs
+
+	return output, nil
+}
+
+func getPendingTransactions(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	txs, err := client.GetPendingTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return printableTxs(txs), nil
+}
+
+func getTxPoolContent(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	content, err := client.GetTxPoolContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make(map[string][]map[string]interface{})
+	for from, txs := range content {
+		output[from.Hex()] = printableTxs(txs)
+	}
+
+	return output, nil
+}
+
+func getTxPoolTxCount(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetTxPoolTxCount(ctx)
+}
+
+func getInfo(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetInfo(ctx)
+}
+
+func suggestGasPrice(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.SuggestGasPrice(ctx)
+}
+
+func getPriceHistogram(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetPriceHistogram(ctx)
+}
+
+func getPendingDebts(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetPendingDebts(ctx)
+}
+
+func getDebtByHash(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	debtHash, err := common.HexToHash(hashValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid debt hash: %s", err)
+	}
+
+	return client.GetDebtByHash(ctx, debtHash)
+}
+
+func dumpHeap(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.DumpHeap(ctx, dumpFileValue, gcBeforeDump)
+}
+
+func callContract(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	contract, err := common.HexToAddress(toValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract address: %s", err)
+	}
+
+	payload, err := hexutil.HexToBytes(payloadValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload, %s", err)
+	}
+
+	receipt, err := client.CallContract(ctx, contract, payload, heightValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.PrintableReceipt(receipt)
+}
+
+func getLogs(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	contract, err := parseAddress(contractValue)
+	if err != nil {
+		return nil, err
+	}
+
+	abiJSON := ""
+	if len(abiFile) > 0 {
+		if abiJSON, err = readABIFile(abiFile); err != nil {
+			return nil, fmt.Errorf("failed to read abi file, err: %s", err)
+		}
+	}
+
+	return client.GetLogs(ctx, heightValue, contract, abiJSON, eventName)
+}
+
+func getPeerCount(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetPeerCount(ctx)
+}
+
+func getPeersInfo(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetPeersInfo(ctx)
+}
+
+func getNetVersion(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetNetVersion(ctx)
+}
+
+func getNetworkID(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetNetworkID(ctx)
+}
+
+func getProtocolVersion(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.GetProtocolVersion(ctx)
+}
+
+func isListening(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.IsListening(ctx)
+}
+
+func minerStart(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.MinerStart(ctx)
+}
+
+func minerStop(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.MinerStop(ctx)
+}
+
+func minerSetThreads(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return nil, client.MinerSetThreads(ctx, int(threadsValue))
+}
+
+func minerSetCoinbase(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	coinbase, err := common.HexToAddress(coinbaseValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coinbase: %s", err)
+	}
+
+	return nil, client.MinerSetCoinbase(ctx, coinbase)
+}
+
+func minerGetCoinbase(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.MinerGetCoinbase(ctx)
+}
+
+func minerStatus(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.MinerStatus(ctx)
+}
+
+func minerGetHashrate(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.MinerGetHashrate(ctx)
+}
+
+func minerGetThreads(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.MinerGetThreads(ctx)
+}
+
+func minerGetWork(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.MinerGetWork(ctx)
+}
+
+func minerGetTarget(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+	return client.MinerGetTarget(ctx)
+}
@@ -7,11 +7,11 @@ package cmd
 
 import (
 	"github.com/seeleteam/go-seele/contract/system"
-	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seeleclient"
 )
 
 // createDomainName create a domain name
-func createDomainName(client *rpc.Client) (interface{}, interface{}, error) {
+func createDomainName(client *seeleclient.Client) (interface{}, interface{}, error) {
 	amountValue = "0"
 	if err := system.ValidateDomainName([]byte(nameValue)); err != nil {
 		return nil, nil, err
@@ -26,7 +26,7 @@ func createDomainName(client *rpc.Client) (interface{}, interface{}, error) {
 }
 
 // getDomainNameOwner get domain name owner
-func getDomainNameOwner(client *rpc.Client) (interface{}, interface{}, error) {
+func getDomainNameOwner(client *seeleclient.Client) (interface{}, interface{}, error) {
 	amountValue = "0"
 
 	if err := system.ValidateDomainName([]byte(nameValue)); err != nil {
@@ -6,44 +6,11 @@
 package cmd
 
 import (
-	"fmt"
+	"math"
 
-	"github.com/seeleteam/go-seele/common"
 	"github.com/urfave/cli"
 )
 
-type rpcFlag interface {
-	getValue() (interface{}, error)
-}
-
-type seeleAddressFlag struct {
-	cli.StringFlag
-}
-
-func (flag seeleAddressFlag) getValue() (interface{}, error) {
-	if val := *flag.Destination; len(val) > 0 {
-		return common.HexToAddress(val)
-	}
-
-	return common.EmptyAddress, nil
-}
-
-type abiFlag struct {
-	cli.StringFlag
-}
-
-func (flag abiFlag) getValue() (interface{}, error) {
-	if val := *flag.Destination; len(val) > 0 {
-		abiJSON, err := readABIFile(val)
-		if err != nil {
-			return "", fmt.Errorf("failed to read abi file, err: %s", err)
-		}
-		return abiJSON, nil
-	}
-
-	return "", nil
-}
-
 var (
 	addressValue string
 	addressFlag  = cli.StringFlag{
@@ -54,13 +21,11 @@ var (
 	}
 
 	accountValue string
-	accountFlag  = seeleAddressFlag{
-		StringFlag: cli.StringFlag{
-			Name:        "account",
-			Value:       "",
-			Usage:       "account address",
-			Destination: &accountValue,
-		},
+	accountFlag  = cli.StringFlag{
+		Name:        "account",
+		Value:       "",
+		Usage:       "account address",
+		Destination: &accountValue,
 	}
 
 	heightValue int64
@@ -125,8 +90,8 @@ var (
 	priceValue string
 	priceFlag  = cli.StringFlag{
 		Name:        "price",
-		Value:       "10",
-		Usage:       "transaction gas price in Fan",
+		Value:       "",
+		Usage:       "transaction gas price in Fan, suggested by node if not specified",
 		Destination: &priceValue,
 	}
 
@@ -147,12 +112,10 @@ var (
 	}
 
 	contractValue string
-	contractFlag  = seeleAddressFlag{
-		StringFlag: cli.StringFlag{
-			Name:        "contract",
-			Usage:       "contract code in hex",
-			Destination: &contractValue,
-		},
+	contractFlag  = cli.StringFlag{
+		Name:        "contract",
+		Usage:       "contract code in hex",
+		Destination: &contractValue,
 	}
 
 	topicValue string
@@ -220,6 +183,13 @@ var (
 		Destination: &dumpFileValue,
 	}
 
+	dumpStateFileValue string
+	dumpStateFileFlag  = cli.StringFlag{
+		Name:        "file",
+		Usage:       "output file of state dump in JSON lines, default is stdout",
+		Destination: &dumpStateFileValue,
+	}
+
 	timeLockValue int64
 	timeLockFlag  = cli.Int64Flag{
 		Name:        "time",
@@ -274,12 +244,10 @@ var (
 // GeneratePayload
 var (
 	abiFile     string
-	abiFileFlag = abiFlag{
-		StringFlag: cli.StringFlag{
-			Name:        "abi",
-			Usage:       "the abi file of contract",
-			Destination: &abiFile,
-		},
+	abiFileFlag = cli.StringFlag{
+		Name:        "abi",
+		Usage:       "the abi file of contract",
+		Destination: &abiFile,
 	}
 
 	methodName     string
@@ -301,4 +269,36 @@ var (
 		Name:  "args",
 		Usage: "the parameters of contract method",
 	}
+
+	fromHeightValue uint64
+	fromHeightFlag  = cli.Uint64Flag{
+		Name:        "fromheight",
+		Usage:       "start block height of the query range",
+		Value:       0,
+		Destination: &fromHeightValue,
+	}
+
+	toHeightValue uint64
+	toHeightFlag  = cli.Uint64Flag{
+		Name:        "toheight",
+		Usage:       "end block height of the query range",
+		Value:       math.MaxUint64,
+		Destination: &toHeightValue,
+	}
+
+	offsetValue uint
+	offsetFlag  = cli.UintFlag{
+		Name:        "offset",
+		Usage:       "number of entries to skip",
+		Value:       0,
+		Destination: &offsetValue,
+	}
+
+	limitValue uint
+	limitFlag  = cli.UintFlag{
+		Name:        "limit",
+		Usage:       "max number of entries to return, at most 1000",
+		Value:       100,
+		Destination: &limitValue,
+	}
 )
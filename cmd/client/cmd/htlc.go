@@ -14,12 +14,12 @@ import (
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/common/hexutil"
 	"github.com/seeleteam/go-seele/contract/system"
-	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seeleclient"
 	"github.com/urfave/cli"
 )
 
 // createHTLC create HTLC
-func createHTLC(client *rpc.Client) (interface{}, interface{}, error) {
+func createHTLC(client *seeleclient.Client) (interface{}, interface{}, error) {
 	hashLockBytes, err := hexutil.HexToBytes(hashValue)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Failed to convert Hex to Hash %s", err)
@@ -52,7 +52,7 @@ func createHTLC(client *rpc.Client) (interface{}, interface{}, error) {
 }
 
 // withdraw obtain seele from transaction
-func withdraw(client *rpc.Client) (interface{}, interface{}, error) {
+func withdraw(client *seeleclient.Client) (interface{}, interface{}, error) {
 	amountValue = "0"
 	txHashBytes, err := common.HexToHash(hashValue)
 	if err != nil {
@@ -85,7 +85,7 @@ func withdraw(client *rpc.Client) (interface{}, interface{}, error) {
 }
 
 // refund used to refund seele from HTLC
-func refund(client *rpc.Client) (interface{}, interface{}, error) {
+func refund(client *seeleclient.Client) (interface{}, interface{}, error) {
 	amountValue = "0"
 	txHashBytes, err := hexutil.HexToBytes(hashValue)
 	if err != nil {
@@ -104,7 +104,7 @@ func refund(client *rpc.Client) (interface{}, interface{}, error) {
 }
 
 // getHTLC used to get HTLC
-func getHTLC(client *rpc.Client) (interface{}, interface{}, error) {
+func getHTLC(client *seeleclient.Client) (interface{}, interface{}, error) {
 	amountValue = "0"
 	priceValue = "1"
 	txHashBytes, err := hexutil.HexToBytes(hashValue)
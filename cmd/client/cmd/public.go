@@ -6,61 +6,27 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/seeleteam/go-seele/cmd/util"
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/common/keystore"
 	"github.com/seeleteam/go-seele/core/types"
-	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seeleclient"
 	"github.com/urfave/cli"
 )
 
-type callArgsFactory func(*cli.Context, *rpc.Client) ([]interface{}, error)
 type callResultHandler func(inputs []interface{}, result interface{}) error
 
 func rpcFlags(callArgFlags ...cli.Flag) []cli.Flag {
 	return append([]cli.Flag{addressFlag}, callArgFlags...)
 }
 
-func parseCallArgs(context *cli.Context, client *rpc.Client) ([]interface{}, error) {
-	var args []interface{}
-
-	for _, flag := range context.Command.Flags {
-		if flag == addressFlag || flag == cli.HelpFlag {
-			continue
-		}
-
-		if rf, ok := flag.(rpcFlag); ok {
-			v, err := rf.getValue()
-			if err != nil {
-				return nil, err
-			}
-
-			args = append(args, v)
-		} else {
-			name := flag.GetName()
-			splitName := strings.Split(name, ",")
-
-			var flagValue interface{}
-			for _, n := range splitName {
-				flagName := strings.TrimSpace(n)
-				flagValue = context.Generic(flagName)
-				if flagValue != nil {
-					break
-				}
-			}
-
-			args = append(args, flagValue)
-		}
-	}
-
-	return args, nil
-}
-
 func handleCallResult(inputs []interface{}, result interface{}) error {
 	if result == nil {
 		return nil
@@ -81,11 +47,10 @@ func handleCallResult(inputs []interface{}, result interface{}) error {
 	return nil
 }
 
-func rpcAction(namespace string, method string) cli.ActionFunc {
-	return rpcActionEx(namespace, method, parseCallArgs, handleCallResult)
-}
+// clientCall calls the node via the typed client, and returns the result to print.
+type clientCall func(ctx context.Context, client *seeleclient.Client) (interface{}, error)
 
-func rpcActionEx(namespace string, method string, argsFactory callArgsFactory, resultHandler callResultHandler) cli.ActionFunc {
+func clientAction(call clientCall) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		// Currently, flag is required to specify value.
 		if c.NArg() > 0 {
@@ -93,37 +58,41 @@ func rpcActionEx(namespace string, method string, argsFactory callArgsFactory, r
 			return cli.ShowCommandHelp(c, c.Command.Name)
 		}
 
-		if namespace == "miner" {
-			if !strings.HasPrefix(addressValue, "127.0.0.1") && !strings.HasPrefix(addressValue, "localhost") {
-				return fmt.Errorf("miner methods only work for 127.0.0.1 (localhost)")
-			}
-		}
-		client, err := rpc.DialTCP(context.Background(), addressValue)
+		client, err := seeleclient.Dial(context.Background(), addressValue)
 		if err != nil {
 			return err
 		}
+		defer client.Close()
 
-		args, err := argsFactory(c, client)
+		result, err := call(context.Background(), client)
 		if err != nil {
 			return err
 		}
 
-		var result interface{}
-		rpcMethod := fmt.Sprintf("%s_%s", namespace, method)
-		if err = client.Call(&result, rpcMethod, args...); err != nil {
-			return fmt.Errorf("Failed to call rpc, %s", err)
+		return handleCallResult(nil, result)
+	}
+}
+
+// minerAction is the same as clientAction, but only works for local node.
+func minerAction(call clientCall) cli.ActionFunc {
+	action := clientAction(call)
+
+	return func(c *cli.Context) error {
+		if !strings.HasPrefix(addressValue, "127.0.0.1") && !strings.HasPrefix(addressValue, "localhost") {
+			return fmt.Errorf("miner methods only work for 127.0.0.1 (localhost)")
 		}
 
-		return resultHandler(args, result)
+		return action(c)
 	}
 }
 
 func rpcActionSystemContract(namespace string, method string, resultHandler callResultHandler) cli.ActionFunc {
 	return func(c *cli.Context) error {
-		client, err := rpc.DialTCP(context.Background(), addressValue)
+		client, err := seeleclient.Dial(context.Background(), addressValue)
 		if err != nil {
 			return err
 		}
+		defer client.Close()
 
 		functions, ok := systemContract[namespace]
 		if !ok {
@@ -155,7 +124,7 @@ func rpcActionSystemContract(namespace string, method string, resultHandler call
 			}
 
 		} else {
-			if err := sendTx(client, arg); err != nil {
+			if err := sendTx(client, arg.(*types.Transaction)); err != nil {
 				return err
 			}
 		}
@@ -164,21 +133,62 @@ func rpcActionSystemContract(namespace string, method string, resultHandler call
 	}
 }
 
-func makeTransaction(context *cli.Context, client *rpc.Client) ([]interface{}, error) {
+// txMaker makes the signed tx to send to node.
+type txMaker func(client *seeleclient.Client) (*types.Transaction, error)
+
+// txAction returns the action that sends the tx made by maker to node via the typed client method send.
+func txAction(maker txMaker, send func(*seeleclient.Client, context.Context, *types.Transaction) error) cli.ActionFunc {
+	return clientAction(func(ctx context.Context, client *seeleclient.Client) (interface{}, error) {
+		tx, err := maker(client)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = send(client, ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to send transaction, %s", err)
+		}
+
+		return nil, onTxAdded(tx)
+	})
+}
+
+func makeTransaction(client *seeleclient.Client) (*types.Transaction, error) {
 	key, txd, err := makeTransactionData(client)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err := util.GenerateTx(key.PrivateKey, txd.To, txd.Amount, txd.GasPrice, txd.GasLimit, txd.AccountNonce, txd.Payload)
+	return util.GenerateTx(key.PrivateKey, txd.To, txd.Amount, txd.GasPrice, txd.GasLimit, txd.AccountNonce, txd.Payload)
+}
+
+// makeCancelTransaction makes the tx to cancel the tx of specified hash in tx pool, which is signed by sender.
+func makeCancelTransaction(client *seeleclient.Client) (*types.Transaction, error) {
+	hash, err := common.HexToHash(hashValue)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid transaction hash: %s", err)
+	}
+
+	pass, err := common.GetPassword()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password %s", err)
+	}
+
+	key, err := keystore.GetKey(fromValue, pass)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender key file. it should be a private key: %s", err)
+	}
+
+	tx, err := client.GetCancelTransaction(context.Background(), hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the cancel transaction: %s", err)
 	}
 
-	return []interface{}{*tx}, nil
+	tx.Sign(key.PrivateKey)
+
+	return tx, nil
 }
 
-func makeTransactionData(client *rpc.Client) (*keystore.Key, *types.TransactionData, error) {
+func makeTransactionData(client *seeleclient.Client) (*keystore.Key, *types.TransactionData, error) {
 	pass, err := common.GetPassword()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get password %s", err)
@@ -197,13 +207,7 @@ func makeTransactionData(client *rpc.Client) (*keystore.Key, *types.TransactionD
 	return key, txd, nil
 }
 
-func onTxAdded(inputs []interface{}, result interface{}) error {
-	if !result.(bool) {
-		fmt.Println("failed to send transaction")
-	}
-
-	tx := inputs[0].(types.Transaction)
-
+func onTxAdded(tx *types.Transaction) error {
 	fmt.Println("transaction sent successfully")
 
 	encoded, err := json.MarshalIndent(tx, "", "\t")
@@ -214,7 +218,7 @@ func onTxAdded(inputs []interface{}, result interface{}) error {
 	fmt.Println(string(encoded))
 
 	// print corresponding debt if exist
-	debt := types.NewDebtWithoutContext(&tx)
+	debt := types.NewDebtWithoutContext(tx)
 	if debt != nil {
 		fmt.Println()
 		fmt.Println("It is a cross shard transaction, its debt is:")
@@ -228,3 +232,44 @@ func onTxAdded(inputs []interface{}, result interface{}) error {
 
 	return nil
 }
+
+// dumpStateAction dumps all the accounts of state page by page into the output file or stdout in JSON lines.
+func dumpStateAction(c *cli.Context) error {
+	client, err := seeleclient.Dial(context.Background(), addressValue)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	output := os.Stdout
+	if len(dumpStateFileValue) > 0 {
+		if output, err = os.Create(dumpStateFileValue); err != nil {
+			return err
+		}
+		defer output.Close()
+	}
+
+	writer := bufio.NewWriter(output)
+	encoder := json.NewEncoder(writer)
+
+	for start := common.EmptyHash; ; {
+		dump, err := client.DumpState(context.Background(), heightValue, start, 0)
+		if err != nil {
+			return fmt.Errorf("failed to dump state, %s", err)
+		}
+
+		for _, account := range dump.Accounts {
+			if err = encoder.Encode(account); err != nil {
+				return err
+			}
+		}
+
+		if dump.Next == nil {
+			break
+		}
+
+		start = *dump.Next
+	}
+
+	return writer.Flush()
+}
@@ -8,6 +8,7 @@ package cmd
 import (
 	"sort"
 
+	"github.com/seeleteam/go-seele/seeleclient"
 	"github.com/urfave/cli"
 )
 
@@ -18,115 +19,121 @@ func AddCommands(app *cli.App, isFullNode bool) {
 			Name:   "getbalance",
 			Usage:  "get balance info",
 			Flags:  rpcFlags(accountFlag, hashFlag, heightFlag),
-			Action: rpcAction("seele", "getBalance"),
+			Action: clientAction(getBalance),
 		},
 		{
 			Name:   "sendtx",
 			Usage:  "send transaction to node",
 			Flags:  rpcFlags(fromFlag, toFlag, amountFlag, priceFlag, gasLimitFlag, payloadFlag, nonceFlag),
-			Action: rpcActionEx("seele", "addTx", makeTransaction, onTxAdded),
+			Action: txAction(makeTransaction, (*seeleclient.Client).AddTx),
 		},
 		{
 			Name:   "getnonce",
 			Usage:  "get account nonce",
 			Flags:  rpcFlags(accountFlag, hashFlag, heightFlag),
-			Action: rpcAction("seele", "getAccountNonce"),
+			Action: clientAction(getAccountNonce),
 		},
 		{
 			Name:   "getblockheight",
 			Usage:  "get block height",
 			Flags:  rpcFlags(),
-			Action: rpcAction("seele", "getBlockHeight"),
+			Action: clientAction(getBlockHeight),
 		},
 		{
 			Name:   "getblock",
 			Usage:  "get block by height or hash",
 			Flags:  rpcFlags(hashFlag, heightFlag, fulltxFlag),
-			Action: rpcAction("seele", "getBlock"),
+			Action: clientAction(getBlock),
 		},
 		{
 			Name:   "gettxpoolcontent",
 			Usage:  "get transaction pool contents",
 			Flags:  rpcFlags(),
-			Action: rpcAction("txpool", "getTxPoolContent"),
+			Action: clientAction(getTxPoolContent),
 		},
 		{
 			Name:   "gettxpoolcount",
 			Usage:  "get transaction pool transaction count",
 			Flags:  rpcFlags(),
-			Action: rpcAction("txpool", "getTxPoolTxCount"),
+			Action: clientAction(getTxPoolTxCount),
 		},
 		{
 			Name:   "getblocktxcount",
 			Usage:  "get block transaction count by block height or block hash",
 			Flags:  rpcFlags(hashFlag, heightFlag),
-			Action: rpcAction("seele", "getBlockTransactionCount"),
+			Action: clientAction(getBlockTransactionCount),
 		},
 		{
 			Name:   "gettxinblock",
 			Usage:  "get transaction by block height or block hash with index of the transaction in the block",
 			Flags:  rpcFlags(hashFlag, heightFlag, indexFlag),
-			Action: rpcAction("seele", "getTransactionByBlockIndex"),
+			Action: clientAction(getTransactionByBlockIndex),
 		},
 		{
 			Name:   "gettxfromaccount",
 			Usage:  "get transaction from one account at specific height or blockhash",
 			Flags:  rpcFlags(accountFlag, hashFlag, heightFlag),
-			Action: rpcAction("seele", "getTransactionsFrom"),
+			Action: clientAction(accountTxsCall((*seeleclient.Client).GetTransactionsFrom)),
 		},
 		{
 			Name:   "gettxtoaccount",
 			Usage:  "get transaction to one account at specific height or blockhash",
 			Flags:  rpcFlags(accountFlag, hashFlag, heightFlag),
-			Action: rpcAction("seele", "getTransactionsTo"),
+			Action: clientAction(accountTxsCall((*seeleclient.Client).GetTransactionsTo)),
 		},
 		{
 			Name:   "getaccounttx",
 			Usage:  "get transaction of one account at specific height or blockhash",
 			Flags:  rpcFlags(accountFlag, hashFlag, heightFlag),
-			Action: rpcAction("seele", "getAccountTransactions"),
+			Action: clientAction(accountTxsCall((*seeleclient.Client).GetAccountTransactions)),
+		},
+		{
+			Name:   "getaccounthistory",
+			Usage:  "get transactions and debts of one account in the block height range, requires account history index enabled in node",
+			Flags:  rpcFlags(accountFlag, fromHeightFlag, toHeightFlag, offsetFlag, limitFlag),
+			Action: clientAction(getAccountHistory),
 		},
 		{
 			Name:   "getblocktx",
 			Usage:  "get transaction by block height or block hash",
 			Flags:  rpcFlags(hashFlag, heightFlag),
-			Action: rpcAction("seele", "getBlockTransactions"),
+			Action: clientAction(getBlockTransactions),
 		},
 		{
 			Name:   "getblocktxbyheight",
 			Usage:  "get the transitions at the specific height",
 			Flags:  rpcFlags(heightFlag),
-			Action: rpcAction("seele", "getBlockTransactionsByHeight"),
+			Action: clientAction(getBlockTransactions),
 		},
 		{
 			Name:   "getblocktxbyhash",
 			Usage:  "get the transitions at the specific blockhash",
 			Flags:  rpcFlags(hashFlag),
-			Action: rpcAction("seele", "getBlockTransactionsByHash"),
+			Action: clientAction(getBlockTransactions),
 		},
 		{
 			Name:   "gettxbyhash",
 			Usage:  "get transaction by transaction hash",
 			Flags:  rpcFlags(hashFlag),
-			Action: rpcAction("txpool", "getTransactionByHash"),
+			Action: clientAction(getTransactionByHash),
 		},
 		{
 			Name:   "getgasprice",
 			Usage:  "get transaction gas price by transaction hash",
 			Flags:  rpcFlags(hashFlag),
-			Action: rpcAction("txpool", "getGasPrice"),
+			Action: clientAction(getGasPrice),
 		},
 		{
 			Name:   "getreceipt",
 			Usage:  "get receipt by transaction hash",
 			Flags:  rpcFlags(hashFlag, abiFileFlag),
-			Action: rpcAction("seele", "getReceiptByTxHash"),
+			Action: clientAction(getReceipt),
 		},
 		{
 			Name:   "getpendingtxs",
 			Usage:  "get pending transactions",
 			Flags:  rpcFlags(),
-			Action: rpcAction("txpool", "getPendingTransactions"),
+			Action: clientAction(getPendingTransactions),
 		},
 		{
 			Name:  "getshardnum",
@@ -297,37 +304,37 @@ func AddCommands(app *cli.App, isFullNode bool) {
 				Name:   "peers",
 				Usage:  "get p2p peer connections",
 				Flags:  rpcFlags(),
-				Action: rpcAction("network", "getPeerCount"),
+				Action: clientAction(getPeerCount),
 			},
 			{
 				Name:   "peersinfo",
 				Usage:  "get p2p peers information",
 				Flags:  rpcFlags(),
-				Action: rpcAction("network", "getPeersInfo"),
+				Action: clientAction(getPeersInfo),
 			},
 			{
 				Name:   "netversion",
 				Usage:  "get current net version",
 				Flags:  rpcFlags(),
-				Action: rpcAction("network", "getNetVersion"),
+				Action: clientAction(getNetVersion),
 			},
 			{
 				Name:   "networkid",
 				Usage:  "get current network id",
 				Flags:  rpcFlags(),
-				Action: rpcAction("network", "getNetworkID"),
+				Action: clientAction(getNetworkID),
 			},
 			{
 				Name:   "protocolversion",
 				Usage:  "get seele protocol version",
 				Flags:  rpcFlags(),
-				Action: rpcAction("network", "getProtocolVersion"),
+				Action: clientAction(getProtocolVersion),
 			},
 			{
 				Name:   "islistening",
 				Usage:  "return whether the node is listen or not",
 				Flags:  rpcFlags(),
-				Action: rpcAction("network", "isListening"),
+				Action: clientAction(isListening),
 			},
 		},
 	}
@@ -340,62 +347,61 @@ func AddCommands(app *cli.App, isFullNode bool) {
 				Name:   "start",
 				Usage:  "start miner",
 				Flags:  rpcFlags(),
-				Action: rpcAction("miner", "start"),
+				Action: minerAction(minerStart),
 			},
 			{
 				Name:   "stop",
 				Usage:  "stop miner",
 				Flags:  rpcFlags(),
-				Action: rpcAction("miner", "stop"),
+				Action: minerAction(minerStop),
 			},
 			{
 				Name:   "setthreads",
 				Usage:  "set miner thread number",
 				Flags:  rpcFlags(threadsFlag),
-				Action: rpcAction("miner", "setThreads"),
+				Action: minerAction(minerSetThreads),
 			},
 			{
 				Name:   "setcoinbase",
 				Usage:  "set miner coinbase",
 				Flags:  rpcFlags(coinbaseFlag),
-				Action: rpcAction("miner", "setCoinbase"),
+				Action: minerAction(minerSetCoinbase),
 			},
 			{
 				Name:   "getcoinbase",
 				Usage:  "get miner coinbase",
 				Flags:  rpcFlags(),
-				Action: rpcAction("miner", "getCoinbase"),
+				Action: minerAction(minerGetCoinbase),
 			},
 			{
 				Name:   "status",
 				Usage:  "get miner status",
 				Flags:  rpcFlags(),
-				Action: rpcAction("miner", "status"),
+				Action: minerAction(minerStatus),
 			},
 			{
 				Name:   "hashrate",
 				Usage:  "get hashrate",
 				Flags:  rpcFlags(),
-				Action: rpcAction("miner", "getHashrate"),
+				Action: minerAction(minerGetHashrate),
 			},
 			{
 				Name:   "threads",
 				Usage:  "get thread number",
 				Flags:  rpcFlags(),
-				Action: rpcAction("miner", "getThreads"),
+				Action: minerAction(minerGetThreads),
 			},
 			{
 				Name:   "getwork",
 				Usage:  "get miner current mining task",
 				Flags:  rpcFlags(),
-				Action: rpcAction("miner", "getWork"),
+				Action: minerAction(minerGetWork),
 			},
 			{
-				Name:  "gettarget",
-				Usage: "get current SPOW mining difficulty ",
-				Flags: rpcFlags(),
-				// Action: rpcAction("miner", "getTaskDifficulty"),
-				Action: rpcAction("miner", "getTarget"),
+				Name:   "gettarget",
+				Usage:  "get current SPOW mining difficulty ",
+				Flags:  rpcFlags(),
+				Action: minerAction(minerGetTarget),
 			},
 		},
 	}
@@ -407,37 +413,67 @@ func AddCommands(app *cli.App, isFullNode bool) {
 				Name:   "getinfo",
 				Usage:  "get node info",
 				Flags:  rpcFlags(),
-				Action: rpcAction("seele", "getInfo"),
+				Action: clientAction(getInfo),
+			},
+			{
+				Name:   "replacetx",
+				Usage:  "replace the transaction with the same nonce in pool, which requires higher gas price",
+				Flags:  rpcFlags(fromFlag, toFlag, amountFlag, priceFlag, gasLimitFlag, payloadFlag, nonceFlag),
+				Action: txAction(makeTransaction, (*seeleclient.Client).ReplaceTransaction),
+			},
+			{
+				Name:   "canceltx",
+				Usage:  "cancel the transaction in pool by replacing it with a zero-value transfer to sender",
+				Flags:  rpcFlags(fromFlag, hashFlag),
+				Action: txAction(makeCancelTransaction, (*seeleclient.Client).ReplaceTransaction),
+			},
+			{
+				Name:   "suggestgasprice",
+				Usage:  "get the gas price suggested to get transaction included in time",
+				Flags:  rpcFlags(),
+				Action: clientAction(suggestGasPrice),
+			},
+			{
+				Name:   "getpricehistogram",
+				Usage:  "get the number of pending transactions per gas price in pool",
+				Flags:  rpcFlags(),
+				Action: clientAction(getPriceHistogram),
 			},
 			{
 				Name:   "getdebts",
 				Usage:  "get pending debts",
 				Flags:  rpcFlags(),
-				Action: rpcAction("txpool", "getPendingDebts"),
+				Action: clientAction(getPendingDebts),
 			},
 			{
 				Name:   "dumpheap",
 				Usage:  "dump heap for profiling, return the file path",
 				Flags:  rpcFlags(dumpFileFlag, gcBeforeDumpFlag),
-				Action: rpcAction("debug", "dumpHeap"),
+				Action: clientAction(dumpHeap),
+			},
+			{
+				Name:   "dumpstate",
+				Usage:  "dump all the accounts of state in JSON lines",
+				Flags:  rpcFlags(heightFlag, dumpStateFileFlag),
+				Action: dumpStateAction,
 			},
 			{
 				Name:   "call",
 				Usage:  "call contract",
 				Flags:  rpcFlags(toFlag, payloadFlag, heightFlag),
-				Action: rpcAction("seele", "call"),
+				Action: clientAction(callContract),
 			},
 			{
 				Name:   "getlogs",
 				Usage:  "get logs",
 				Flags:  rpcFlags(heightFlag, contractFlag, abiFileFlag, eventNameFlag),
-				Action: rpcAction("seele", "getLogs"),
+				Action: clientAction(getLogs),
 			},
 			{
 				Name:   "getdebtbyhash",
 				Usage:  "get debt by debt hash",
 				Flags:  rpcFlags(hashFlag),
-				Action: rpcAction("txpool", "getDebtByHash"),
+				Action: clientAction(getDebtByHash),
 			},
 		}...)
 
@@ -28,7 +28,7 @@ import (
 	"github.com/seeleteam/go-seele/node"
 	"github.com/seeleteam/go-seele/p2p"
 	"github.com/seeleteam/go-seele/p2p/discovery"
-	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seeleclient"
 	"github.com/urfave/cli"
 )
 
@@ -43,7 +43,7 @@ var (
 	defaultTokenShortName = "seele"
 )
 
-func registerSubChain(client *rpc.Client) (interface{}, interface{}, error) {
+func registerSubChain(client *seeleclient.Client) (interface{}, interface{}, error) {
 	amountValue = "0"
 
 	subChain, err := getSubChainFromFile(subChainJSONFileVale)
@@ -90,7 +90,7 @@ func registerSubChain(client *rpc.Client) (interface{}, interface{}, error) {
 	return output, tx, err
 }
 
-func querySubChain(client *rpc.Client) (interface{}, interface{}, error) {
+func querySubChain(client *seeleclient.Client) (interface{}, interface{}, error) {
 	amountValue = "0"
 
 	if err := system.ValidateDomainName([]byte(nameValue)); err != nil {
@@ -106,17 +106,18 @@ func querySubChain(client *rpc.Client) (interface{}, interface{}, error) {
 }
 
 func createSubChainConfigFile(c *cli.Context) error {
-	client, err := rpc.DialTCP(context.Background(), addressValue)
+	client, err := seeleclient.Dial(context.Background(), addressValue)
 	if err != nil {
 		return err
 	}
+	defer client.Close()
 
 	subChainInfo, err := getSubChainFromReceipt(client)
 	if err != nil {
 		return err
 	}
 
-	networkID, err := util.GetNetworkID(client)
+	networkID, err := client.GetNetworkID(context.Background())
 	if err != nil {
 		return err
 	}
@@ -159,33 +160,21 @@ func getSubChainFromFile(filepath string) (*system.SubChainInfo, error) {
 	return &subChain, err
 }
 
-func getSubChainFromReceipt(client *rpc.Client) (*system.SubChainInfo, error) {
+func getSubChainFromReceipt(client *seeleclient.Client) (*system.SubChainInfo, error) {
 	if err := system.ValidateDomainName([]byte(nameValue)); err != nil {
 		return nil, err
 	}
 	payloadBytes := append([]byte{system.CmdSubChainQuery}, []byte(nameValue)...)
-	mapReceipt, err := util.CallContract(client, system.SubChainContractAddress.Hex(), hexutil.BytesToHex(payloadBytes), -1)
+	receipt, err := client.CallContract(context.Background(), system.SubChainContractAddress, payloadBytes, -1)
 	if err != nil {
 		return nil, err
 	}
 
-	resultFlag, ok := mapReceipt["failed"].(bool)
-	if !ok {
-		return nil, errSubChainInfo
-	}
-	result, ok := mapReceipt["result"].(string)
-	if !ok {
-		return nil, errSubChainInfo
-	}
-	if resultFlag {
-		return nil, fmt.Errorf("failed to get sub-chain information, %s", result)
-	}
-
-	bytesSubChainInfo, err := hexutil.HexToBytes(result)
-	if err != nil {
-		return nil, err
+	if receipt.Failed {
+		return nil, fmt.Errorf("failed to get sub-chain information, %s", string(receipt.Result))
 	}
 
+	bytesSubChainInfo := receipt.Result
 	if len(bytesSubChainInfo) == 0 {
 		return nil, fmt.Errorf("sub-chain %s does not exist", nameValue)
 	}
@@ -6,17 +6,18 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"github.com/seeleteam/go-seele/api"
 	"github.com/seeleteam/go-seele/cmd/util"
 	"github.com/seeleteam/go-seele/common"
-	"github.com/seeleteam/go-seele/common/hexutil"
 	"github.com/seeleteam/go-seele/core/types"
-	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seeleclient"
 )
 
-type handler func(client *rpc.Client) (interface{}, interface{}, error)
+type handler func(client *seeleclient.Client) (interface{}, interface{}, error)
 
 var (
 	errInvalidCommand    = errors.New("invalid command")
@@ -48,7 +49,7 @@ var (
 )
 
 // sendSystemContractTx send system contract transaction
-func sendSystemContractTx(client *rpc.Client, to common.Address, method byte, payload []byte) (*types.Transaction, error) {
+func sendSystemContractTx(client *seeleclient.Client, to common.Address, method byte, payload []byte) (*types.Transaction, error) {
 	key, txd, err := makeTransactionData(client)
 	if err != nil {
 		return nil, err
@@ -65,9 +66,8 @@ func sendSystemContractTx(client *rpc.Client, to common.Address, method byte, pa
 }
 
 // sendTx send transaction or contract
-func sendTx(client *rpc.Client, arg interface{}) error {
-	var result bool
-	if err := client.Call(&result, "seele_addTx", arg); err != nil || !result {
+func sendTx(client *seeleclient.Client, tx *types.Transaction) error {
+	if err := client.AddTx(context.Background(), tx); err != nil {
 		return fmt.Errorf("Failed to call rpc, %s", err)
 	}
 
@@ -75,15 +75,15 @@ func sendTx(client *rpc.Client, arg interface{}) error {
 }
 
 // callTx call transaction or contract
-func callTx(client *rpc.Client, tx *types.Transaction) (interface{}, error) {
-	var result interface{}
-	if tx != nil {
-		if err := client.Call(&result, "seele_call", tx.Data.To.Hex(), hexutil.BytesToHex(tx.Data.Payload), -1); err != nil {
-			return nil, fmt.Errorf("Failed to call rpc, %s", err)
-		}
-	} else {
+func callTx(client *seeleclient.Client, tx *types.Transaction) (interface{}, error) {
+	if tx == nil {
 		return nil, errors.New("Invalid parameters")
 	}
 
-	return result, nil
+	receipt, err := client.CallContract(context.Background(), tx.Data.To, tx.Data.Payload, -1)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to call rpc, %s", err)
+	}
+
+	return api.PrintableReceipt(receipt)
 }
@@ -20,7 +20,7 @@ import (
 	"github.com/seeleteam/go-seele/common/keystore"
 	"github.com/seeleteam/go-seele/core/types"
 	"github.com/seeleteam/go-seele/crypto"
-	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seeleclient"
 	"github.com/urfave/cli"
 )
 
@@ -80,12 +80,13 @@ func SaveKeyAction(c *cli.Context) error {
 
 // SignTxAction is a action that signs a transaction
 func SignTxAction(c *cli.Context) error {
-	var client *rpc.Client
+	var client *seeleclient.Client
 	if addressValue != "" {
-		c, err := rpc.DialTCP(context.Background(), addressValue)
+		c, err := seeleclient.Dial(context.Background(), addressValue)
 		if err != nil {
 			return err
 		}
+		defer c.Close()
 
 		client = c
 	}
@@ -6,25 +6,28 @@
 package cmd
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
-	"github.com/seeleteam/go-seele/cmd/util"
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/common/hexutil"
 	"github.com/seeleteam/go-seele/core/types"
 	"github.com/seeleteam/go-seele/crypto"
-	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seeleclient"
 	"github.com/urfave/cli"
 )
 
 const (
 	// DefaultNonce is the default value of nonce,when you are not set the nonce flag in client sendtx command by --nonce .
 	DefaultNonce uint64 = 0
+
+	// DefaultGasPrice is the default gas price in Fan if not specified and no node to suggest.
+	DefaultGasPrice int64 = 10
 )
 
-func checkParameter(publicKey *ecdsa.PublicKey, client *rpc.Client) (*types.TransactionData, error) {
+func checkParameter(publicKey *ecdsa.PublicKey, client *seeleclient.Client) (*types.TransactionData, error) {
 	info := &types.TransactionData{}
 	var err error
 	if len(toValue) > 0 {
@@ -41,11 +44,9 @@ func checkParameter(publicKey *ecdsa.PublicKey, client *rpc.Client) (*types.Tran
 	}
 	info.Amount = amount
 
-	price, ok := big.NewInt(0).SetString(priceValue, 10)
-	if !ok {
-		return info, fmt.Errorf("invalid gas price value")
+	if info.GasPrice, err = parseGasPrice(client); err != nil {
+		return info, err
 	}
-	info.GasPrice = price
 
 	info.GasLimit = gasLimitValue
 
@@ -54,7 +55,7 @@ func checkParameter(publicKey *ecdsa.PublicKey, client *rpc.Client) (*types.Tran
 
 	if nonceValue == DefaultNonce && client != nil {
 		// get current nonce
-		nonce, err := util.GetAccountNonce(client, *fromAddr, "", -1)
+		nonce, err := client.GetAccountNonce(context.Background(), *fromAddr, common.EmptyHash, -1)
 		if err != nil {
 			return info, fmt.Errorf("failed to get the sender account nonce: %s", err)
 		}
@@ -75,6 +76,32 @@ func checkParameter(publicKey *ecdsa.PublicKey, client *rpc.Client) (*types.Tran
 	return info, nil
 }
 
+// parseGasPrice returns the gas price in flag, or the price suggested by node if not specified,
+// and the default price if node cannot suggest, e.g. light node.
+func parseGasPrice(client *seeleclient.Client) (*big.Int, error) {
+	if len(priceValue) > 0 {
+		price, ok := big.NewInt(0).SetString(priceValue, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid gas price value")
+		}
+
+		return price, nil
+	}
+
+	if client == nil {
+		return big.NewInt(DefaultGasPrice), nil
+	}
+
+	price, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		fmt.Printf("failed to get the suggested gas price, use the default price %v: %s\n", DefaultGasPrice, err)
+		return big.NewInt(DefaultGasPrice), nil
+	}
+	fmt.Printf("gas price not specified, use the suggested price %v\n", price)
+
+	return price, nil
+}
+
 // NewApp generate default app
 func NewApp(isFullNode bool) *cli.App {
 	app := cli.NewApp()
@@ -0,0 +1,260 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/node"
+	"github.com/seeleteam/go-seele/seele"
+	"github.com/spf13/cobra"
+)
+
+// chainProgressInterval is the number of blocks to report progress when export or import blocks.
+const chainProgressInterval = 1000
+
+var chainConfigFile string
+
+// exportChainCmd represents the export command
+var exportChainCmd = &cobra.Command{
+	Use:   "export <file> [from] [to]",
+	Short: "export the canonical blocks to file",
+	Long: `Export the canonical blocks in height range [from, to] into file as RLP stream. By default, from is 1
+and to is the HEAD block height. The file is gzip compressed if ends with ".gz". The node must be stopped
+before export. For example:
+		node.exe export -c cmd\node.json chain.gz 1 10000`,
+	Args: cobra.RangeArgs(1, 3),
+	Run: func(cmd *cobra.Command, args []string) {
+		nCfg, err := LoadConfigFromFile(chainConfigFile, "")
+		if err != nil {
+			fmt.Printf("failed to reading the config file: %s\n", err.Error())
+			return
+		}
+
+		heights := []int64{1, -1}
+		for i, arg := range args[1:] {
+			if heights[i], err = strconv.ParseInt(arg, 10, 64); err != nil || heights[i] < 0 {
+				fmt.Printf("invalid block height %v\n", arg)
+				return
+			}
+		}
+
+		count, err := exportChain(nCfg.BasicConfig.DataDir, args[0], uint64(heights[0]), heights[1])
+		if err != nil {
+			fmt.Println("failed to export blocks:", err.Error())
+			return
+		}
+
+		fmt.Printf("%v blocks exported\n", count)
+	},
+}
+
+// importChainCmd represents the import command
+var importChainCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "import blocks from file",
+	Long: `Import blocks from the file exported by the export command. The blocks are fully validated,
+and the existing blocks are skipped. The blocks with cross shard debts are refused, since the debts
+could not be verified against other shards offline. The file is gzip compressed if ends with ".gz".
+The node must be stopped before import. For example:
+		node.exe import -c cmd\node.json chain.gz`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		nCfg, err := LoadConfigFromFile(chainConfigFile, "")
+		if err != nil {
+			fmt.Printf("failed to reading the config file: %s\n", err.Error())
+			return
+		}
+
+		count, err := importChain(nCfg, args[0])
+		if err != nil {
+			fmt.Println("failed to import blocks:", err.Error())
+		}
+
+		fmt.Printf("%v blocks imported\n", count)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportChainCmd)
+	rootCmd.AddCommand(importChainCmd)
+
+	exportChainCmd.Flags().StringVarP(&chainConfigFile, "config", "c", "", "seele node config file (required)")
+	exportChainCmd.MustMarkFlagRequired("config")
+
+	importChainCmd.Flags().StringVarP(&chainConfigFile, "config", "c", "", "seele node config file (required)")
+	importChainCmd.MustMarkFlagRequired("config")
+}
+
+// exportChain exports the canonical blocks from the specified height to the HEAD block if to is negative.
+func exportChain(dataDir string, file string, from uint64, to int64) (uint64, error) {
+	chainDB, accountStateDB, err := openChainDatabases(dataDir)
+	if err != nil {
+		return 0, err
+	}
+	defer chainDB.Close()
+	defer accountStateDB.Close()
+
+	bcStore := store.NewBlockchainDatabase(chainDB)
+	if to < 0 {
+		headHash, err := bcStore.GetHeadBlockHash()
+		if err != nil {
+			return 0, errors.NewStackedError(err, "failed to get HEAD block hash")
+		}
+
+		head, err := bcStore.GetBlockHeader(headHash)
+		if err != nil {
+			return 0, errors.NewStackedErrorf(err, "failed to get HEAD block header by hash %v", headHash)
+		}
+
+		to = int64(head.Height)
+	}
+
+	w, err := createChainFile(file)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	if err = core.ExportChain(w, bcStore, from, uint64(to), newChainProgress("exported", &count)); err != nil {
+		w.Close()
+		return count, err
+	}
+
+	// flush the compressed data if any
+	return count, w.Close()
+}
+
+// importChain imports blocks from file into the blockchain of the data folder in config.
+func importChain(nCfg *node.Config, file string) (uint64, error) {
+	r, err := openChainFile(file)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	dataDir := nCfg.BasicConfig.DataDir
+	chainDB, accountStateDB, err := openChainDatabases(dataDir)
+	if err != nil {
+		return 0, err
+	}
+	defer chainDB.Close()
+	defer accountStateDB.Close()
+
+	bcStore := store.NewBlockchainDatabase(chainDB)
+	if nCfg.BasicConfig.AccountHistory {
+		bcStore = store.NewBlockchainDatabaseWithAccountHistory(chainDB)
+	}
+
+	genesis := core.GetGenesis(&nCfg.SeeleConfig.GenesisConfig)
+	if err = genesis.InitializeAndValidate(bcStore, accountStateDB); err != nil {
+		return 0, errors.NewStackedError(err, "failed to initialize genesis")
+	}
+
+	// the percentage flag is only registered on start command, and import does not mine
+	engine, err := newConsensusEngine(nCfg, defaultPercentage)
+	if err != nil {
+		return 0, errors.NewStackedError(err, "failed to create consensus engine")
+	}
+
+	recoveryPointFile := filepath.Join(dataDir, seele.BlockChainRecoveryPointFile)
+	bc, err := core.NewBlockchain(bcStore, accountStateDB, recoveryPointFile, engine, nil, -1)
+	if err != nil {
+		return 0, errors.NewStackedError(err, "failed to create blockchain")
+	}
+
+	if nCfg.BasicConfig.StateGCMode == common.StateGCModePrune {
+		bc.EnableStatePruning(nCfg.BasicConfig.GetStateRetained())
+	}
+
+	var count uint64
+	_, err = core.ImportChain(r, bc, newChainProgress("imported", &count))
+
+	return count, err
+}
+
+// newChainProgress returns a progress reporter that prints periodically.
+func newChainProgress(action string, count *uint64) core.ChainProgress {
+	start := time.Now()
+
+	return func(block *types.Block, n uint64) {
+		if *count = n; n%chainProgressInterval == 0 {
+			fmt.Printf("%v %v blocks, height: %v, elapsed: %v\n", action, n, block.Header.Height, time.Since(start))
+		}
+	}
+}
+
+type gzipFile struct {
+	*gzip.Writer
+	file *os.File
+}
+
+// Close flushes the gzip writer and closes the file.
+func (f *gzipFile) Close() error {
+	if err := f.Writer.Close(); err != nil {
+		f.file.Close()
+		return err
+	}
+
+	return f.file.Close()
+}
+
+// createChainFile creates file to write, which is gzip compressed if ends with ".gz".
+func createChainFile(file string) (io.WriteCloser, error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to create file %v", file)
+	}
+
+	if !strings.HasSuffix(file, ".gz") {
+		return f, nil
+	}
+
+	return &gzipFile{gzip.NewWriter(f), f}, nil
+}
+
+type gunzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+// Close closes the gzip reader and file.
+func (f *gunzipFile) Close() error {
+	f.Reader.Close()
+	return f.file.Close()
+}
+
+// openChainFile opens file to read, which is gzip compressed if ends with ".gz".
+func openChainFile(file string) (io.ReadCloser, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to open file %v", file)
+	}
+
+	if !strings.HasSuffix(file, ".gz") {
+		return f, nil
+	}
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, errors.NewStackedErrorf(err, "failed to read gzip file %v", file)
+	}
+
+	return &gunzipFile{r, f}, nil
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ChainFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chainfile")
+	assert.Equal(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	data := []byte("seele chain file")
+	for _, name := range []string{"chain", "chain.gz"} {
+		file := filepath.Join(dir, name)
+
+		w, err := createChainFile(file)
+		assert.Equal(t, err, nil)
+		_, err = w.Write(data)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, w.Close(), nil)
+
+		r, err := openChainFile(file)
+		assert.Equal(t, err, nil)
+		read, err := ioutil.ReadAll(r)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, read, data)
+		assert.Equal(t, r.Close(), nil)
+	}
+
+	// gzip file is compressed
+	raw, err := ioutil.ReadFile(filepath.Join(dir, "chain.gz"))
+	assert.Equal(t, err, nil)
+	assert.NotEqual(t, raw, data)
+}
@@ -0,0 +1,109 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/consensus/factory"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/seeleteam/go-seele/database/migration"
+	"github.com/seeleteam/go-seele/seele"
+	"github.com/spf13/cobra"
+)
+
+var migrateConfigFile string
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "upgrade the databases to the latest schema version",
+	Long: `Upgrade the databases in data folder to the latest schema version. The interrupted migration
+will resume when run again. Note, the migration also runs when node starts. For example:
+		node.exe migrate -c cmd\node.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		nCfg, err := LoadConfigFromFile(migrateConfigFile, "")
+		if err != nil {
+			fmt.Printf("failed to reading the config file: %s\n", err.Error())
+			return
+		}
+
+		dataDir := nCfg.BasicConfig.DataDir
+		schemas := []struct {
+			path   string
+			schema *migration.Schema
+		}{
+			{filepath.Join(dataDir, seele.BlockChainDir), store.ChainSchema},
+			{filepath.Join(dataDir, seele.AccountStateDir), state.AccountStateSchema},
+			{filepath.Join(dataDir, seele.DebtManagerDir), seele.DebtManagerSchema},
+			{filepath.Join(dataDir, common.BFTDataFolder), factory.BFTSchema},
+		}
+
+		for _, s := range schemas {
+			if _, err := os.Stat(s.path); os.IsNotExist(err) {
+				fmt.Printf("%v database not found, skipped\n", s.schema.Name())
+				continue
+			}
+
+			from, applied, err := migrateDatabase(s.path, s.schema)
+			if err != nil {
+				fmt.Printf("failed to migrate %v database: %s\n", s.schema.Name(), err.Error())
+				return
+			}
+
+			fmt.Printf("%v database schema version: %v -> %v, %v migrations applied\n",
+				s.schema.Name(), from, s.schema.LatestVersion(), applied)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVarP(&migrateConfigFile, "config", "c", "", "seele node config file (required)")
+	migrateCmd.MustMarkFlagRequired("config")
+}
+
+// migrateDatabase upgrades the database to the latest schema version, and returns the
+// original version and the number of migrations applied.
+func migrateDatabase(path string, schema *migration.Schema) (uint, int, error) {
+	db, err := leveldb.NewLevelDB(path)
+	if err != nil {
+		return 0, 0, errors.NewStackedErrorf(err, "failed to open %v db", schema.Name())
+	}
+	defer db.Close()
+
+	from, err := schema.Version(db)
+	if err != nil {
+		return 0, 0, errors.NewStackedErrorf(err, "failed to get %v schema version", schema.Name())
+	}
+
+	applied, err := schema.Migrate(db)
+
+	return from, applied, err
+}
+
+// openDatabase opens the database under the given path, and upgrades it to the latest schema version.
+func openDatabase(path string, schema *migration.Schema) (database.Database, error) {
+	db, err := leveldb.NewLevelDB(path)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to open %v db", schema.Name())
+	}
+
+	if _, err = schema.Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,86 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneConfigFile string
+	pruneRetained   uint64
+)
+
+// pruneStateCmd represents the prune-state command
+var pruneStateCmd = &cobra.Command{
+	Use:   "prune-state",
+	Short: "delete the stale state trie nodes offline",
+	Long: `Delete the state trie nodes that are not reachable from the state of recent blocks.
+The node must be stopped before pruning. For example:
+		node.exe prune-state -c cmd\node.json --retained 128`,
+	Run: func(cmd *cobra.Command, args []string) {
+		nCfg, err := LoadConfigFromFile(pruneConfigFile, "")
+		if err != nil {
+			fmt.Printf("failed to reading the config file: %s\n", err.Error())
+			return
+		}
+
+		retained := nCfg.BasicConfig.GetStateRetained()
+		if cmd.Flags().Changed("retained") {
+			retained = pruneRetained
+		}
+
+		result, err := pruneState(nCfg.BasicConfig.DataDir, retained)
+		if err != nil {
+			fmt.Println("failed to prune state:", err.Error())
+			return
+		}
+
+		fmt.Printf("state pruned, roots: %v, retained nodes: %v, deleted nodes: %v, elapsed: %v\n",
+			result.Roots, result.Retained, result.Deleted, result.Elapsed)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pruneStateCmd)
+
+	pruneStateCmd.Flags().StringVarP(&pruneConfigFile, "config", "c", "", "seele node config file (required)")
+	pruneStateCmd.MustMarkFlagRequired("config")
+
+	pruneStateCmd.Flags().Uint64VarP(&pruneRetained, "retained", "", 0, "number of confirmed block states to retain, default is the stateRetained in config")
+}
+
+func pruneState(dataDir string, retained uint64) (*core.StatePruneResult, error) {
+	chainDB, accountStateDB, err := openChainDatabases(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer chainDB.Close()
+	defer accountStateDB.Close()
+
+	bcStore := store.NewBlockchainDatabase(chainDB)
+	headHash, err := bcStore.GetHeadBlockHash()
+	if err != nil {
+		return nil, errors.NewStackedError(err, "failed to get HEAD block hash")
+	}
+
+	head, err := bcStore.GetBlockHeader(headHash)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to get HEAD block header by hash %v", headHash)
+	}
+
+	roots, err := core.RetainedStateRoots(bcStore, head.Height, retained)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.NewStatePruner(accountStateDB).Prune(roots)
+}
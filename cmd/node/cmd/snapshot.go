@@ -0,0 +1,183 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/node"
+	"github.com/seeleteam/go-seele/seele"
+	"github.com/spf13/cobra"
+)
+
+// defaultSnapshotBlocks is the default number of recent blocks exported in snapshot.
+const defaultSnapshotBlocks = 256
+
+var (
+	snapshotConfigFile string
+	snapshotHeight     uint64
+	snapshotBlocks     uint64
+)
+
+// exportSnapshotCmd represents the export-snapshot command
+var exportSnapshotCmd = &cobra.Command{
+	Use:   "export-snapshot <file>",
+	Short: "export the state snapshot of a confirmed block to file",
+	Long: `Export the state and recent blocks of a confirmed block into file, which is used to bootstrap
+a new node. The file is gzip compressed if ends with ".gz". The node must be stopped before export. For example:
+		node.exe export-snapshot -c cmd\node.json --height 10000 snapshot.gz`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		nCfg, err := LoadConfigFromFile(snapshotConfigFile, "")
+		if err != nil {
+			fmt.Printf("failed to reading the config file: %s\n", err.Error())
+			return
+		}
+
+		height := int64(-1)
+		if cmd.Flags().Changed("height") {
+			height = int64(snapshotHeight)
+		}
+
+		header, err := exportSnapshot(nCfg.BasicConfig.DataDir, args[0], height, snapshotBlocks)
+		if err != nil {
+			fmt.Println("failed to export snapshot:", err.Error())
+			return
+		}
+
+		fmt.Printf("snapshot exported, height: %v, block: %v, state root: %v, blocks: %v\n",
+			header.Height, header.BlockHash.Hex(), header.StateRoot.Hex(), header.Blocks)
+	},
+}
+
+// importSnapshotCmd represents the import-snapshot command
+var importSnapshotCmd = &cobra.Command{
+	Use:   "import-snapshot <file>",
+	Short: "bootstrap an empty node from the state snapshot file",
+	Long: `Import the state snapshot file into an empty node, and then the node could start to sync from
+the snapshot block. The file is gzip compressed if ends with ".gz". For example:
+		node.exe import-snapshot -c cmd\node.json snapshot.gz`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		nCfg, err := LoadConfigFromFile(snapshotConfigFile, "")
+		if err != nil {
+			fmt.Printf("failed to reading the config file: %s\n", err.Error())
+			return
+		}
+
+		header, err := importSnapshot(nCfg, args[0])
+		if err != nil {
+			fmt.Println("failed to import snapshot:", err.Error())
+			return
+		}
+
+		fmt.Printf("snapshot imported, height: %v, block: %v, state root: %v, blocks: %v\n",
+			header.Height, header.BlockHash.Hex(), header.StateRoot.Hex(), header.Blocks)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportSnapshotCmd)
+	rootCmd.AddCommand(importSnapshotCmd)
+
+	exportSnapshotCmd.Flags().StringVarP(&snapshotConfigFile, "config", "c", "", "seele node config file (required)")
+	exportSnapshotCmd.MustMarkFlagRequired("config")
+	exportSnapshotCmd.Flags().Uint64VarP(&snapshotHeight, "height", "", 0, "block height to export, default is the latest confirmed block")
+	exportSnapshotCmd.Flags().Uint64VarP(&snapshotBlocks, "blocks", "", defaultSnapshotBlocks, "number of recent blocks to export")
+
+	importSnapshotCmd.Flags().StringVarP(&snapshotConfigFile, "config", "c", "", "seele node config file (required)")
+	importSnapshotCmd.MustMarkFlagRequired("config")
+}
+
+// openChainDatabases opens the blockchain and account state databases in the data folder,
+// which are upgraded to the latest schema version.
+func openChainDatabases(dataDir string) (database.Database, database.Database, error) {
+	chainDB, err := openDatabase(filepath.Join(dataDir, seele.BlockChainDir), store.ChainSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accountStateDB, err := openDatabase(filepath.Join(dataDir, seele.AccountStateDir), state.AccountStateSchema)
+	if err != nil {
+		chainDB.Close()
+		return nil, nil, err
+	}
+
+	return chainDB, accountStateDB, nil
+}
+
+// exportSnapshot exports the snapshot of specified height, or the latest confirmed block if height is negative.
+func exportSnapshot(dataDir string, file string, height int64, blocks uint64) (*core.SnapshotHeader, error) {
+	chainDB, accountStateDB, err := openChainDatabases(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer chainDB.Close()
+	defer accountStateDB.Close()
+
+	bcStore := store.NewBlockchainDatabase(chainDB)
+	if height < 0 {
+		headHash, err := bcStore.GetHeadBlockHash()
+		if err != nil {
+			return nil, errors.NewStackedError(err, "failed to get HEAD block hash")
+		}
+
+		head, err := bcStore.GetBlockHeader(headHash)
+		if err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get HEAD block header by hash %v", headHash)
+		}
+
+		height = 0
+		if head.Height > common.ConfirmedBlockNumber {
+			height = int64(head.Height - common.ConfirmedBlockNumber)
+		}
+	}
+
+	w, err := createChainFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := core.ExportSnapshot(w, bcStore, accountStateDB, uint64(height), blocks)
+	if err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	// flush the compressed data if any
+	return header, w.Close()
+}
+
+// importSnapshot imports the snapshot file into the empty databases of data folder, and the
+// recent blocks are indexed the same as blocks written by node, e.g. account history if enabled.
+func importSnapshot(nCfg *node.Config, file string) (*core.SnapshotHeader, error) {
+	r, err := openChainFile(file)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	chainDB, accountStateDB, err := openChainDatabases(nCfg.BasicConfig.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer chainDB.Close()
+	defer accountStateDB.Close()
+
+	bcStore := store.NewBlockchainDatabase(chainDB)
+	if nCfg.BasicConfig.AccountHistory {
+		bcStore = store.NewBlockchainDatabaseWithAccountHistory(chainDB)
+	}
+
+	return core.ImportSnapshot(r, bcStore, accountStateDB)
+}
@@ -32,6 +32,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// defaultPercentage is the default miner target confidence range.
+const defaultPercentage = 10
+
 var (
 	seeleNodeConfigFile string
 	miner               string
@@ -89,13 +92,7 @@ var startCmd = &cobra.Command{
 		}
 		ctx := context.WithValue(context.Background(), "ServiceContext", serviceContext)
 
-		var engine consensus.Engine
-		if nCfg.BasicConfig.MinerAlgorithm == common.BFTEngine {
-			engine, err = factory.GetBFTEngine(nCfg.SeeleConfig.CoinbasePrivateKey, nCfg.BasicConfig.DataDir)
-		} else {
-			engine, err = factory.GetConsensusEngine(nCfg.BasicConfig.MinerAlgorithm, nCfg.BasicConfig.DataSetDir, percentage)
-		}
-
+		engine, err := newConsensusEngine(nCfg, percentage)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -224,7 +221,7 @@ func init() {
 	startCmd.Flags().BoolVarP(&metricsEnableFlag, "metrics", "t", false, "start metrics")
 	startCmd.Flags().StringVarP(&accountsConfig, "accounts", "", "", "init accounts info")
 	startCmd.Flags().IntVarP(&threads, "threads", "", 1, "miner thread value")
-	startCmd.Flags().IntVarP(&percentage, "percentage", "p", 10, "miner target confidence range (integer, 1-10), higher: more calculation time; lower: less chance to hit target")
+	startCmd.Flags().IntVarP(&percentage, "percentage", "p", defaultPercentage, "miner target confidence range (integer, 1-10), higher: more calculation time; lower: less chance to hit target")
 	startCmd.Flags().BoolVarP(&lightNode, "light", "l", false, "whether start with light mode")
 	startCmd.Flags().Uint64VarP(&pprofPort, "port", "", 0, "which port pprof http server listen to")
 	startCmd.Flags().IntVarP(&startHeight, "startheight", "", -1, "the block height to start from")
@@ -284,3 +281,12 @@ func monitorPC() {
 		}
 	}
 }
+
+// newConsensusEngine creates the consensus engine of the miner algorithm in config.
+func newConsensusEngine(nCfg *node.Config, percentage int) (consensus.Engine, error) {
+	if nCfg.BasicConfig.MinerAlgorithm == common.BFTEngine {
+		return factory.GetBFTEngine(nCfg.SeeleConfig.CoinbasePrivateKey, nCfg.BasicConfig.DataDir)
+	}
+
+	return factory.GetConsensusEngine(nCfg.BasicConfig.MinerAlgorithm, nCfg.BasicConfig.DataSetDir, percentage)
+}
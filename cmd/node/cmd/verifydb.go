@@ -0,0 +1,114 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/node"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyConfigFile string
+	verifyRepair     bool
+	verifyFrom       uint64
+	verifyStateFrom  uint64
+)
+
+// verifyDBCmd represents the verifydb command
+var verifyDBCmd = &cobra.Command{
+	Use:   "verifydb",
+	Short: "verify the integrity of blockchain database offline",
+	Long: `Walk the canonical chain backward from the HEAD block, and verify the header linkage, total difficulty,
+merkle roots of txs, receipts and debts, height-to-hash mappings, tx and debt indices, and the existence
+of state roots. In repair mode, the height-to-hash mappings and tx/debt indices are rebuilt, but the account
+history index is not. The node must be stopped before verification. For example:
+		node.exe verifydb -c cmd\node.json --repair`,
+	Run: func(cmd *cobra.Command, args []string) {
+		nCfg, err := LoadConfigFromFile(verifyConfigFile, "")
+		if err != nil {
+			fmt.Printf("failed to reading the config file: %s\n", err.Error())
+			return
+		}
+
+		stateFrom := int64(-1)
+		if cmd.Flags().Changed("state-from") {
+			stateFrom = int64(verifyStateFrom)
+		}
+
+		result, err := verifyDB(nCfg, verifyFrom, stateFrom, verifyRepair)
+		if err != nil {
+			fmt.Println("failed to verify db:", err.Error())
+			return
+		}
+
+		for _, issue := range result.Issues {
+			fmt.Println(issue)
+		}
+
+		fmt.Printf("%v blocks verified, HEAD height: %v, issues: %v, repaired: %v\n",
+			result.Blocks, result.Head, len(result.Issues), result.Repaired())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyDBCmd)
+
+	verifyDBCmd.Flags().StringVarP(&verifyConfigFile, "config", "c", "", "seele node config file (required)")
+	verifyDBCmd.MustMarkFlagRequired("config")
+
+	verifyDBCmd.Flags().BoolVarP(&verifyRepair, "repair", "", false, "rebuild the height-to-hash mappings and tx/debt indices")
+	verifyDBCmd.Flags().Uint64VarP(&verifyFrom, "from", "", 0, "the lowest block height to verify, e.g. node bootstrapped from snapshot")
+	verifyDBCmd.Flags().Uint64VarP(&verifyStateFrom, "state-from", "", 0, "the lowest block height to check state root, default is 0 or the retained states if pruned")
+}
+
+// verifyDB verifies the blockchain database. If stateFrom is negative, the state roots are checked from
+// genesis block in archive mode, or only for the retained states in prune mode.
+func verifyDB(nCfg *node.Config, from uint64, stateFrom int64, repair bool) (*core.ChainVerifyResult, error) {
+	chainDB, accountStateDB, err := openChainDatabases(nCfg.BasicConfig.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer chainDB.Close()
+	defer accountStateDB.Close()
+
+	bcStore := store.NewBlockchainDatabase(chainDB)
+
+	if stateFrom < 0 {
+		stateFrom = 0
+
+		if nCfg.BasicConfig.StateGCMode == common.StateGCModePrune {
+			headHash, err := bcStore.GetHeadBlockHash()
+			if err != nil {
+				return nil, errors.NewStackedError(err, "failed to get HEAD block hash")
+			}
+
+			head, err := bcStore.GetBlockHeader(headHash)
+			if err != nil {
+				return nil, errors.NewStackedErrorf(err, "failed to get HEAD block header by hash %v", headHash)
+			}
+
+			if retained := nCfg.BasicConfig.GetStateRetained() + common.ConfirmedBlockNumber; head.Height >= retained {
+				stateFrom = int64(head.Height - retained + 1)
+			}
+		}
+	}
+
+	var count uint64
+	options := &core.ChainVerifyOptions{
+		From:      from,
+		StateFrom: uint64(stateFrom),
+		Repair:    repair,
+		Progress:  newChainProgress("verified", &count),
+	}
+
+	return core.VerifyChain(bcStore, accountStateDB, options)
+}
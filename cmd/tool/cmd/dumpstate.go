@@ -0,0 +1,111 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/seeleteam/go-seele/seele"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dumpDataDir string
+	dumpHeight  int64
+	dumpOutput  string
+
+	dumpStateCmd = &cobra.Command{
+		Use:   "dumpstate",
+		Short: "dump all the accounts of state at the specified block height in JSON lines",
+		Long: `The node must be stopped before dumping state. For example:
+		tool.exe dumpstate --datadir ~/.seele/node1 --height 100 --output state.dump`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := dumpState(); err != nil {
+				fmt.Println("failed to dump state:", err)
+			}
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(dumpStateCmd)
+
+	dumpStateCmd.Flags().StringVar(&dumpDataDir, "datadir", "", "data folder of the node (required)")
+	dumpStateCmd.MarkFlagRequired("datadir")
+
+	dumpStateCmd.Flags().Int64Var(&dumpHeight, "height", -1, "block height of state, default is the HEAD block")
+	dumpStateCmd.Flags().StringVarP(&dumpOutput, "output", "o", "", "output file, default is stdout")
+}
+
+func dumpState() error {
+	chainDB, err := leveldb.NewLevelDB(filepath.Join(dumpDataDir, seele.BlockChainDir))
+	if err != nil {
+		return errors.NewStackedError(err, "failed to open blockchain db")
+	}
+	defer chainDB.Close()
+
+	accountStateDB, err := leveldb.NewLevelDB(filepath.Join(dumpDataDir, seele.AccountStateDir))
+	if err != nil {
+		return errors.NewStackedError(err, "failed to open account state db")
+	}
+	defer accountStateDB.Close()
+
+	if err = store.ChainSchema.Check(chainDB); err != nil {
+		return err
+	}
+
+	if err = state.AccountStateSchema.Check(accountStateDB); err != nil {
+		return err
+	}
+
+	bcStore := store.NewBlockchainDatabase(chainDB)
+
+	var hash common.Hash
+	if dumpHeight < 0 {
+		hash, err = bcStore.GetHeadBlockHash()
+	} else {
+		hash, err = bcStore.GetBlockHash(uint64(dumpHeight))
+	}
+
+	if err != nil {
+		return errors.NewStackedErrorf(err, "failed to get block hash by height %v", dumpHeight)
+	}
+
+	header, err := bcStore.GetBlockHeader(hash)
+	if err != nil {
+		return errors.NewStackedErrorf(err, "failed to get block header by hash %v", hash)
+	}
+
+	var out io.Writer = os.Stdout
+	if len(dumpOutput) > 0 {
+		f, err := os.Create(dumpOutput)
+		if err != nil {
+			return errors.NewStackedErrorf(err, "failed to create file %v", dumpOutput)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	writer := bufio.NewWriter(out)
+	encoder := json.NewEncoder(writer)
+	if err = state.DumpState(header.StateHash, accountStateDB, func(account *state.DumpAccount) error {
+		return encoder.Encode(account)
+	}); err != nil {
+		return errors.NewStackedErrorf(err, "failed to dump state of block %v", hash)
+	}
+
+	return writer.Flush()
+}
@@ -9,7 +9,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/seeleteam/go-seele/seele"
+	"github.com/seeleteam/go-seele/api"
 	"github.com/spf13/cobra"
 )
 
@@ -27,7 +27,7 @@ var gettps = &cobra.Command{
 			sum := float64(0)
 
 			for _, client := range clientList {
-				var tps seele.TpsInfo
+				var tps api.TpsInfo
 				err := client.Call(&tps, "debug_getTPS")
 				if err != nil {
 					fmt.Println("failed to get tps ", err)
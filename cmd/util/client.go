@@ -72,19 +72,3 @@ func SendTx(client *rpc.Client, tx *types.Transaction) (bool, error) {
 
 	return result, err
 }
-
-// CallContract call contract
-func CallContract(client *rpc.Client, contractID, payLoad string, height int64) (map[string]interface{}, error) {
-	var info map[string]interface{}
-	err := client.Call(&info, "seele_call", contractID, payLoad, height)
-
-	return info, err
-}
-
-// GetNetworkID get network ID
-func GetNetworkID(client *rpc.Client) (string, error) {
-	var networkID string
-	err := client.Call(&networkID, "network_getNetworkID")
-
-	return networkID, err
-}
@@ -65,6 +65,15 @@ const (
 	// BFT data folder
 	BFTDataFolder = "bftdata"
 
+	// StateGCModeArchive keeps all the historical state trie nodes
+	StateGCModeArchive = "archive"
+
+	// StateGCModePrune keeps only the recent state trie nodes, and deletes the stale ones
+	StateGCModePrune = "prune"
+
+	// DefaultStateRetained is the default number of confirmed state roots retained in prune mode
+	DefaultStateRetained = 128
+
 	// EVMStackLimit increase evm stack limit to 8192
 	EVMStackLimit = 8192
 
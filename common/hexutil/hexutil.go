@@ -7,6 +7,8 @@ package hexutil
 
 import (
 	"encoding/hex"
+	"math/big"
+	"strconv"
 )
 
 var (
@@ -18,6 +20,10 @@ var (
 	ErrMissingPrefix = &decError{"hex string without 0x prefix"}
 	// ErrInvalidOddLength hex string of odd length
 	ErrInvalidOddLength = &decError{"hex string of odd length"}
+	// ErrLeadingZero hex quantity with leading zero digits
+	ErrLeadingZero = &decError{"hex quantity with leading zero digits"}
+	// ErrUint64Range hex quantity larger than 64 bits
+	ErrUint64Range = &decError{"hex quantity larger than 64 bits"}
 )
 
 type decError struct{ msg string }
@@ -58,6 +64,81 @@ func MustHexToBytes(input string) []byte {
 	return result
 }
 
+// EncodeUint64 encodes i as a hex quantity with 0x prefix and without leading zero digits, e.g. 0x1f.
+func EncodeUint64(i uint64) string {
+	return "0x" + strconv.FormatUint(i, 16)
+}
+
+// EncodeBig encodes i as a hex quantity with 0x prefix and without leading zero digits.
+// Negative value is encoded with a minus sign, e.g. -0x1f.
+func EncodeBig(i *big.Int) string {
+	if i == nil {
+		return "0x0"
+	}
+
+	if i.Sign() < 0 {
+		return "-0x" + new(big.Int).Neg(i).Text(16)
+	}
+
+	return "0x" + i.Text(16)
+}
+
+// DecodeUint64 decodes a hex quantity with 0x prefix.
+func DecodeUint64(input string) (uint64, error) {
+	digits, err := quantityDigits(input)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return 0, ErrUint64Range
+		}
+
+		return 0, ErrSyntax
+	}
+
+	return i, nil
+}
+
+// DecodeBig decodes a non-negative hex quantity with 0x prefix.
+func DecodeBig(input string) (*big.Int, error) {
+	digits, err := quantityDigits(input)
+	if err != nil {
+		return nil, err
+	}
+
+	i, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return nil, ErrSyntax
+	}
+
+	return i, nil
+}
+
+// quantityDigits returns the hex digits of a hex quantity.
+func quantityDigits(input string) (string, error) {
+	if len(input) == 0 {
+		return "", ErrEmptyString
+	}
+
+	if !Has0xPrefix(input) {
+		return "", ErrMissingPrefix
+	}
+
+	digits := input[2:]
+	if len(digits) == 0 {
+		return "", ErrEmptyString
+	}
+
+	if len(digits) > 1 && digits[0] == '0' {
+		return "", ErrLeadingZero
+	}
+
+	return digits, nil
+}
+
 // Has0xPrefix returns true if input start with 0x, otherwise false
 func Has0xPrefix(input string) bool {
 	return len(input) >= 2 && input[0] == '0' && (input[1] == 'x' || input[1] == 'X')
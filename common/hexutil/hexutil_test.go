@@ -5,6 +5,7 @@
 package hexutil
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -81,3 +82,33 @@ func Test_Has0xPrefix(t *testing.T) {
 	result = Has0xPrefix(str)
 	assert.Equal(t, result, false)
 }
+
+func Test_EncodeDecodeQuantity(t *testing.T) {
+	assert.Equal(t, EncodeUint64(0), "0x0")
+	assert.Equal(t, EncodeUint64(31), "0x1f")
+	assert.Equal(t, EncodeBig(big.NewInt(256)), "0x100")
+	assert.Equal(t, EncodeBig(big.NewInt(-31)), "-0x1f")
+	assert.Equal(t, EncodeBig(nil), "0x0")
+
+	i, err := DecodeUint64("0x1f")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, i, uint64(31))
+
+	b, err := DecodeBig("0x10000000000000000")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, b, new(big.Int).Lsh(big.NewInt(1), 64))
+
+	cases := map[string]error{
+		"":                    ErrEmptyString,
+		"0x":                  ErrEmptyString,
+		"1f":                  ErrMissingPrefix,
+		"0x01":                ErrLeadingZero,
+		"0xzz":                ErrSyntax,
+		"0x10000000000000000": ErrUint64Range,
+	}
+
+	for input, expected := range cases {
+		_, err = DecodeUint64(input)
+		assert.Equal(t, err, expected, input)
+	}
+}
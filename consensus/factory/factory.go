@@ -45,6 +45,11 @@ func GetBFTEngine(privateKey *ecdsa.PrivateKey, folder string) (consensus.Engine
 		return nil, errors.NewStackedError(err, "create bft folder failed")
 	}
 
+	if _, err = BFTSchema.Migrate(db); err != nil {
+		db.Close()
+		return nil, errors.NewStackedError(err, "failed to migrate bft db")
+	}
+
 	return backend.New(istanbul.DefaultConfig, privateKey, db), nil
 }
 
@@ -0,0 +1,14 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package factory
+
+import "github.com/seeleteam/go-seele/database/migration"
+
+// BFTSchema is the schema of BFT database in the BFT data folder.
+// Append migration here when the key layout changed.
+var BFTSchema = migration.NewSchema("bft",
+	&migration.Migration{Version: 1, Description: "initial versioned layout"},
+)
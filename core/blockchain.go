@@ -20,6 +20,7 @@ import (
 	"github.com/seeleteam/go-seele/core/svm"
 	"github.com/seeleteam/go-seele/core/txs"
 	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/core/vm"
 	"github.com/seeleteam/go-seele/database"
 	"github.com/seeleteam/go-seele/event"
 	"github.com/seeleteam/go-seele/log"
@@ -88,6 +89,12 @@ type Blockchain struct {
 	debtVerifier types.DebtVerifier
 
 	lastBlockTime time.Time // last sucessful written block time.
+
+	statePruner   *StatePruner // nil in archive mode, otherwise prunes the stale state periodically
+	stateRetained uint64       // number of confirmed block states retained when pruning
+	statePruning  int32        // 1 if state pruning in progress, otherwise 0
+
+	headRollbackEventManager *event.EventManager // fires the hashes of blocks removed from canonical chain
 }
 
 // NewBlockchain returns an initialized blockchain with the given store and account state DB.
@@ -100,6 +107,8 @@ func NewBlockchain(bcStore store.BlockchainStore, accountStateDB database.Databa
 		log:            log.GetLogger("blockchain"),
 		debtVerifier:   verifier,
 		lastBlockTime:  time.Now(),
+
+		headRollbackEventManager: event.NewEventManager(),
 	}
 
 	var err error
@@ -332,7 +341,8 @@ func (bc *Blockchain) doWriteBlock(block *types.Block, pool *Pool) error {
 	}
 	copy(currentBlock.Transactions, block.Transactions)
 	for i, tx := range block.Transactions { // for 1st tx is reward tx, no need to check the duplicate
-		if i == 0 {
+		// pool is nil when import blocks offline
+		if i == 0 || pool == nil {
 			continue
 		}
 		if !pool.cachedTxs.has(tx.Hash) {
@@ -385,7 +395,10 @@ func (bc *Blockchain) doWriteBlock(block *types.Block, pool *Pool) error {
 
 	// If the new block has larger TD, the canonical chain will be changed.
 	// In this case, need to update the height-to-blockHash mapping for the new canonical chain.
+	var rollbackHashes []common.Hash
 	if isHead {
+		oldHead := bc.CurrentBlock()
+
 		largerHeight := block.Header.Height + 1
 		if err = DeleteLargerHeightBlocks(bc.bcStore, largerHeight, bc.rp); err != nil {
 			bc.log.Error(errors.NewStackedErrorf(err, "failed to delete larger height blocks, height = %v", largerHeight).Error())
@@ -397,6 +410,12 @@ func (bc *Blockchain) doWriteBlock(block *types.Block, pool *Pool) error {
 			bc.log.Error(errors.NewStackedErrorf(err, "failed to overwrite stale blocks, hash = %v", previousHash).Error())
 		}
 		auditor.Audit("succeed to overwrite stale blocks, hash = %v", previousHash)
+
+		if !previousHash.Equal(oldHead.HeaderHash) {
+			if rollbackHashes, err = staleCanonicalBlocks(bc.bcStore, oldHead.Header); err != nil {
+				bc.log.Error(errors.NewStackedErrorf(err, "failed to get stale canonical blocks, old HEAD = %v", oldHead.HeaderHash).Error())
+			}
+		}
 	}
 
 	// update block header after meta info updated
@@ -415,7 +434,15 @@ func (bc *Blockchain) doWriteBlock(block *types.Block, pool *Pool) error {
 			}
 		})
 
+		if len(rollbackHashes) > 0 {
+			bc.headRollbackEventManager.Fire(rollbackHashes)
+		}
+
 		event.ChainHeaderChangedEventMananger.Fire(block)
+
+		if bc.statePruner != nil && block.Header.Height%statePruneInterval == 0 && bc.pruneStateAsync(block.Header.Height) {
+			auditor.Audit("state pruning started, height = %v", block.Header.Height)
+		}
 	}
 
 	bc.lastBlockTime = time.Now()
@@ -572,12 +599,21 @@ func (bc *Blockchain) applyRewardAndRegularTxs(statedb *state.Statedb, rewardTx
 // ApplyTransaction applies a transaction, changes corresponding statedb and generates its receipt
 func (bc *Blockchain) ApplyTransaction(tx *types.Transaction, txIndex int, coinbase common.Address, statedb *state.Statedb,
 	blockHeader *types.BlockHeader) (*types.Receipt, error) {
+	return bc.ApplyTransactionWithVMConfig(tx, txIndex, coinbase, statedb, blockHeader, nil)
+}
+
+// ApplyTransactionWithVMConfig applies a transaction with the specified EVM config, e.g. to trace
+// the execution, changes corresponding statedb and generates its receipt. The default EVM config is
+// used if vmConfig is nil.
+func (bc *Blockchain) ApplyTransactionWithVMConfig(tx *types.Transaction, txIndex int, coinbase common.Address, statedb *state.Statedb,
+	blockHeader *types.BlockHeader, vmConfig *vm.Config) (*types.Receipt, error) {
 	ctx := &svm.Context{
 		Tx:          tx,
 		TxIndex:     txIndex,
 		Statedb:     statedb,
 		BlockHeader: blockHeader,
 		BcStore:     bc.bcStore,
+		VMConfig:    vmConfig,
 	}
 	receipt, err := svm.Process(ctx, blockHeader.Height)
 	if err != nil {
@@ -587,6 +623,57 @@ func (bc *Blockchain) ApplyTransaction(tx *types.Transaction, txIndex int, coinb
 	return receipt, nil
 }
 
+// ReplayBlock re-executes the debts and the first txCount txs (including the reward tx) of
+// the specified block on the state of its parent block, and returns the statedb and receipts.
+// All txs are re-executed if txCount is out of range. The vmConfig returns the EVM config of
+// each regular tx, e.g. to trace the execution, and the default EVM config is used if it is nil.
+// Note, the block is supposed to be validated, and the statedb is not committed.
+func (bc *Blockchain) ReplayBlock(block *types.Block, txCount int, vmConfig func(txIndex int) *vm.Config) (*state.Statedb, []*types.Receipt, error) {
+	if block.Header.Height == 0 {
+		return nil, nil, errors.New("genesis block cannot be replayed")
+	}
+
+	parent, err := bc.bcStore.GetBlockHeader(block.Header.PreviousBlockHash)
+	if err != nil {
+		return nil, nil, errors.NewStackedErrorf(err, "failed to get parent block header by hash %v", block.Header.PreviousBlockHash.Hex())
+	}
+
+	statedb, err := state.NewStatedb(parent.StateHash, bc.accountStateDB)
+	if err != nil {
+		return nil, nil, errors.NewStackedErrorf(err, "failed to create statedb by root hash %v", parent.StateHash.Hex())
+	}
+
+	for _, d := range block.Debts {
+		if err = applyDebt(statedb, d, block.Header.Creator); err != nil {
+			return nil, nil, errors.NewStackedError(err, "failed to apply debt")
+		}
+	}
+
+	if txCount < 0 || txCount > len(block.Transactions) {
+		txCount = len(block.Transactions)
+	}
+
+	receipts := make([]*types.Receipt, txCount)
+	for i, tx := range block.Transactions[:txCount] {
+		if i == 0 {
+			receipts[i], err = txs.ApplyRewardTx(tx, statedb)
+		} else {
+			var config *vm.Config
+			if vmConfig != nil {
+				config = vmConfig(i)
+			}
+
+			receipts[i], err = bc.ApplyTransactionWithVMConfig(tx, i, block.Header.Creator, statedb, block.Header, config)
+		}
+
+		if err != nil {
+			return nil, nil, errors.NewStackedErrorf(err, "failed to apply tx[%v]", i)
+		}
+	}
+
+	return statedb, receipts, nil
+}
+
 // ApplyDebtWithoutVerify applies a debt and update statedb.
 func (bc *Blockchain) ApplyDebtWithoutVerify(statedb *state.Statedb, d *types.Debt, coinbase common.Address) error {
 	debtIndex, _ := bc.bcStore.GetDebtIndex(d.Hash)
@@ -594,6 +681,11 @@ func (bc *Blockchain) ApplyDebtWithoutVerify(statedb *state.Statedb, d *types.De
 		return fmt.Errorf("debt already packed, debt hash %s", d.Hash.Hex())
 	}
 
+	return applyDebt(statedb, d, coinbase)
+}
+
+// applyDebt updates statedb with the specified debt, which is supposed not packed before.
+func applyDebt(statedb *state.Statedb, d *types.Debt, coinbase common.Address) error {
 	if !statedb.Exist(d.Data.Account) {
 		statedb.CreateAccount(d.Data.Account)
 	}
@@ -752,6 +844,39 @@ func overwriteSingleStaleBlock(bcStore store.BlockchainStore, hash common.Hash)
 	return true, header.PreviousBlockHash, nil
 }
 
+// staleCanonicalBlocks returns the hashes of blocks in ascending order that are no longer in the
+// canonical chain, starting from the specified old HEAD block header back to the common ancestor.
+func staleCanonicalBlocks(bcStore store.BlockchainStore, oldHead *types.BlockHeader) ([]common.Hash, error) {
+	var hashes []common.Hash
+
+	for header := oldHead; header.Height > genesisBlockHeight; {
+		hash := header.Hash()
+		canonicalHash, err := bcStore.GetBlockHash(header.Height)
+		if err == nil && canonicalHash.Equal(hash) {
+			break
+		}
+
+		if err != nil && err != leveldbErrors.ErrNotFound {
+			return nil, errors.NewStackedErrorf(err, "failed to get block hash by height %v", header.Height)
+		}
+
+		hashes = append([]common.Hash{hash}, hashes...)
+
+		parentHash := header.PreviousBlockHash
+		if header, err = bcStore.GetBlockHeader(parentHash); err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get block header by hash %v", parentHash)
+		}
+	}
+
+	return hashes, nil
+}
+
+// GetHeadRollbackEventManager returns the event manager that fires the hashes of blocks
+// removed from the canonical chain in ascending order when the canonical chain changed.
+func (bc *Blockchain) GetHeadRollbackEventManager() *event.EventManager {
+	return bc.headRollbackEventManager
+}
+
 // GetShardNumber returns the shard number of blockchain.
 func (bc *Blockchain) GetShardNumber() (uint, error) {
 	data, err := getShardInfo(bc.genesisBlock)
@@ -771,10 +896,6 @@ func (bc *Blockchain) PutTd(td *big.Int) {
 	panic("Not Supported")
 }
 
-func (bc *Blockchain) GetHeadRollbackEventManager() *event.EventManager {
-	panic("Not Supported")
-}
-
 func (bc *Blockchain) recoverHeightIndices() {
 	bc.log.Info("checking blockchain database...")
 	curBlock := bc.CurrentBlock()
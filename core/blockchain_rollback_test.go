@@ -0,0 +1,77 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/seeleteam/go-seele/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Blockchain_StaleCanonicalBlocks(t *testing.T) {
+	db, dispose := leveldb.NewTestDatabase()
+	defer dispose()
+	bcStore := store.NewBlockchainDatabase(db)
+
+	putHeader := func(parent *types.BlockHeader, height uint64, difficulty int64, isHead bool) *types.BlockHeader {
+		header := &types.BlockHeader{Height: height, Difficulty: big.NewInt(difficulty), CreateTimestamp: big.NewInt(1)}
+		if parent != nil {
+			header.PreviousBlockHash = parent.Hash()
+		}
+
+		assert.Equal(t, bcStore.PutBlockHeader(header.Hash(), header, big.NewInt(int64(height)), isHead), nil)
+		return header
+	}
+
+	// genesis <- block11 <- block12 <- block13
+	//         <- block21 <- block22 (canonical)
+	genesis := putHeader(nil, 0, 1, true)
+	block11 := putHeader(genesis, 1, 1, true)
+	block12 := putHeader(block11, 2, 1, true)
+	block13 := putHeader(block12, 3, 1, true)
+	block21 := putHeader(genesis, 1, 2, true)
+	putHeader(block21, 2, 2, true)
+	_, err := bcStore.DeleteBlockHash(3)
+	assert.Equal(t, err, nil)
+
+	hashes, err := staleCanonicalBlocks(bcStore, block13)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, hashes, []common.Hash{block11.Hash(), block12.Hash(), block13.Hash()})
+
+	// no stale blocks for the canonical HEAD
+	hashes, err = staleCanonicalBlocks(bcStore, block21)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(hashes), 0)
+}
+
+func Test_Blockchain_HeadRollbackEvent(t *testing.T) {
+	bc := NewTestBlockchain()
+
+	var rollbacks [][]common.Hash
+	bc.GetHeadRollbackEventManager().AddListener(func(e event.Event) {
+		rollbacks = append(rollbacks, e.([]common.Hash))
+	})
+
+	// genesis <- block11 <- block12
+	block11 := newTestBlock(bc, bc.genesisBlock.HeaderHash, 1, 3, 0)
+	assert.Equal(t, bc.WriteBlock(block11, nil), error(nil))
+	block12 := newTestBlock(bc, block11.HeaderHash, 2, 3, 3)
+	assert.Equal(t, bc.WriteBlock(block12, nil), error(nil))
+	assert.Equal(t, len(rollbacks), 0)
+
+	// genesis <- block11 <- block12
+	//         <- block21 (HEAD)
+	block21 := newTestBlock(bc, bc.genesisBlock.HeaderHash, 1, 3, 0)
+	assert.Equal(t, bc.WriteBlock(block21, nil), error(nil))
+	assert.Equal(t, bc.CurrentBlock().HeaderHash, block21.HeaderHash)
+	assert.Equal(t, rollbacks, [][]common.Hash{{block11.HeaderHash, block12.HeaderHash}})
+}
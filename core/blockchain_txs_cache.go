@@ -9,6 +9,7 @@ import (
 	"github.com/seeleteam/go-seele/common/errors"
 	"github.com/seeleteam/go-seele/core/types"
 	"github.com/seeleteam/go-seele/log"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
 )
 
 var errTxCacheFull = errors.New("CachedTxs reaches max")
@@ -59,6 +60,12 @@ func (c *CachedTxs) init(chain blockchain) error {
 	}
 	for start < curHeight {
 		dup, tc, err := c.getTxsInOneBlock(chain, start)
+		if err == leveldbErrors.ErrNotFound {
+			// blocks may be missing, e.g. node bootstrapped from a state snapshot.
+			start++
+			continue
+		}
+
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,111 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+var (
+	// ErrExportRange is returned when export blocks with invalid height range.
+	ErrExportRange = errors.New("invalid block height range to export")
+
+	// ErrImportDebtUnverifiable is returned when import block with cross shard debts but no debt verifier.
+	ErrImportDebtUnverifiable = errors.New("no debt verifier to validate cross shard debts")
+)
+
+// ChainProgress is called when a block is exported or imported, count is the number of blocks handled so far.
+type ChainProgress func(block *types.Block, count uint64)
+
+// ExportChain writes the canonical blocks in height range [from, to] into the writer as a RLP stream.
+func ExportChain(w io.Writer, bcStore store.BlockchainStore, from, to uint64, progress ChainProgress) error {
+	if from > to {
+		return ErrExportRange
+	}
+
+	for height := from; height <= to; height++ {
+		block, err := bcStore.GetBlockByHeight(height)
+		if err != nil {
+			return errors.NewStackedErrorf(err, "failed to get block by height %v", height)
+		}
+
+		if err = rlp.Encode(w, block); err != nil {
+			return errors.NewStackedErrorf(err, "failed to write block %v", height)
+		}
+
+		if progress != nil {
+			progress(block, height-from+1)
+		}
+	}
+
+	return nil
+}
+
+// ImportChain reads the RLP encoded blocks from the reader, and writes them into the blockchain
+// with full validation. The blocks that already exist in blockchain are skipped, and the blocks
+// with cross shard debts are refused if blockchain has no debt verifier to validate them.
+// It returns the number of blocks that imported.
+func ImportChain(r io.Reader, bc *Blockchain, progress ChainProgress) (uint64, error) {
+	// txs in recent blocks to check duplicate txs, the same as tx pool does for synced blocks.
+	cachedTxs := NewCachedTxs(CachedCapacity)
+	if err := cachedTxs.init(bc); err != nil {
+		return 0, errors.NewStackedError(err, "failed to cache txs in recent blocks")
+	}
+
+	stream := rlp.NewStream(r, 0)
+	imported := uint64(0)
+
+	for {
+		block := &types.Block{}
+		if err := stream.Decode(block); err == io.EOF {
+			return imported, nil
+		} else if err != nil {
+			return imported, errors.NewStackedErrorf(err, "failed to read block at index %v", imported)
+		}
+
+		if block.Header == nil {
+			return imported, types.ErrBlockHeaderNil
+		}
+
+		exist, err := bc.bcStore.HasBlock(block.HeaderHash)
+		if err != nil {
+			return imported, errors.NewStackedErrorf(err, "failed to check block existence by hash %v", block.HeaderHash)
+		}
+
+		if exist {
+			continue
+		}
+
+		if len(block.Debts) > 0 && bc.debtVerifier == nil {
+			return imported, errors.NewStackedErrorf(ErrImportDebtUnverifiable, "failed to import block %v, height %v", block.HeaderHash, block.Header.Height)
+		}
+
+		// 1st tx is reward tx, no need to check the duplicate
+		for i := 1; i < len(block.Transactions); i++ {
+			if hash := block.Transactions[i].Hash; cachedTxs.has(hash) {
+				return imported, errors.NewStackedErrorf(errDuplicateTx, "failed to import block %v, height %v, tx %v", block.HeaderHash, block.Header.Height, hash)
+			}
+		}
+
+		if err = bc.WriteBlock(block, nil); err != nil {
+			return imported, errors.NewStackedErrorf(err, "failed to write block %v, height %v", block.HeaderHash, block.Header.Height)
+		}
+
+		for i := 1; i < len(block.Transactions); i++ {
+			cachedTxs.add(block.Transactions[i])
+		}
+
+		imported++
+		if progress != nil {
+			progress(block, imported)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExportChain(t *testing.T) {
+	bcStore, _, _ := newTestSnapshotChain(t, 10)
+
+	var buf bytes.Buffer
+	var heights []uint64
+	err := ExportChain(&buf, bcStore, 3, 7, func(block *types.Block, count uint64) {
+		heights = append(heights, block.Header.Height)
+		assert.Equal(t, count, uint64(len(heights)))
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, heights, []uint64{3, 4, 5, 6, 7})
+
+	stream := rlp.NewStream(&buf, 0)
+	for _, height := range heights {
+		block := &types.Block{}
+		assert.Equal(t, stream.Decode(block), nil)
+
+		expected, err := bcStore.GetBlockByHeight(height)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, block.HeaderHash, expected.HeaderHash)
+		assert.Equal(t, block.Header.Hash(), expected.HeaderHash)
+	}
+	assert.Equal(t, stream.Decode(&types.Block{}), io.EOF)
+
+	// invalid range
+	assert.Equal(t, ExportChain(&buf, bcStore, 7, 3, nil), ErrExportRange)
+
+	// block not found
+	assert.Equal(t, ExportChain(&buf, bcStore, 8, 11, nil) != nil, true)
+}
+
+func Test_ImportChain_SkipExisting(t *testing.T) {
+	bcStore, _, _ := newTestSnapshotChain(t, 5)
+
+	var buf bytes.Buffer
+	assert.Equal(t, ExportChain(&buf, bcStore, 1, 5, nil), nil)
+
+	// all blocks exist
+	bc := &Blockchain{bcStore: bcStore}
+	imported, err := ImportChain(bytes.NewReader(buf.Bytes()), bc, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, imported, uint64(0))
+
+	// invalid RLP stream
+	imported, err = ImportChain(bytes.NewReader([]byte{0x01, 0x02}), bc, nil)
+	assert.Equal(t, err != nil, true)
+	assert.Equal(t, imported, uint64(0))
+}
+
+func Test_ImportChain_Refuse(t *testing.T) {
+	bcStore, _, _ := newTestSnapshotChain(t, 3)
+
+	// block 4 packs the tx, and block 5 is the HEAD
+	tx := types.NewTestTxDetail(1, 1, 1)
+	prev, err := bcStore.GetBlockByHeight(3)
+	assert.Equal(t, err, nil)
+	for h := uint64(4); h <= 5; h++ {
+		txs := []*types.Transaction{types.NewTestTxDetail(1, 1, h)}
+		if h == 4 {
+			txs = append(txs, tx)
+		}
+
+		block := types.NewBlock(&types.BlockHeader{PreviousBlockHash: prev.HeaderHash, Difficulty: big.NewInt(1), Height: h}, txs, nil, nil)
+		assert.Equal(t, bcStore.PutBlock(block, new(big.Int).SetUint64(h+1), true), nil)
+		prev = block
+	}
+
+	header := &types.BlockHeader{PreviousBlockHash: prev.HeaderHash, Difficulty: big.NewInt(1), Height: 6}
+	importBlock := func(block *types.Block) (uint64, error) {
+		var buf bytes.Buffer
+		assert.Equal(t, rlp.Encode(&buf, block), nil)
+		return ImportChain(&buf, &Blockchain{bcStore: bcStore}, nil)
+	}
+
+	// duplicate tx in recent blocks
+	imported, err := importBlock(types.NewBlock(header, []*types.Transaction{types.NewTestTxDetail(1, 1, 6), tx}, nil, nil))
+	assert.Equal(t, errors.IsOrContains(err, errDuplicateTx), true)
+	assert.Equal(t, imported, uint64(0))
+
+	// cross shard debts without debt verifier
+	imported, err = importBlock(types.NewBlock(header, []*types.Transaction{types.NewTestTxDetail(1, 1, 6)}, nil, []*types.Debt{types.NewTestDebt()}))
+	assert.Equal(t, errors.IsOrContains(err, ErrImportDebtUnverifiable), true)
+	assert.Equal(t, imported, uint64(0))
+}
@@ -0,0 +1,324 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/database"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+)
+
+// ChainIssue is an inconsistency found in the blockchain database.
+type ChainIssue struct {
+	Height   uint64
+	Hash     common.Hash
+	Message  string
+	Repaired bool
+}
+
+func (issue *ChainIssue) String() string {
+	status := ""
+	if issue.Repaired {
+		status = " (repaired)"
+	}
+
+	return fmt.Sprintf("height %v, block %v: %v%v", issue.Height, issue.Hash.Hex(), issue.Message, status)
+}
+
+// ChainVerifyOptions is the options to verify the blockchain database.
+type ChainVerifyOptions struct {
+	From      uint64        // the lowest block height to verify, e.g. the node bootstrapped from snapshot.
+	StateFrom uint64        // the lowest block height to check the existence of state root, e.g. the state is pruned.
+	Repair    bool          // indicates whether to repair the height-to-hash mappings and tx/debt indices.
+	Progress  ChainProgress // called when a block is verified
+}
+
+// ChainVerifyResult is the result of blockchain database verification.
+type ChainVerifyResult struct {
+	Head   uint64        // height of the HEAD block
+	Blocks uint64        // number of verified blocks
+	Issues []*ChainIssue // inconsistencies found
+}
+
+// Repaired returns the number of repaired issues.
+func (result *ChainVerifyResult) Repaired() int {
+	count := 0
+	for _, issue := range result.Issues {
+		if issue.Repaired {
+			count++
+		}
+	}
+
+	return count
+}
+
+// chainVerifier walks the canonical chain backward from HEAD block via the previous block hash.
+type chainVerifier struct {
+	bcStore        store.BlockchainStore
+	accountStateDB database.Database
+	options        *ChainVerifyOptions
+	result         *ChainVerifyResult
+}
+
+// VerifyChain verifies the consistency of canonical chain in the blockchain database, including
+// header linkage, total difficulty, merkle roots of txs, receipts and debts, height-to-hash mappings,
+// tx and debt indices, and the existence of state root in account state database.
+func VerifyChain(bcStore store.BlockchainStore, accountStateDB database.Database, options *ChainVerifyOptions) (*ChainVerifyResult, error) {
+	if options == nil {
+		options = &ChainVerifyOptions{}
+	}
+
+	headHash, err := bcStore.GetHeadBlockHash()
+	if err != nil {
+		return nil, errors.NewStackedError(err, "failed to get HEAD block hash")
+	}
+
+	head, err := bcStore.GetBlockHeader(headHash)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to get HEAD block header by hash %v", headHash)
+	}
+
+	v := &chainVerifier{
+		bcStore:        bcStore,
+		accountStateDB: accountStateDB,
+		options:        options,
+		result:         &ChainVerifyResult{Head: head.Height},
+	}
+
+	if err = v.verifyStaleMappings(head.Height); err != nil {
+		return nil, err
+	}
+
+	hash := headHash
+	for {
+		block, err := bcStore.GetBlock(hash)
+		if err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get block by hash %v", hash)
+		}
+
+		if err = v.verifyBlock(block); err != nil {
+			return nil, err
+		}
+
+		v.result.Blocks++
+		if options.Progress != nil {
+			options.Progress(block, v.result.Blocks)
+		}
+
+		if block.Header.Height <= options.From || block.Header.Height == genesisBlockHeight {
+			break
+		}
+
+		// verify linkage with the parent block
+		parentHash := block.Header.PreviousBlockHash
+		parent, err := bcStore.GetBlockHeader(parentHash)
+		if err == leveldbErrors.ErrNotFound {
+			v.report(block, "parent block %v not found", parentHash.Hex())
+			break
+		} else if err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get block header by hash %v", parentHash)
+		}
+
+		if parent.Height+1 != block.Header.Height {
+			v.report(block, "parent block height %v mismatch", parent.Height)
+		}
+
+		if err = v.verifyTD(block, parentHash); err != nil {
+			return nil, err
+		}
+
+		hash = parentHash
+	}
+
+	return v.result, nil
+}
+
+func (v *chainVerifier) report(block *types.Block, format string, args ...interface{}) *ChainIssue {
+	issue := &ChainIssue{
+		Height:  block.Header.Height,
+		Hash:    block.HeaderHash,
+		Message: fmt.Sprintf(format, args...),
+	}
+
+	v.result.Issues = append(v.result.Issues, issue)
+
+	return issue
+}
+
+// verifyStaleMappings checks the height-to-hash mappings above HEAD block.
+func (v *chainVerifier) verifyStaleMappings(headHeight uint64) error {
+	for height := headHeight + 1; ; height++ {
+		hash, err := v.bcStore.GetBlockHash(height)
+		if err == leveldbErrors.ErrNotFound {
+			return nil
+		} else if err != nil {
+			return errors.NewStackedErrorf(err, "failed to get block hash by height %v", height)
+		}
+
+		issue := &ChainIssue{Height: height, Hash: hash, Message: "height-to-hash mapping above HEAD block"}
+		v.result.Issues = append(v.result.Issues, issue)
+
+		if v.options.Repair {
+			if _, err = v.bcStore.DeleteBlockHash(height); err != nil {
+				return errors.NewStackedErrorf(err, "failed to delete block hash by height %v", height)
+			}
+
+			issue.Repaired = true
+		}
+	}
+}
+
+func (v *chainVerifier) verifyBlock(block *types.Block) error {
+	height := block.Header.Height
+
+	if h := block.Header.Hash(); !h.Equal(block.HeaderHash) {
+		v.report(block, "header hash mismatch")
+	}
+
+	if err := v.verifyMapping(block); err != nil {
+		return err
+	}
+
+	if height >= v.options.StateFrom {
+		found, err := v.accountStateDB.Has(append(common.CopyBytes(state.TrieDbPrefix), block.Header.StateHash.Bytes()...))
+		if err != nil {
+			return errors.NewStackedErrorf(err, "failed to check state root %v", block.Header.StateHash)
+		}
+
+		if !found {
+			v.report(block, "state root %v not found", block.Header.StateHash.Hex())
+		}
+	}
+
+	// genesis block has no body and receipts.
+	if height == genesisBlockHeight {
+		return nil
+	}
+
+	if h := types.MerkleRootHash(block.Transactions); !h.Equal(block.Header.TxHash) {
+		v.report(block, "tx root hash mismatch")
+	}
+
+	if h := types.DebtMerkleRootHash(types.NewDebts(block.Transactions)); !h.Equal(block.Header.TxDebtHash) {
+		v.report(block, "tx debt root hash mismatch")
+	}
+
+	if h := types.DebtMerkleRootHash(block.Debts); !h.Equal(block.Header.DebtHash) {
+		v.report(block, "debt root hash mismatch")
+	}
+
+	receipts, err := v.bcStore.GetReceiptsByBlockHash(block.HeaderHash)
+	if err == leveldbErrors.ErrNotFound {
+		v.report(block, "receipts not found")
+	} else if err != nil {
+		return errors.NewStackedErrorf(err, "failed to get receipts by block hash %v", block.HeaderHash)
+	} else if h := types.ReceiptMerkleRootHash(receipts); !h.Equal(block.Header.ReceiptHash) {
+		v.report(block, "receipt root hash mismatch")
+	}
+
+	return v.verifyIndices(block)
+}
+
+// verifyMapping checks the height-to-hash mapping of canonical block.
+func (v *chainVerifier) verifyMapping(block *types.Block) error {
+	hash, err := v.bcStore.GetBlockHash(block.Header.Height)
+	if err != nil && err != leveldbErrors.ErrNotFound {
+		return errors.NewStackedErrorf(err, "failed to get block hash by height %v", block.Header.Height)
+	}
+
+	if err == nil && hash.Equal(block.HeaderHash) {
+		return nil
+	}
+
+	var issue *ChainIssue
+	if err != nil {
+		issue = v.report(block, "height-to-hash mapping not found")
+	} else {
+		issue = v.report(block, "height-to-hash mapping mismatch, stored hash %v", hash.Hex())
+	}
+
+	if v.options.Repair {
+		if err = v.bcStore.PutBlockHash(block.Header.Height, block.HeaderHash); err != nil {
+			return errors.NewStackedErrorf(err, "failed to put block hash by height %v", block.Header.Height)
+		}
+
+		issue.Repaired = true
+	}
+
+	return nil
+}
+
+// verifyIndices checks the tx and debt indices of canonical block.
+func (v *chainVerifier) verifyIndices(block *types.Block) error {
+	var issues []*ChainIssue
+
+	for i, tx := range block.Transactions {
+		index, err := v.bcStore.GetTxIndex(tx.Hash)
+		if err == leveldbErrors.ErrNotFound {
+			issues = append(issues, v.report(block, "tx %v index not found", tx.Hash.Hex()))
+		} else if err != nil {
+			return errors.NewStackedErrorf(err, "failed to get tx index by hash %v", tx.Hash)
+		} else if !index.BlockHash.Equal(block.HeaderHash) || index.Index != uint(i) {
+			issues = append(issues, v.report(block, "tx %v index mismatch", tx.Hash.Hex()))
+		}
+	}
+
+	for i, debt := range block.Debts {
+		index, err := v.bcStore.GetDebtIndex(debt.Hash)
+		if err == leveldbErrors.ErrNotFound {
+			issues = append(issues, v.report(block, "debt %v index not found", debt.Hash.Hex()))
+		} else if err != nil {
+			return errors.NewStackedErrorf(err, "failed to get debt index by hash %v", debt.Hash)
+		} else if !index.BlockHash.Equal(block.HeaderHash) || index.Index != uint(i) {
+			issues = append(issues, v.report(block, "debt %v index mismatch", debt.Hash.Hex()))
+		}
+	}
+
+	if len(issues) == 0 || !v.options.Repair {
+		return nil
+	}
+
+	if err := v.bcStore.AddIndices(block); err != nil {
+		return errors.NewStackedErrorf(err, "failed to add indices of block %v", block.HeaderHash)
+	}
+
+	for _, issue := range issues {
+		issue.Repaired = true
+	}
+
+	return nil
+}
+
+// verifyTD checks the total difficulty of block against the parent block.
+func (v *chainVerifier) verifyTD(block *types.Block, parentHash common.Hash) error {
+	td, err := v.bcStore.GetBlockTotalDifficulty(block.HeaderHash)
+	if err == leveldbErrors.ErrNotFound {
+		v.report(block, "total difficulty not found")
+		return nil
+	} else if err != nil {
+		return errors.NewStackedErrorf(err, "failed to get block TD by hash %v", block.HeaderHash)
+	}
+
+	parentTD, err := v.bcStore.GetBlockTotalDifficulty(parentHash)
+	if err == leveldbErrors.ErrNotFound {
+		return nil // reported when verify the parent block
+	} else if err != nil {
+		return errors.NewStackedErrorf(err, "failed to get block TD by hash %v", parentHash)
+	}
+
+	if expected := new(big.Int).Add(parentTD, block.Header.Difficulty); td.Cmp(expected) != 0 {
+		v.report(block, "total difficulty %v mismatch, expected %v", td, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/database/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifyChain(t *testing.T) {
+	bcStore, accountStateDB, _ := newTestSnapshotChain(t, 10)
+
+	result, err := VerifyChain(bcStore, accountStateDB, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.Head, uint64(10))
+	assert.Equal(t, result.Blocks, uint64(11))
+	assert.Equal(t, len(result.Issues), 0)
+
+	// verify from the specified height
+	result, err = VerifyChain(bcStore, accountStateDB, &ChainVerifyOptions{From: 8})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.Blocks, uint64(3))
+	assert.Equal(t, len(result.Issues), 0)
+}
+
+func Test_VerifyChain_Repair(t *testing.T) {
+	db := memory.NewMemoryDB()
+	bcStore, accountStateDB, _ := newTestSnapshotChain(t, 10)
+
+	// copy the chain into db to corrupt
+	chain := store.NewBlockchainDatabase(db)
+	for height := uint64(0); height <= 10; height++ {
+		block, err := bcStore.GetBlockByHeight(height)
+		assert.Equal(t, err, nil)
+		td, err := bcStore.GetBlockTotalDifficulty(block.HeaderHash)
+		assert.Equal(t, err, nil)
+
+		if height == 0 {
+			assert.Equal(t, chain.PutBlockHeader(block.HeaderHash, block.Header, td, true), nil)
+			continue
+		}
+
+		receipts, err := bcStore.GetReceiptsByBlockHash(block.HeaderHash)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, chain.PutReceipts(block.HeaderHash, receipts), nil)
+		assert.Equal(t, chain.PutBlock(block, td, true), nil)
+	}
+
+	block3, _ := chain.GetBlockByHeight(3)
+	block2, _ := chain.GetBlockByHeight(2)
+	block5, _ := chain.GetBlockByHeight(5)
+
+	// corrupt tx index, height-to-hash mappings and state
+	assert.Equal(t, db.Delete(append([]byte("i"), block3.Transactions[0].Hash.Bytes()...)), nil)
+	assert.Equal(t, chain.PutBlockHash(5, block3.HeaderHash), nil)
+	assert.Equal(t, chain.PutBlockHash(11, block3.HeaderHash), nil)
+	assert.Equal(t, accountStateDB.Delete(append(common.CopyBytes(state.TrieDbPrefix), block2.Header.StateHash.Bytes()...)), nil)
+
+	result, err := VerifyChain(chain, accountStateDB, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.Blocks, uint64(11))
+	assert.Equal(t, len(result.Issues), 4)
+	assert.Equal(t, result.Repaired(), 0)
+
+	// state of block 2 is not checked
+	result, err = VerifyChain(chain, accountStateDB, &ChainVerifyOptions{StateFrom: 3})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(result.Issues), 3)
+
+	// repair
+	result, err = VerifyChain(chain, accountStateDB, &ChainVerifyOptions{Repair: true})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(result.Issues), 4)
+	assert.Equal(t, result.Repaired(), 3)
+
+	hash, err := chain.GetBlockHash(5)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, hash, block5.HeaderHash)
+
+	_, err = chain.GetBlockHash(11)
+	assert.Equal(t, err != nil, true)
+
+	index, err := chain.GetTxIndex(block3.Transactions[0].Hash)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, index.BlockHash, block3.HeaderHash)
+
+	// only state issue left
+	result, err = VerifyChain(chain, accountStateDB, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(result.Issues), 1)
+	assert.Equal(t, result.Issues[0].Height, uint64(2))
+}
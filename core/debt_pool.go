@@ -23,6 +23,7 @@ type DebtPool struct {
 	*Pool
 	verifier         types.DebtVerifier
 	toConfirmedDebts *ConcurrentDebtMap
+	journal          *poolJournal
 }
 
 func NewDebtPool(chain blockchain, verifier types.DebtVerifier) *DebtPool {
@@ -196,9 +197,37 @@ func (dp *DebtPool) AddDebt(debt *types.Debt) error {
 	err := dp.toConfirmedDebts.add(debt)
 	if err != nil {
 		dp.log.Warn("add debts to to be confirmed pool failed debt hash:%s, err: %s.", debt.Hash, err)
+		return err
 	}
 
-	return err
+	if dp.journal != nil {
+		if err := dp.journal.insert(debt); err != nil && err != errNoActiveJournal {
+			dp.log.Warn("failed to journal debt %v, %s", debt.Hash.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// StartJournal replays the debts persisted in the journal file, and journals the debts
+// to be confirmed or in pool, so that the in-flight debts survive node restarts. The
+// journal is regenerated with the debts in pool at the specified interval.
+func (dp *DebtPool) StartJournal(path string, rejournal time.Duration) error {
+	dp.journal = newPoolJournal(path, func() poolObject { return new(types.Debt) }, dp.log)
+
+	add := func(obj poolObject) error { return dp.AddDebt(obj.(*types.Debt)) }
+	objects := func() []poolObject { return debtsToObjects(dp.GetDebts(true, true)) }
+
+	return dp.journal.open(add, objects, rejournal)
+}
+
+// Stop stops the debt pool and closes the journal if enabled.
+func (dp *DebtPool) Stop() {
+	if dp.journal != nil {
+		if err := dp.journal.close(); err != nil {
+			dp.log.Warn("failed to close debt journal, %s", err)
+		}
+	}
 }
 
 func (dp *DebtPool) addToPool(debt *types.Debt) error {
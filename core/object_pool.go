@@ -20,9 +20,12 @@ import (
 )
 
 var (
-	errObjectHashExists = errors.New("object hash already exists")
-	errObjectPoolFull   = errors.New("object pool is full")
-	errObjectNonceUsed  = errors.New("object nonce already been used")
+	errObjectHashExists   = errors.New("object hash already exists")
+	errObjectPoolFull     = errors.New("object pool is full")
+	errObjectNonceUsed    = errors.New("object nonce already been used")
+	errAccountQueueFull   = errors.New("too many queued objects of account")
+	errReplaceUnderpriced = errors.New("replacement object underpriced")
+	errNoReplaceable      = errors.New("no object to replace")
 )
 
 var CachedCapacity = CachedBlocks * 500
@@ -60,6 +63,15 @@ type getObjectFromBlockFunc func(block *types.Block) []poolObject
 type canRemoveFunc func(chain blockchain, state *state.Statedb, item *poolItem) (bool, bool)
 type objectValidationFunc func(state *state.Statedb, obj poolObject) error
 type afterAddFunc func(obj poolObject)
+type afterReplaceFunc func(old, new poolObject)
+
+// poolLimits is the limits of objects per account and queued objects in pool, where 0 is unlimited.
+type poolLimits struct {
+	accountSlots int    // number of executable slots guaranteed per account
+	accountQueue int    // maximum number of queued objects per account
+	globalQueue  int    // maximum number of queued objects in pool
+	priceBump    uint64 // minimum price bump percentage to replace an object with the same nonce
+}
 
 // Pool is a thread-safe container for block object received from the network or submitted locally.
 // An object will be removed from the pool once included in a blockchain or pending time too long (> timeoutDuration).
@@ -70,6 +82,12 @@ type Pool struct {
 	hashToTxMap        map[common.Hash]*poolItem
 	pendingQueue       *pendingQueue
 	processingObjects  map[common.Hash]struct{}
+	queue              map[common.Address]*txCollection        // objects with future nonce, not executable until the nonce gap closed
+	queuedCount        int                                     // number of objects in queue
+	nonceToTxMap       map[common.Address]map[uint64]*poolItem // objects indexed by account and nonce
+	limits             *poolLimits                             // nil to disable queue, e.g. objects without nonce order
+	isLocal            func(account common.Address) bool       // objects of local accounts are exempted from eviction, nil if no local accounts
+	afterReplace       afterReplaceFunc                        // called when an object replaced by another one with the same nonce, nil if not required
 	log                *log.SeeleLog
 	getObjectFromBlock getObjectFromBlockFunc
 	canRemove          canRemoveFunc
@@ -87,6 +105,8 @@ func NewPool(capacity int, chain blockchain, getObjectFromBlock getObjectFromBlo
 		hashToTxMap:        make(map[common.Hash]*poolItem),
 		pendingQueue:       newPendingQueue(),
 		processingObjects:  make(map[common.Hash]struct{}),
+		queue:              make(map[common.Address]*txCollection),
+		nonceToTxMap:       make(map[common.Address]map[uint64]*poolItem),
 		log:                log,
 		getObjectFromBlock: getObjectFromBlock,
 		canRemove:          canRemove,
@@ -106,6 +126,34 @@ func (pool *Pool) SetLogLevel(level logrus.Level) {
 	pool.log.SetLevel(level)
 }
 
+// setLimits enables the queue of objects with future nonce, and limits the objects per account.
+func (pool *Pool) setLimits(limits poolLimits) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.limits = &limits
+}
+
+// setLocals sets the function to check local accounts, whose objects are exempted from eviction.
+func (pool *Pool) setLocals(isLocal func(account common.Address) bool) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.isLocal = isLocal
+}
+
+// setAfterReplace sets the function called when an object replaced by another one with the same nonce.
+func (pool *Pool) setAfterReplace(afterReplace afterReplaceFunc) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.afterReplace = afterReplace
+}
+
+func (pool *Pool) isLocalAccount(account common.Address) bool {
+	return pool.isLocal != nil && pool.isLocal(account)
+}
+
 // check the pool frequently, remove finalized and old txs, reinject the txs not on the chain yet
 func (pool *Pool) loopCheckingPool() {
 	for {
@@ -119,7 +167,7 @@ func (pool *Pool) loopCheckingPool() {
 			pool.mutex.Lock()
 			if len(pool.hashToTxMap) > 0 {
 				for _, poolTx := range pool.hashToTxMap {
-					if _,ok := pool.processingObjects[poolTx.GetHash()]; ok{
+					if _, ok := pool.processingObjects[poolTx.GetHash()]; ok || pool.isQueued(poolTx) {
 						continue
 					}
 					pool.pendingQueue.add(poolTx)
@@ -258,6 +306,18 @@ func (pool *Pool) Has(hash common.Hash) bool {
 // addObject adds a single transaction into the pool if it is valid and returns nil.
 // Otherwise, return the concrete error.
 func (pool *Pool) addObject(obj poolObject) error {
+	return pool.insertObject(obj, false)
+}
+
+// replaceObject replaces the object with the same account and nonce in the pool by the specified
+// object, and returns errNoReplaceable if not found.
+func (pool *Pool) replaceObject(obj poolObject) error {
+	return pool.insertObject(obj, true)
+}
+
+// insertObject adds the object into the pool, and the object must replace an existing one if mustReplace
+// is true, which is checked under the same lock of adding.
+func (pool *Pool) insertObject(obj poolObject, mustReplace bool) error {
 	if pool.Has(obj.GetHash()) {
 		return errObjectHashExists
 	}
@@ -281,43 +341,331 @@ func (pool *Pool) addObject(obj poolObject) error {
 		return errObjectHashExists
 	}
 
-	// update obj with higher price, otherwise return errObjectNonceUsed
-	if existTx := pool.pendingQueue.get(obj.FromAccount(), obj.Nonce()); existTx != nil {
-		if obj.Price().Cmp(existTx.Price()) > 0 {
-			pool.log.Debug("got a object has higher gas price than before. remove old one. new: %s, old: %s",
-				obj.GetHash().Hex(), existTx.GetHash().Hex())
-			pool.doRemoveObject(existTx.GetHash())
-		} else {
+	// update obj with price bumped enough, otherwise return errObjectNonceUsed or errReplaceUnderpriced
+	existTx := pool.getReplaceable(obj.FromAccount(), obj.Nonce())
+	if existTx == nil && mustReplace {
+		return errNoReplaceable
+	}
+
+	if existTx != nil {
+		if obj.Price().Cmp(existTx.Price()) <= 0 {
 			return errObjectNonceUsed
 		}
+
+		if obj.Price().Cmp(pool.replacementPrice(existTx.Price())) < 0 {
+			return errReplaceUnderpriced
+		}
+
+		pool.log.Debug("got a object has higher gas price than before. remove old one. new: %s, old: %s",
+			obj.GetHash().Hex(), existTx.GetHash().Hex())
+		pool.doRemoveObject(existTx.GetHash())
 	}
 
-	// if txpool capacity reached, then discard lower price txs if any.
+	queued := pool.limits != nil && obj.Nonce() > pool.executableNonce(statedb, obj.FromAccount())
+	if queued {
+		if err := pool.checkQueueLimits(obj); err != nil {
+			return err
+		}
+	}
+
+	// if txpool capacity reached, then discard queued or lower price txs if any.
 	// Otherwise, return errObjectPoolFull.
 	if len(pool.hashToTxMap) >= pool.capacity {
-		c := pool.pendingQueue.discard(obj.Price())
-		if c == nil || c.len() == 0 {
-			return errObjectPoolFull
+		if err := pool.makeRoom(obj, queued); err != nil {
+			return err
 		}
+	}
 
-		discardedAccount := c.peek().FromAccount()
-		pool.log.Info("object pool is full, discarded account = %v, object len = %v", discardedAccount.Hex(), c.len())
+	poolTx := pool.doAddObject(obj)
+	if existTx != nil && pool.afterReplace != nil {
+		pool.afterReplace(existTx.poolObject, obj)
+	}
 
-		for c.len() > 0 {
-			delete(pool.hashToTxMap, c.pop().GetHash())
-		}
+	if queued {
+		pool.enqueue(poolTx)
+		pool.log.Debug("object %v queued since nonce gap, account %v, nonce %v", obj.GetHash().Hex(), obj.FromAccount().Hex(), obj.Nonce())
+		return nil
 	}
 
-	pool.doAddObject(obj)
+	pool.pendingQueue.add(poolTx)
 	pool.afterAdd(obj)
 
+	if pool.limits != nil {
+		pool.promote(statedb, obj.FromAccount())
+	}
+
 	return nil
 }
 
-func (pool *Pool) doAddObject(obj poolObject) {
+func (pool *Pool) doAddObject(obj poolObject) *poolItem {
 	poolTx := newPooledItem(obj)
 	pool.hashToTxMap[obj.GetHash()] = poolTx
-	pool.pendingQueue.add(poolTx)
+
+	nonces := pool.nonceToTxMap[obj.FromAccount()]
+	if nonces == nil {
+		nonces = make(map[uint64]*poolItem)
+		pool.nonceToTxMap[obj.FromAccount()] = nonces
+	}
+	nonces[obj.Nonce()] = poolTx
+
+	return poolTx
+}
+
+// doDeleteObject deletes the object from pool indexes, but not from the pending queue or queue.
+func (pool *Pool) doDeleteObject(poolTx *poolItem) {
+	delete(pool.processingObjects, poolTx.GetHash())
+	delete(pool.hashToTxMap, poolTx.GetHash())
+
+	account := poolTx.FromAccount()
+	if nonces := pool.nonceToTxMap[account]; nonces != nil && nonces[poolTx.Nonce()] == poolTx {
+		delete(nonces, poolTx.Nonce())
+		if len(nonces) == 0 {
+			delete(pool.nonceToTxMap, account)
+		}
+	}
+}
+
+// getReplaceable returns the pending or queued object of the specified account and nonce,
+// which could be replaced by another object with higher price.
+func (pool *Pool) getReplaceable(account common.Address, nonce uint64) *poolItem {
+	if poolTx := pool.pendingQueue.get(account, nonce); poolTx != nil {
+		return poolTx
+	}
+
+	return pool.getQueued(account, nonce)
+}
+
+// replacementPrice returns the minimum price to replace an object of the specified price,
+// which is bumped by the percentage in limits, and higher than the price at least.
+func (pool *Pool) replacementPrice(price *big.Int) *big.Int {
+	var bump uint64
+	if pool.limits != nil {
+		bump = pool.limits.priceBump
+	}
+
+	result := new(big.Int).Mul(price, new(big.Int).SetUint64(100+bump))
+	result.Div(result, big.NewInt(100))
+	if result.Cmp(price) <= 0 {
+		result.Add(price, big.NewInt(1))
+	}
+
+	return result
+}
+
+// makeRoom discards an object to add the specified object when pool is full. The queued objects are
+// discarded first, then the executable objects of the account most over the slots quota, and then
+// the objects of account with lower price. The objects of local accounts are never discarded.
+func (pool *Pool) makeRoom(obj poolObject, queued bool) error {
+	if account, count := pool.mostQueuedAccount(); count > 0 && (account != obj.FromAccount() || obj.Nonce() < pool.queue[account].last().Nonce()) {
+		pool.discardQueued(account)
+		return nil
+	}
+
+	if queued {
+		return errObjectPoolFull
+	}
+
+	if pool.limits != nil {
+		if account, slots := pool.mostPendingAccount(); account != obj.FromAccount() && slots > pool.limits.accountSlots {
+			poolTx := pool.pendingQueue.txs[account].best.last()
+			pool.log.Info("object pool is full, discarded object %v of account %v over the slots quota", poolTx.GetHash().Hex(), account.Hex())
+			pool.pendingQueue.remove(account, poolTx.Nonce())
+			pool.doDeleteObject(poolTx)
+			return nil
+		}
+	}
+
+	c := pool.pendingQueue.discard(obj.Price(), pool.isLocal)
+	if c == nil || c.len() == 0 {
+		return errObjectPoolFull
+	}
+
+	discardedAccount := c.peek().FromAccount()
+	pool.log.Info("object pool is full, discarded account = %v, object len = %v", discardedAccount.Hex(), c.len())
+
+	for c.len() > 0 {
+		pool.doDeleteObject(c.pop())
+	}
+
+	return nil
+}
+
+// checkQueueLimits checks the queue limits to add the specified object into queue, and discards
+// the queued object of the account most over the quota if the global queue limit reached.
+func (pool *Pool) checkQueueLimits(obj poolObject) error {
+	if c := pool.queue[obj.FromAccount()]; c != nil && pool.limits.accountQueue > 0 && c.len() >= pool.limits.accountQueue {
+		return errAccountQueueFull
+	}
+
+	if pool.limits.globalQueue > 0 && pool.queuedCount >= pool.limits.globalQueue {
+		account, count := pool.mostQueuedAccount()
+		if count == 0 {
+			// all queued objects are local ones
+			if pool.isLocalAccount(obj.FromAccount()) {
+				return nil
+			}
+
+			return errObjectPoolFull
+		}
+
+		if account == obj.FromAccount() && obj.Nonce() > pool.queue[account].last().Nonce() {
+			return errAccountQueueFull
+		}
+
+		pool.discardQueued(account)
+	}
+
+	return nil
+}
+
+// executableNonce returns the next nonce of account after the executable objects in pool,
+// including the processing objects.
+func (pool *Pool) executableNonce(statedb *state.Statedb, account common.Address) uint64 {
+	nonce := statedb.GetNonce(account)
+	nonces := pool.nonceToTxMap[account]
+
+	for {
+		if poolTx := nonces[nonce]; poolTx == nil || pool.isQueued(poolTx) {
+			return nonce
+		}
+
+		nonce++
+	}
+}
+
+func (pool *Pool) isQueued(poolTx *poolItem) bool {
+	return pool.getQueued(poolTx.FromAccount(), poolTx.Nonce()) == poolTx
+}
+
+func (pool *Pool) getQueued(account common.Address, nonce uint64) *poolItem {
+	if c := pool.queue[account]; c != nil {
+		return c.get(nonce)
+	}
+
+	return nil
+}
+
+func (pool *Pool) enqueue(poolTx *poolItem) {
+	c := pool.queue[poolTx.FromAccount()]
+	if c == nil {
+		c = newTxCollection()
+		pool.queue[poolTx.FromAccount()] = c
+	}
+
+	if c.add(poolTx) {
+		pool.queuedCount++
+	}
+}
+
+func (pool *Pool) dequeue(poolTx *poolItem) {
+	c := pool.queue[poolTx.FromAccount()]
+	if c == nil || !c.remove(poolTx.Nonce()) {
+		return
+	}
+
+	pool.queuedCount--
+	if c.len() == 0 {
+		delete(pool.queue, poolTx.FromAccount())
+	}
+}
+
+// discardQueued discards the queued object with the highest nonce of the specified account.
+func (pool *Pool) discardQueued(account common.Address) {
+	poolTx := pool.queue[account].last()
+	pool.log.Debug("discard queued object %v of account %v", poolTx.GetHash().Hex(), account.Hex())
+	pool.dequeue(poolTx)
+	pool.doDeleteObject(poolTx)
+}
+
+// mostQueuedAccount returns the non-local account that has the most queued objects.
+func (pool *Pool) mostQueuedAccount() (common.Address, int) {
+	var result common.Address
+	max := 0
+
+	for account, c := range pool.queue {
+		if c.len() > max && !pool.isLocalAccount(account) {
+			result, max = account, c.len()
+		}
+	}
+
+	return result, max
+}
+
+// mostPendingAccount returns the non-local account that has the most executable objects in pending queue.
+func (pool *Pool) mostPendingAccount() (common.Address, int) {
+	var result common.Address
+	max := 0
+
+	for account, pair := range pool.pendingQueue.txs {
+		if pair.best.len() > max && !pool.isLocalAccount(account) {
+			result, max = account, pair.best.len()
+		}
+	}
+
+	return result, max
+}
+
+// promote moves the queued objects of account that become executable into pending queue,
+// and drops the stale ones whose nonce already used.
+func (pool *Pool) promote(statedb *state.Statedb, account common.Address) {
+	c := pool.queue[account]
+	if c == nil {
+		return
+	}
+
+	nonce := pool.executableNonce(statedb, account)
+	for c.len() > 0 && c.peek().Nonce() <= nonce {
+		poolTx := c.peek()
+		pool.dequeue(poolTx)
+
+		if poolTx.Nonce() < nonce {
+			pool.log.Debug("drop stale queued object %v, nonce %v", poolTx.GetHash().Hex(), poolTx.Nonce())
+			pool.doDeleteObject(poolTx)
+			continue
+		}
+
+		pool.pendingQueue.add(poolTx)
+		pool.afterAdd(poolTx.poolObject)
+		nonce++
+	}
+}
+
+// demote moves the pending objects of account that are not executable any more due to nonce gap,
+// e.g. a lower nonce object removed, into queue.
+func (pool *Pool) demote(statedb *state.Statedb, account common.Address) {
+	pair := pool.pendingQueue.txs[account]
+	if pair == nil {
+		return
+	}
+
+	nonce := pool.executableNonce(statedb, account)
+	for _, obj := range pair.best.list() {
+		if obj.Nonce() > nonce {
+			poolTx := pair.best.get(obj.Nonce())
+			pool.log.Debug("demote object %v since nonce gap, account %v, nonce %v", obj.GetHash().Hex(), account.Hex(), obj.Nonce())
+			pool.pendingQueue.remove(account, obj.Nonce())
+			pool.enqueue(poolTx)
+		}
+	}
+}
+
+// reorganize demotes the pending objects that are not executable, and promotes the queued objects
+// that become executable against the specified statedb.
+func (pool *Pool) reorganize(statedb *state.Statedb) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if pool.limits == nil {
+		return
+	}
+
+	for account := range pool.pendingQueue.txs {
+		pool.demote(statedb, account)
+	}
+
+	for account := range pool.queue {
+		pool.promote(statedb, account)
+	}
 }
 
 // GetObject returns a transaction if it is contained in the pool and nil otherwise.
@@ -342,9 +690,13 @@ func (pool *Pool) removeOject(objHash common.Hash) {
 // doRemoveObject removes a transaction from pool.
 func (pool *Pool) doRemoveObject(objHash common.Hash) {
 	if tx := pool.hashToTxMap[objHash]; tx != nil {
-		pool.pendingQueue.remove(tx.FromAccount(), tx.Nonce())
-		delete(pool.processingObjects, objHash)
-		delete(pool.hashToTxMap, objHash)
+		if pool.isQueued(tx) {
+			pool.dequeue(tx)
+		} else {
+			pool.pendingQueue.remove(tx.FromAccount(), tx.Nonce())
+		}
+
+		pool.doDeleteObject(tx)
 	}
 }
 
@@ -366,6 +718,8 @@ func (pool *Pool) removeObjects() {
 			pool.removeOject(objHash)
 		}
 	}
+
+	pool.reorganize(state)
 }
 
 func (pool *Pool) getObjectMap() map[common.Hash]*poolItem {
@@ -443,3 +797,24 @@ func (pool *Pool) getObjects(processing, pending bool) []poolObject {
 
 	return txs
 }
+
+// getQueuedCount returns the number of queued objects that are not executable due to nonce gap.
+func (pool *Pool) getQueuedCount() int {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	return pool.queuedCount
+}
+
+// getQueuedObjects returns the queued objects that are not executable due to nonce gap.
+func (pool *Pool) getQueuedObjects() []poolObject {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	var objects []poolObject
+	for _, c := range pool.queue {
+		objects = append(objects, c.list()...)
+	}
+
+	return objects
+}
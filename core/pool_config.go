@@ -5,9 +5,27 @@
 
 package core
 
+import (
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
 // TransactionPoolConfig is the configuration of the transaction pool.
 type TransactionPoolConfig struct {
 	Capacity int // Maximum number of transactions in the pool.
+
+	AccountSlots int // Number of executable tx slots guaranteed per account, the txs over it are evicted first when pool is full.
+	AccountQueue int // Maximum number of non-executable txs per account due to nonce gap, 0 for unlimited.
+	GlobalQueue  int // Maximum number of non-executable txs in the pool due to nonce gap, 0 for unlimited.
+
+	Locals     []common.Address // Accounts treated as local ones, besides the accounts that submitted txs locally.
+	PriceLimit uint64           // Minimum gas price in Fan of the txs from non-local accounts.
+	PriceBump  uint64           // Minimum gas price bump percentage to replace a tx with the same nonce.
+
+	Journal        string        // File path of the journal to persist txs across node restarts, disabled if empty.
+	JournalRemotes bool          // Whether to journal the txs received from network, besides the local ones.
+	Rejournal      time.Duration // Interval to regenerate the journal with the txs in pool.
 }
 
 // DefaultTxPoolConfig returns the default configuration of the transaction pool.
@@ -17,7 +35,13 @@ func DefaultTxPoolConfig() *TransactionPoolConfig {
 		// We want to cache transactions for about 100 blocks (about 500k transactions), which means at least 25 minutes block generation consume,
 		// the memory usage will be <=100MB for tx pool.
 		// in real test. 100000 transaction will use 100MB memory. so we will set capacity to 200000, which is about 200MB memory usage.
-		Capacity: 200000,
+		Capacity:     200000,
+		AccountSlots: 16,
+		AccountQueue: 64,
+		GlobalQueue:  1024,
+		PriceLimit:   1,
+		PriceBump:    10,
+		Rejournal:    time.Hour,
 	}
 }
 
@@ -0,0 +1,192 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/log"
+)
+
+var errNoActiveJournal = errors.New("no active journal")
+
+// poolJournal is an append-only file of RLP encoded pool objects, so that the objects
+// in pool survive node restarts. It is rotated periodically to drop the objects that
+// are no longer in pool.
+type poolJournal struct {
+	mutex     sync.Mutex
+	path      string
+	writer    io.WriteCloser
+	newObject func() poolObject
+	log       *log.SeeleLog
+	quit      chan struct{}
+}
+
+func newPoolJournal(path string, newObject func() poolObject, log *log.SeeleLog) *poolJournal {
+	return &poolJournal{
+		path:      path,
+		newObject: newObject,
+		log:       log,
+		quit:      make(chan struct{}),
+	}
+}
+
+// load replays the objects in journal via the add function, and the objects
+// failed to add, e.g. already included in blocks, are dropped.
+func (journal *poolJournal) load(add func(obj poolObject) error) error {
+	file, err := os.Open(journal.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.NewStackedErrorf(err, "failed to open journal %v", journal.path)
+	}
+	defer file.Close()
+
+	stream := rlp.NewStream(file, 0)
+	total, dropped := 0, 0
+
+	for {
+		obj := journal.newObject()
+		if err = stream.Decode(obj); err != nil {
+			// the tail may be corrupted if node crashed while writing
+			if err != io.EOF {
+				journal.log.Warn("failed to decode journal %v, %s", journal.path, err)
+			}
+
+			break
+		}
+
+		total++
+		if err = add(obj); err != nil {
+			journal.log.Debug("drop object %v in journal, %s", obj.GetHash().Hex(), err)
+			dropped++
+		}
+	}
+
+	journal.log.Info("loaded %d objects from journal %v, dropped %d invalid ones", total, journal.path, dropped)
+
+	return nil
+}
+
+// insert appends the object to journal.
+func (journal *poolJournal) insert(obj poolObject) error {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+
+	if journal.writer == nil {
+		return errNoActiveJournal
+	}
+
+	return rlp.Encode(journal.writer, obj)
+}
+
+// rotate regenerates the journal with the specified objects, and reopens it for appending.
+func (journal *poolJournal) rotate(objects []poolObject) error {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+
+	if journal.writer != nil {
+		if err := journal.writer.Close(); err != nil {
+			return errors.NewStackedErrorf(err, "failed to close journal %v", journal.path)
+		}
+
+		journal.writer = nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(journal.path), os.ModePerm); err != nil {
+		return errors.NewStackedErrorf(err, "failed to create journal folder")
+	}
+
+	tmpPath := journal.path + ".new"
+	replacement, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.NewStackedErrorf(err, "failed to create journal %v", tmpPath)
+	}
+
+	for _, obj := range objects {
+		if err = rlp.Encode(replacement, obj); err != nil {
+			replacement.Close()
+			return errors.NewStackedErrorf(err, "failed to write object %v into journal", obj.GetHash().Hex())
+		}
+	}
+
+	replacement.Close()
+
+	if err = os.Rename(tmpPath, journal.path); err != nil {
+		return errors.NewStackedErrorf(err, "failed to replace journal %v", journal.path)
+	}
+
+	if journal.writer, err = os.OpenFile(journal.path, os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return errors.NewStackedErrorf(err, "failed to open journal %v", journal.path)
+	}
+
+	journal.log.Debug("rotated journal %v with %d objects", journal.path, len(objects))
+
+	return nil
+}
+
+// loopRotating rotates the journal with the objects returned by the function at the
+// specified interval until the journal is closed.
+func (journal *poolJournal) loopRotating(interval time.Duration, objects func() []poolObject) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := journal.rotate(objects()); err != nil {
+				journal.log.Warn("failed to rotate journal, %s", err)
+			}
+		case <-journal.quit:
+			return
+		}
+	}
+}
+
+// close stops the rotation and closes the journal file.
+func (journal *poolJournal) close() error {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+
+	select {
+	case <-journal.quit:
+	default:
+		close(journal.quit)
+	}
+
+	if journal.writer == nil {
+		return nil
+	}
+
+	err := journal.writer.Close()
+	journal.writer = nil
+
+	return err
+}
+
+// open loads the journal via the add function, rotates it with the objects returned by
+// the function, and keeps rotating at the specified interval in background.
+func (journal *poolJournal) open(add func(obj poolObject) error, objects func() []poolObject, interval time.Duration) error {
+	if err := journal.load(add); err != nil {
+		return err
+	}
+
+	if err := journal.rotate(objects()); err != nil {
+		return err
+	}
+
+	go journal.loopRotating(interval, objects)
+
+	return nil
+}
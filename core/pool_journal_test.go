@@ -0,0 +1,130 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJournalTx(t *testing.T, nonce uint64) *types.Transaction {
+	from, privKey := crypto.MustGenerateShardKeyPair(1)
+	to := crypto.MustGenerateShardAddress(1)
+
+	tx, err := types.NewTransaction(*from, *to, big.NewInt(1), big.NewInt(1), nonce)
+	assert.Equal(t, err, nil)
+	tx.Sign(privKey)
+
+	return tx
+}
+
+func loadTestJournal(t *testing.T, journal *poolJournal, add func(tx *types.Transaction) error) []common.Hash {
+	var hashes []common.Hash
+	err := journal.load(func(obj poolObject) error {
+		hashes = append(hashes, obj.GetHash())
+		return add(obj.(*types.Transaction))
+	})
+	assert.Equal(t, err, nil)
+
+	return hashes
+}
+
+func Test_PoolJournal(t *testing.T) {
+	dir := filepath.Join(common.GetTempFolder(), "poolJournal")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "transactions.rlp")
+	newTx := func() poolObject { return new(types.Transaction) }
+	log := log.GetLogger("test")
+	tx1, tx2, tx3 := newTestJournalTx(t, 1), newTestJournalTx(t, 2), newTestJournalTx(t, 3)
+
+	// journal not exists
+	journal := newPoolJournal(path, newTx, log)
+	assert.Equal(t, len(loadTestJournal(t, journal, nil)), 0)
+	assert.Equal(t, journal.insert(tx1), errNoActiveJournal)
+
+	// insert after rotation
+	assert.Equal(t, journal.rotate([]poolObject{tx1}), nil)
+	assert.Equal(t, journal.insert(tx2), nil)
+	assert.Equal(t, journal.close(), nil)
+
+	journal = newPoolJournal(path, newTx, log)
+	added := 0
+	hashes := loadTestJournal(t, journal, func(tx *types.Transaction) error {
+		if tx.Hash == tx1.Hash {
+			return errors.New("invalid tx")
+		}
+
+		added++
+		return nil
+	})
+	assert.Equal(t, hashes, []common.Hash{tx1.Hash, tx2.Hash})
+	assert.Equal(t, added, 1)
+
+	// rotation drops the objects not in pool
+	assert.Equal(t, journal.rotate([]poolObject{tx2, tx3}), nil)
+	assert.Equal(t, journal.close(), nil)
+
+	journal = newPoolJournal(path, newTx, log)
+	hashes = loadTestJournal(t, journal, func(tx *types.Transaction) error { return nil })
+	assert.Equal(t, hashes, []common.Hash{tx2.Hash, tx3.Hash})
+
+	// truncated tail is ignored
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	assert.Equal(t, err, nil)
+	_, err = file.Write([]byte{0xf8, 0x01})
+	assert.Equal(t, err, nil)
+	file.Close()
+
+	hashes = loadTestJournal(t, journal, func(tx *types.Transaction) error { return nil })
+	assert.Equal(t, hashes, []common.Hash{tx2.Hash, tx3.Hash})
+}
+
+func Test_PoolJournal_Open(t *testing.T) {
+	dir := filepath.Join(common.GetTempFolder(), "poolJournalOpen")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "transactions.rlp")
+	newTx := func() poolObject { return new(types.Transaction) }
+	tx1, tx2 := newTestJournalTx(t, 1), newTestJournalTx(t, 2)
+
+	journal := newPoolJournal(path, newTx, log.GetLogger("test"))
+	assert.Equal(t, journal.rotate([]poolObject{tx1}), nil)
+	assert.Equal(t, journal.close(), nil)
+
+	// replay and rotate in background
+	var pooled []poolObject
+	add := func(obj poolObject) error {
+		pooled = append(pooled, obj)
+		return nil
+	}
+	objects := func() []poolObject { return []poolObject{tx2} }
+
+	journal = newPoolJournal(path, newTx, log.GetLogger("test"))
+	assert.Equal(t, journal.open(add, func() []poolObject { return pooled }, time.Hour), nil)
+	assert.Equal(t, len(pooled), 1)
+	assert.Equal(t, pooled[0].GetHash(), tx1.Hash)
+	assert.Equal(t, journal.close(), nil)
+
+	journal = newPoolJournal(path, newTx, log.GetLogger("test"))
+	pooled = nil
+	assert.Equal(t, journal.open(add, objects, 10*time.Millisecond), nil)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, journal.close(), nil)
+
+	hashes := loadTestJournal(t, journal, func(tx *types.Transaction) error { return nil })
+	assert.Equal(t, hashes, []common.Hash{tx2.Hash})
+}
@@ -68,6 +68,19 @@ func (collection *txCollection) peek() *poolItem {
 	return nil
 }
 
+// last returns the tx with the highest nonce.
+func (collection *txCollection) last() *poolItem {
+	var result *poolItem
+
+	for _, tx := range collection.txs {
+		if result == nil || tx.Nonce() > result.Nonce() {
+			result = tx
+		}
+	}
+
+	return result
+}
+
 func (collection *txCollection) pop() *poolItem {
 	tx := heap.Pop(collection.nonceHeap).(*poolItem)
 	delete(collection.txs, tx.Nonce())
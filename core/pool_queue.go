@@ -144,25 +144,37 @@ func (q *pendingQueue) pop() poolObject {
 }
 
 // discard removes and returns the txs of worst account that has
-// lower price than the specified price. Return nil if no lower
-// price txs found.
-func (q *pendingQueue) discard(price *big.Int) *txCollection {
-	if q.empty() {
-		return nil
-	}
+// lower price than the specified price, skipping the exempted
+// accounts if any. Return nil if no lower price txs found.
+func (q *pendingQueue) discard(price *big.Int, exempted func(account common.Address) bool) *txCollection {
+	var skipped []*heapedTxList
+	defer func() {
+		for _, list := range skipped {
+			heap.Push(q.worstHeap, list)
+		}
+	}()
 
-	worstCollection := q.worstHeap.Peek().(*heapedTxList).txCollection
-	worstTx := worstCollection.peek()
-	if worstTx == nil || price.Cmp(worstTx.Price()) <= 0 {
-		return nil
-	}
+	for q.worstHeap.Len() > 0 {
+		worstList := q.worstHeap.Peek().(*heapedTxList)
+		worstTx := worstList.peek()
+		if worstTx == nil || price.Cmp(worstTx.Price()) <= 0 {
+			return nil
+		}
 
-	heap.Pop(q.worstHeap)
-	account := worstTx.FromAccount()
-	heap.Remove(q.bestHeap, q.txs[account].best.GetHeapIndex())
-	delete(q.txs, account)
+		heap.Pop(q.worstHeap)
+		account := worstTx.FromAccount()
+		if exempted != nil && exempted(account) {
+			skipped = append(skipped, worstList)
+			continue
+		}
+
+		heap.Remove(q.bestHeap, q.txs[account].best.GetHeapIndex())
+		delete(q.txs, account)
 
-	return worstCollection
+		return worstList.txCollection
+	}
+
+	return nil
 }
 
 func (q *pendingQueue) list() []poolObject {
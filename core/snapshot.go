@@ -0,0 +1,291 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/trie"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+)
+
+const (
+	// snapshotVersion is the version of snapshot format.
+	snapshotVersion uint = 1
+
+	// snapshotBatchSize is the max number of state nodes written in a batch when import.
+	snapshotBatchSize = 10000
+)
+
+var (
+	// ErrSnapshotVersion is returned when import a snapshot with unsupported version.
+	ErrSnapshotVersion = errors.New("unsupported snapshot version")
+
+	// ErrSnapshotChainNotEmpty is returned when import a snapshot into a non-empty blockchain.
+	ErrSnapshotChainNotEmpty = errors.New("blockchain is not empty")
+
+	// ErrSnapshotBlocksMismatch is returned when the blocks in snapshot are not linked.
+	ErrSnapshotBlocksMismatch = errors.New("snapshot blocks mismatch")
+)
+
+// SnapshotHeader is the header of a state snapshot.
+// The snapshot is a RLP stream in order of:
+//  1. snapshot header
+//  2. genesis block
+//  3. recent blocks in ascending order, the last one is the snapshot block
+//  4. state trie nodes of the snapshot block, ended with an empty item
+type SnapshotHeader struct {
+	Version   uint
+	BlockHash common.Hash // hash of the snapshot block
+	Height    uint64      // height of the snapshot block
+	StateRoot common.Hash // state root hash of the snapshot block
+	Blocks    uint64      // number of recent blocks, including the snapshot block
+}
+
+// snapshotBlock is the block with total difficulty and receipts in snapshot.
+type snapshotBlock struct {
+	Block    *types.Block
+	TD       *big.Int
+	Receipts []*types.Receipt
+}
+
+// ExportSnapshot writes the state of the canonical block at the specified height,
+// as well as the specified number of recent blocks, into the writer.
+func ExportSnapshot(w io.Writer, bcStore store.BlockchainStore, accountStateDB database.Database, height uint64, blocks uint64) (*SnapshotHeader, error) {
+	block, err := bcStore.GetBlockByHeight(height)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to get block by height %v", height)
+	}
+
+	if blocks == 0 || blocks > height {
+		blocks = height
+	}
+
+	header := &SnapshotHeader{
+		Version:   snapshotVersion,
+		BlockHash: block.HeaderHash,
+		Height:    height,
+		StateRoot: block.Header.StateHash,
+		Blocks:    blocks,
+	}
+
+	if err = rlp.Encode(w, header); err != nil {
+		return nil, errors.NewStackedError(err, "failed to write snapshot header")
+	}
+
+	// genesis block and recent blocks
+	if err = exportSnapshotBlock(w, bcStore, genesisBlockHeight); err != nil {
+		return nil, err
+	}
+
+	for h := height - blocks + 1; h <= height; h++ {
+		if err = exportSnapshotBlock(w, bcStore, h); err != nil {
+			return nil, err
+		}
+	}
+
+	// state trie nodes
+	t, err := trie.NewTrie(header.StateRoot, state.TrieDbPrefix, accountStateDB)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to load state trie %v", header.StateRoot)
+	}
+
+	visited := make(map[common.Hash]struct{})
+	err = t.WalkNodes(func(hash []byte) bool {
+		h := common.BytesToHash(hash)
+		if _, found := visited[h]; found || err != nil {
+			return false
+		}
+		visited[h] = struct{}{}
+
+		var node []byte
+		if node, err = accountStateDB.Get(append(common.CopyBytes(state.TrieDbPrefix), hash...)); err == nil {
+			err = rlp.Encode(w, node)
+		}
+
+		return err == nil
+	})
+
+	if err != nil {
+		return nil, errors.NewStackedError(err, "failed to write state trie nodes")
+	}
+
+	if err = rlp.Encode(w, []byte{}); err != nil {
+		return nil, errors.NewStackedError(err, "failed to write snapshot end")
+	}
+
+	return header, nil
+}
+
+func exportSnapshotBlock(w io.Writer, bcStore store.BlockchainStore, height uint64) error {
+	block, err := bcStore.GetBlockByHeight(height)
+	if err != nil {
+		return errors.NewStackedErrorf(err, "failed to get block by height %v", height)
+	}
+
+	td, err := bcStore.GetBlockTotalDifficulty(block.HeaderHash)
+	if err != nil {
+		return errors.NewStackedErrorf(err, "failed to get block TD by hash %v", block.HeaderHash)
+	}
+
+	// genesis block has no receipts
+	receipts, err := bcStore.GetReceiptsByBlockHash(block.HeaderHash)
+	if err != nil && (height != genesisBlockHeight || err != leveldbErrors.ErrNotFound) {
+		return errors.NewStackedErrorf(err, "failed to get receipts by block hash %v", block.HeaderHash)
+	}
+
+	return rlp.Encode(w, &snapshotBlock{block, td, receipts})
+}
+
+// ImportSnapshot reads the snapshot from the reader into the empty blockchain store and account state DB.
+// The blocks are validated and linked, and the state trie must be complete with the state root of the
+// snapshot block header, otherwise the HEAD block is not updated and the node could not start. The
+// recent blocks are written as HEAD in ascending order, so they are indexed the same as blocks written
+// by blockchain, including the account history if maintained by the store.
+func ImportSnapshot(r io.Reader, bcStore store.BlockchainStore, accountStateDB database.Database) (*SnapshotHeader, error) {
+	if _, err := bcStore.GetHeadBlockHash(); err == nil {
+		return nil, ErrSnapshotChainNotEmpty
+	}
+
+	stream := rlp.NewStream(r, 0)
+
+	header := &SnapshotHeader{}
+	if err := stream.Decode(header); err != nil {
+		return nil, errors.NewStackedError(err, "failed to read snapshot header")
+	}
+
+	if header.Version != snapshotVersion {
+		return nil, ErrSnapshotVersion
+	}
+
+	// read and validate blocks
+	var blocks []*snapshotBlock
+	for i := uint64(0); i <= header.Blocks; i++ {
+		b := &snapshotBlock{}
+		if err := stream.Decode(b); err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to read snapshot block %v", i)
+		}
+
+		if err := validateSnapshotBlock(b, blocks); err != nil {
+			return nil, errors.NewStackedErrorf(err, "invalid snapshot block %v", i)
+		}
+
+		blocks = append(blocks, b)
+	}
+
+	if last := blocks[len(blocks)-1].Block; !last.HeaderHash.Equal(header.BlockHash) ||
+		last.Header.Height != header.Height || !last.Header.StateHash.Equal(header.StateRoot) {
+		return nil, ErrSnapshotBlocksMismatch
+	}
+
+	// read state trie nodes, which are keyed by content hash.
+	batch := accountStateDB.NewBatch()
+	pending := 0
+	for {
+		node, err := stream.Bytes()
+		if err != nil {
+			return nil, errors.NewStackedError(err, "failed to read state trie node")
+		}
+
+		if len(node) == 0 {
+			break
+		}
+
+		batch.Put(append(common.CopyBytes(state.TrieDbPrefix), crypto.Keccak256Hash(node).Bytes()...), node)
+		if pending++; pending >= snapshotBatchSize {
+			if err = batch.Commit(); err != nil {
+				return nil, errors.NewStackedError(err, "failed to write state trie nodes")
+			}
+
+			batch = accountStateDB.NewBatch()
+			pending = 0
+		}
+	}
+
+	if err := batch.Commit(); err != nil {
+		return nil, errors.NewStackedError(err, "failed to write state trie nodes")
+	}
+
+	// verify that state trie is complete against the state root of snapshot block header.
+	t, err := trie.NewTrie(header.StateRoot, state.TrieDbPrefix, accountStateDB)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to load state trie %v", header.StateRoot)
+	}
+
+	if err = t.WalkNodes(func(hash []byte) bool { return true }); err != nil {
+		return nil, errors.NewStackedErrorf(err, "incomplete state trie %v", header.StateRoot)
+	}
+
+	// write blocks, and the snapshot block will be the HEAD at last.
+	genesis := blocks[0]
+	if err = bcStore.PutBlockHeader(genesis.Block.HeaderHash, genesis.Block.Header, genesis.TD, true); err != nil {
+		return nil, errors.NewStackedError(err, "failed to put genesis block header")
+	}
+
+	for _, b := range blocks[1:] {
+		if err = bcStore.PutReceipts(b.Block.HeaderHash, b.Receipts); err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to put receipts of block %v", b.Block.HeaderHash)
+		}
+
+		if err = bcStore.PutBlock(b.Block, b.TD, true); err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to put block %v", b.Block.HeaderHash)
+		}
+	}
+
+	return header, nil
+}
+
+// validateSnapshotBlock validates the block content, and that the block is linked to the previous
+// recent block. Note, the first block is genesis block, and the second one is not linked to genesis
+// unless it is at height 1.
+func validateSnapshotBlock(b *snapshotBlock, previous []*snapshotBlock) error {
+	if b.Block == nil || b.Block.Header == nil || b.TD == nil {
+		return types.ErrBlockHeaderNil
+	}
+
+	// genesis block only contains header
+	if len(previous) == 0 {
+		if b.Block.Header.Height != genesisBlockHeight {
+			return ErrSnapshotBlocksMismatch
+		}
+
+		if !b.Block.HeaderHash.Equal(b.Block.Header.Hash()) {
+			return types.ErrBlockHashMismatch
+		}
+
+		return nil
+	}
+
+	if err := b.Block.Validate(); err != nil {
+		return err
+	}
+
+	if h := types.ReceiptMerkleRootHash(b.Receipts); !h.Equal(b.Block.Header.ReceiptHash) {
+		return ErrBlockReceiptHashMismatch
+	}
+
+	// recent blocks may not start from height 1.
+	if len(previous) == 1 && b.Block.Header.Height > genesisBlockHeight+1 {
+		return nil
+	}
+
+	prev := previous[len(previous)-1]
+	if !b.Block.Header.PreviousBlockHash.Equal(prev.Block.HeaderHash) || b.Block.Header.Height != prev.Block.Header.Height+1 ||
+		b.TD.Cmp(new(big.Int).Add(prev.TD, b.Block.Header.Difficulty)) != 0 {
+		return ErrSnapshotBlocksMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/database/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSnapshotChain(t *testing.T, height uint64) (store.BlockchainStore, database.Database, []common.Address) {
+	bcStore := store.NewBlockchainDatabase(memory.NewMemoryDB())
+	accountStateDB := memory.NewMemoryDB()
+
+	var addrs []common.Address
+	for i := uint64(0); i <= height; i++ {
+		addrs = append(addrs, *crypto.MustGenerateRandomAddress())
+	}
+
+	root := commitTestState(t, accountStateDB, common.EmptyHash, func(statedb *state.Statedb) {
+		statedb.CreateAccount(addrs[0])
+	})
+
+	genesis := types.NewBlock(&types.BlockHeader{Height: genesisBlockHeight, StateHash: root, Difficulty: big.NewInt(1)}, nil, nil, nil)
+	assert.Equal(t, bcStore.PutBlockHeader(genesis.HeaderHash, genesis.Header, genesis.Header.Difficulty, true), nil)
+
+	prev, td := genesis, big.NewInt(1)
+	for h := uint64(1); h <= height; h++ {
+		root = commitTestState(t, accountStateDB, root, func(statedb *state.Statedb) {
+			statedb.CreateAccount(addrs[h])
+			statedb.SetBalance(addrs[h], new(big.Int).SetUint64(h))
+		})
+
+		header := &types.BlockHeader{
+			PreviousBlockHash: prev.HeaderHash,
+			StateHash:         root,
+			Difficulty:        big.NewInt(1),
+			Height:            h,
+		}
+		receipts := []*types.Receipt{{TxHash: common.BigToHash(new(big.Int).SetUint64(h)), UsedGas: h}}
+		block := types.NewBlock(header, []*types.Transaction{types.NewTestTxDetail(1, 1, h)}, receipts, nil)
+		td = new(big.Int).Add(td, header.Difficulty)
+
+		assert.Equal(t, bcStore.PutReceipts(block.HeaderHash, receipts), nil)
+		assert.Equal(t, bcStore.PutBlock(block, td, true), nil)
+		prev = block
+	}
+
+	return bcStore, accountStateDB, addrs
+}
+
+func Test_Snapshot_ExportImport(t *testing.T) {
+	bcStore, accountStateDB, addrs := newTestSnapshotChain(t, 10)
+
+	var buf bytes.Buffer
+	header, err := ExportSnapshot(&buf, bcStore, accountStateDB, 8, 3)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, header.Height, uint64(8))
+	assert.Equal(t, header.Blocks, uint64(3))
+
+	block8, err := bcStore.GetBlockByHeight(8)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, header.BlockHash, block8.HeaderHash)
+	assert.Equal(t, header.StateRoot, block8.Header.StateHash)
+
+	newStore := store.NewBlockchainDatabase(memory.NewMemoryDB())
+	newStateDB := memory.NewMemoryDB()
+	imported, err := ImportSnapshot(bytes.NewReader(buf.Bytes()), newStore, newStateDB)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, imported, header)
+
+	// HEAD is the snapshot block
+	headHash, err := newStore.GetHeadBlockHash()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, headHash, block8.HeaderHash)
+
+	td, err := newStore.GetBlockTotalDifficulty(headHash)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, td, big.NewInt(9))
+
+	receipts, err := newStore.GetReceiptsByBlockHash(headHash)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(receipts), 1)
+
+	// genesis and recent blocks available
+	for _, h := range []uint64{0, 6, 7, 8} {
+		hash, err := newStore.GetBlockHash(h)
+		assert.Equal(t, err, nil)
+
+		expected, _ := bcStore.GetBlockHash(h)
+		assert.Equal(t, hash, expected)
+	}
+
+	_, err = newStore.GetBlockHash(5)
+	assert.Equal(t, err != nil, true)
+
+	// tx indices of recent blocks written as normal block writes
+	for _, h := range []uint64{6, 7, 8} {
+		block, err := bcStore.GetBlockByHeight(h)
+		assert.Equal(t, err, nil)
+
+		txIndex, err := newStore.GetTxIndex(block.Transactions[0].Hash)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, txIndex, &types.TxIndex{BlockHash: block.HeaderHash, Index: 0})
+	}
+
+	// state of snapshot block available
+	statedb, err := state.NewStatedb(header.StateRoot, newStateDB)
+	assert.Equal(t, err, nil)
+	for h := 1; h <= 8; h++ {
+		assert.Equal(t, statedb.GetBalance(addrs[h]), big.NewInt(int64(h)))
+	}
+	assert.Equal(t, statedb.GetBalance(addrs[9]), big.NewInt(0))
+
+	// only reachable state trie nodes are imported
+	assert.Equal(t, countStateNodes(newStateDB) < countStateNodes(accountStateDB), true)
+
+	// import again
+	_, err = ImportSnapshot(bytes.NewReader(buf.Bytes()), newStore, newStateDB)
+	assert.Equal(t, err, ErrSnapshotChainNotEmpty)
+}
+
+func Test_Snapshot_ImportAccountHistory(t *testing.T) {
+	bcStore, accountStateDB, _ := newTestSnapshotChain(t, 10)
+
+	var buf bytes.Buffer
+	_, err := ExportSnapshot(&buf, bcStore, accountStateDB, 8, 3)
+	assert.Equal(t, err, nil)
+
+	newStore := store.NewBlockchainDatabaseWithAccountHistory(memory.NewMemoryDB())
+	_, err = ImportSnapshot(bytes.NewReader(buf.Bytes()), newStore, memory.NewMemoryDB())
+	assert.Equal(t, err, nil)
+
+	// recent blocks indexed, and the blocks below are not available
+	history, err := newStore.GetAccountHistory(types.TestGenesisAccount.Addr, 6, 8, 0, 10)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(history), 3)
+	for i, entry := range history {
+		block, _ := bcStore.GetBlockByHeight(uint64(6 + i))
+		assert.Equal(t, entry.Hash, block.Transactions[0].Hash)
+		assert.Equal(t, entry.BlockHash, block.HeaderHash)
+	}
+
+	_, err = newStore.GetAccountHistory(types.TestGenesisAccount.Addr, 5, 8, 0, 10)
+	assert.Equal(t, errors.IsOrContains(err, store.ErrAccountHistoryNotIndexed), true)
+}
+
+func Test_Snapshot_ImportInvalid(t *testing.T) {
+	bcStore, accountStateDB, _ := newTestSnapshotChain(t, 5)
+
+	var buf bytes.Buffer
+	header, err := ExportSnapshot(&buf, bcStore, accountStateDB, 5, 2)
+	assert.Equal(t, err, nil)
+
+	// incomplete state trie nodes
+	stream := rlp.NewStream(bytes.NewReader(buf.Bytes()), 0)
+	var incomplete bytes.Buffer
+	for i := uint64(0); i < header.Blocks+2; i++ {
+		raw, err := stream.Raw()
+		assert.Equal(t, err, nil)
+		incomplete.Write(raw)
+	}
+	assert.Equal(t, rlp.Encode(&incomplete, []byte{}), nil)
+
+	newStore := store.NewBlockchainDatabase(memory.NewMemoryDB())
+	_, err = ImportSnapshot(&incomplete, newStore, memory.NewMemoryDB())
+	assert.Equal(t, err != nil, true)
+
+	_, err = newStore.GetHeadBlockHash()
+	assert.Equal(t, err != nil, true)
+
+	// unsupported version
+	var invalid bytes.Buffer
+	header.Version = snapshotVersion + 1
+	assert.Equal(t, rlp.Encode(&invalid, header), nil)
+	_, err = ImportSnapshot(&invalid, newStore, memory.NewMemoryDB())
+	assert.Equal(t, err, ErrSnapshotVersion)
+}
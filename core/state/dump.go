@@ -0,0 +1,93 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/trie"
+)
+
+// DumpAccount is the account info in state dump.
+// Note, the account is keyed by the address hash in trie, as well as
+// the storage, so the original address and storage keys are not available.
+type DumpAccount struct {
+	AddressHash common.Hash            `json:"addressHash"`
+	Balance     *big.Int               `json:"balance"`
+	Nonce       uint64                 `json:"nonce"`
+	CodeHash    common.Hash            `json:"codeHash"`
+	Storage     map[common.Hash]string `json:"storage,omitempty"`
+}
+
+// DumpState iterates all the accounts of the state with the specified root hash in the
+// order of address hash, and calls the dump function for each account. The iteration
+// stops if the dump function returns any error.
+func DumpState(root common.Hash, db database.Database, dump func(*DumpAccount) error) error {
+	return DumpStateFrom(root, db, common.EmptyHash, dump)
+}
+
+// DumpStateFrom is the same as DumpState, but starts from the account whose address hash is
+// not less than the specified one, so that a large state could be dumped in pages.
+func DumpStateFrom(root common.Hash, db database.Database, start common.Hash, dump func(*DumpAccount) error) error {
+	t, err := trie.NewTrie(root, TrieDbPrefix, db)
+	if err != nil {
+		return err
+	}
+
+	var current *DumpAccount
+	it := t.NewIteratorFrom(start.Bytes())
+	for it.Next() {
+		key := it.Key()
+		if len(key) <= common.HashLength {
+			return fmt.Errorf("invalid state key %v", hexutil.BytesToHex(key))
+		}
+
+		addrHash := common.BytesToHash(key[:common.HashLength])
+
+		// data of the same account are adjacent, and account info comes first.
+		if current == nil || !current.AddressHash.Equal(addrHash) {
+			if current != nil {
+				if err = dump(current); err != nil {
+					return err
+				}
+			}
+
+			current = &DumpAccount{AddressHash: addrHash, Balance: new(big.Int)}
+		}
+
+		switch key[common.HashLength] {
+		case dataTypeAccount:
+			var account account
+			if err = common.Deserialize(it.Value(), &account); err != nil {
+				return err
+			}
+
+			current.Balance = account.Amount
+			current.Nonce = account.Nonce
+			current.CodeHash = common.BytesToHash(account.CodeHash)
+		case dataTypeStorage:
+			if current.Storage == nil {
+				current.Storage = make(map[common.Hash]string)
+			}
+
+			current.Storage[common.BytesToHash(key[common.HashLength+1:])] = hexutil.BytesToHex(it.Value())
+		}
+	}
+
+	if err = it.Err(); err != nil {
+		return err
+	}
+
+	if current != nil {
+		return dump(current)
+	}
+
+	return nil
+}
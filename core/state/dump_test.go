@@ -0,0 +1,88 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/database/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DumpState(t *testing.T) {
+	db := memory.NewMemoryDB()
+	statedb := NewEmptyStatedb(db)
+
+	accounts := make(map[common.Hash]common.Address)
+	for i := 0; i < 5; i++ {
+		addr := *crypto.MustGenerateRandomAddress()
+		statedb.CreateAccount(addr)
+		statedb.SetBalance(addr, big.NewInt(int64(i+1)))
+		statedb.SetNonce(addr, uint64(i))
+		accounts[crypto.MustHash(addr)] = addr
+	}
+
+	contract := *crypto.MustGenerateRandomAddress()
+	statedb.CreateAccount(contract)
+	statedb.SetCode(contract, []byte("code"))
+	statedb.SetData(contract, common.StringToHash("k1"), []byte("v1"))
+	statedb.SetData(contract, common.StringToHash("k2"), []byte("v2"))
+	accounts[crypto.MustHash(contract)] = contract
+
+	batch := db.NewBatch()
+	root, err := statedb.Commit(batch)
+	assert.Equal(t, err, nil)
+	batch.Commit()
+
+	var dumped []*DumpAccount
+	err = DumpState(root, db, func(account *DumpAccount) error {
+		dumped = append(dumped, account)
+		return nil
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(dumped), len(accounts))
+
+	for i, account := range dumped {
+		if i > 0 {
+			assert.Equal(t, account.AddressHash.Big().Cmp(dumped[i-1].AddressHash.Big()) > 0, true)
+		}
+
+		addr := accounts[account.AddressHash]
+		assert.Equal(t, account.Balance, statedb.GetBalance(addr))
+		assert.Equal(t, account.Nonce, statedb.GetNonce(addr))
+		assert.Equal(t, account.CodeHash, statedb.GetCodeHash(addr))
+
+		if addr == contract {
+			assert.Equal(t, len(account.Storage), 2)
+			assert.Equal(t, account.Storage[crypto.MustHash(common.StringToHash("k1"))], "0x7631")
+		} else {
+			assert.Equal(t, len(account.Storage), 0)
+		}
+	}
+
+	// dump from the middle
+	var paged []*DumpAccount
+	err = DumpStateFrom(root, db, dumped[3].AddressHash, func(account *DumpAccount) error {
+		paged = append(paged, account)
+		return nil
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, paged, dumped[3:])
+
+	// stop on error
+	count := 0
+	errStop := errors.New("stop")
+	err = DumpState(root, db, func(account *DumpAccount) error {
+		count++
+		return errStop
+	})
+	assert.Equal(t, err, errStop)
+	assert.Equal(t, count, 1)
+}
@@ -0,0 +1,64 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/trie"
+)
+
+// ProvedAccount is the account state proved by the merkle proof against a state root.
+type ProvedAccount struct {
+	Nonce    uint64
+	Balance  *big.Int
+	CodeHash common.Hash // empty if not contract
+}
+
+// GetAccountProof returns the merkle proof of the specified account against the state root.
+// Note, the uncommitted changes of statedb are not included.
+func (s *Statedb) GetAccountProof(addr common.Address) (map[string][]byte, error) {
+	return s.trie.GetProof(newStateObject(addr).dataKey(dataTypeAccount))
+}
+
+// GetStorageProof returns the merkle proof of the specified storage key of account against
+// the state root. Note, the uncommitted changes of statedb are not included.
+func (s *Statedb) GetStorageProof(addr common.Address, key common.Hash) (map[string][]byte, error) {
+	return s.trie.GetProof(storageKey(addr, key))
+}
+
+// VerifyAccountProof verifies the account proof against the state root, and returns the
+// account state, or nil if the account does not exist.
+func VerifyAccountProof(root common.Hash, addr common.Address, proof map[string][]byte) (*ProvedAccount, error) {
+	value, err := trie.VerifyProof(root, newStateObject(addr).dataKey(dataTypeAccount), proof)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	var account account
+	if err = common.Deserialize(value, &account); err != nil {
+		return nil, errors.NewStackedError(err, "failed to decode account")
+	}
+
+	return &ProvedAccount{
+		Nonce:    account.Nonce,
+		Balance:  account.Amount,
+		CodeHash: common.BytesToHash(account.CodeHash),
+	}, nil
+}
+
+// VerifyStorageProof verifies the storage proof against the state root, and returns the
+// storage value of the specified key, or nil if not set.
+func VerifyStorageProof(root common.Hash, addr common.Address, key common.Hash, proof map[string][]byte) ([]byte, error) {
+	return trie.VerifyProof(root, storageKey(addr, key), proof)
+}
+
+func storageKey(addr common.Address, key common.Hash) []byte {
+	return newStateObject(addr).dataKey(dataTypeStorage, crypto.MustHash(key).Bytes()...)
+}
@@ -0,0 +1,77 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Statedb_Proof(t *testing.T) {
+	db, remove := leveldb.NewTestDatabase()
+	defer remove()
+
+	statedb := NewEmptyStatedb(db)
+	addr, contract := *crypto.MustGenerateRandomAddress(), *crypto.MustGenerateRandomAddress()
+	statedb.CreateAccount(addr)
+	statedb.SetBalance(addr, big.NewInt(100))
+	statedb.SetNonce(addr, 3)
+	statedb.CreateAccount(contract)
+	statedb.SetCode(contract, []byte("code"))
+	key := common.StringToHash("key")
+	statedb.SetData(contract, key, []byte("value"))
+
+	batch := db.NewBatch()
+	root, err := statedb.Commit(batch)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, batch.Commit(), nil)
+
+	statedb, err = NewStatedb(root, db)
+	assert.Equal(t, err, nil)
+
+	// account exists
+	proof, err := statedb.GetAccountProof(addr)
+	assert.Equal(t, err, nil)
+	account, err := VerifyAccountProof(root, addr, proof)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, account, &ProvedAccount{Nonce: 3, Balance: big.NewInt(100), CodeHash: common.EmptyHash})
+
+	proof, err = statedb.GetAccountProof(contract)
+	assert.Equal(t, err, nil)
+	account, err = VerifyAccountProof(root, contract, proof)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, account.CodeHash, crypto.HashBytes([]byte("code")))
+
+	// storage exists or not
+	proof, err = statedb.GetStorageProof(contract, key)
+	assert.Equal(t, err, nil)
+	value, err := VerifyStorageProof(root, contract, key, proof)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, value, []byte("value"))
+
+	proof, err = statedb.GetStorageProof(contract, common.StringToHash("unknown"))
+	assert.Equal(t, err, nil)
+	value, err = VerifyStorageProof(root, contract, common.StringToHash("unknown"), proof)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(value), 0)
+
+	// account does not exist
+	unknown := *crypto.MustGenerateRandomAddress()
+	proof, err = statedb.GetAccountProof(unknown)
+	assert.Equal(t, err, nil)
+	account, err = VerifyAccountProof(root, unknown, proof)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, account == nil, true)
+
+	// proof against another root
+	_, err = VerifyAccountProof(common.StringToHash("root"), addr, proof)
+	assert.Error(t, err)
+}
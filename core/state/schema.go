@@ -0,0 +1,14 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package state
+
+import "github.com/seeleteam/go-seele/database/migration"
+
+// AccountStateSchema is the schema of account state database, in which the state trie
+// nodes are stored with TrieDbPrefix. Append migration here when the key layout changed.
+var AccountStateSchema = migration.NewSchema("account state",
+	&migration.Migration{Version: 1, Description: "initial versioned layout"},
+)
@@ -0,0 +1,305 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/log"
+	"github.com/seeleteam/go-seele/trie"
+)
+
+const (
+	// statePruneInterval is the number of blocks between two online prunings.
+	statePruneInterval = 1000
+
+	// statePruneBatchSize is the max number of deleted nodes in a batch.
+	statePruneBatchSize = 10000
+)
+
+var stateNodeKeyLength = len(state.TrieDbPrefix) + common.HashLength
+
+// StatePruneResult is the statistics of a state pruning.
+type StatePruneResult struct {
+	Roots    int           // number of retained state roots
+	Retained int           // number of trie nodes that reachable from the retained roots
+	Deleted  int           // number of stale trie nodes deleted
+	Elapsed  time.Duration // time elapsed of pruning
+}
+
+// StatePruner deletes the stale state trie nodes in account state DB,
+// which are not reachable from any retained state root.
+type StatePruner struct {
+	accountStateDB database.Database
+	mutex          sync.Mutex // only one pruning at a time
+	log            *log.SeeleLog
+}
+
+// NewStatePruner returns a state pruner for the specified account state DB.
+func NewStatePruner(accountStateDB database.Database) *StatePruner {
+	return &StatePruner{
+		accountStateDB: accountStateDB,
+		log:            log.GetLogger("statePruner"),
+	}
+}
+
+// RetainedStateRoots returns the state roots of canonical blocks that should be retained,
+// that is all the unconfirmed blocks and the last retained number of confirmed blocks.
+func RetainedStateRoots(bcStore store.BlockchainStore, headHeight uint64, retained uint64) ([]common.Hash, error) {
+	from := uint64(0)
+	if keep := retained + common.ConfirmedBlockNumber; headHeight >= keep {
+		from = headHeight - keep + 1
+	}
+
+	var roots []common.Hash
+	for height := from; height <= headHeight; height++ {
+		hash, err := bcStore.GetBlockHash(height)
+		if err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get block hash by height %v", height)
+		}
+
+		header, err := bcStore.GetBlockHeader(hash)
+		if err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get block header by hash %v", hash)
+		}
+
+		roots = append(roots, header.StateHash)
+	}
+
+	return roots, nil
+}
+
+// Prune marks all the trie nodes that reachable from the specified state roots,
+// and then deletes all the others. Note, there should be no concurrent state
+// written into the account state DB during pruning.
+func (p *StatePruner) Prune(roots []common.Hash) (*StatePruneResult, error) {
+	return p.prune(roots, nil, nil)
+}
+
+// PruneOnline is the same as Prune, but allows the state written into the account state DB
+// concurrently with the lock held. Before deleting each batch of stale nodes, it holds the lock
+// and marks the trie nodes of the latest state roots, so that the nodes written during pruning,
+// which may be the same as some stale ones, are never deleted.
+func (p *StatePruner) PruneOnline(roots []common.Hash, lock sync.Locker, latestRoots func() ([]common.Hash, error)) (*StatePruneResult, error) {
+	return p.prune(roots, lock, latestRoots)
+}
+
+func (p *StatePruner) prune(roots []common.Hash, lock sync.Locker, latestRoots func() ([]common.Hash, error)) (*StatePruneResult, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	start := time.Now()
+	result := &StatePruneResult{}
+
+	// mark the reachable nodes
+	reachable := make(map[common.Hash]struct{})
+	marked, err := p.mark(roots, reachable)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Roots = marked
+	result.Retained = len(reachable)
+	p.log.Info("state pruning marked %v nodes of %v roots, elapsed %v", result.Retained, result.Roots, time.Since(start))
+
+	// sweep the unreachable nodes
+	it := p.accountStateDB.NewIteratorWithPrefix(state.TrieDbPrefix)
+	defer it.Release()
+
+	var stale [][]byte
+	for it.Next() {
+		key := it.Key()
+		if len(key) != stateNodeKeyLength {
+			continue
+		}
+
+		if _, found := reachable[common.BytesToHash(key[len(state.TrieDbPrefix):])]; found {
+			continue
+		}
+
+		stale = append(stale, common.CopyBytes(key))
+		if len(stale) >= statePruneBatchSize {
+			deleted, err := p.delete(stale, reachable, lock, latestRoots)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Deleted += deleted
+			stale = nil
+		}
+	}
+
+	if err := it.Error(); err != nil {
+		return nil, errors.NewStackedError(err, "failed to iterate state nodes")
+	}
+
+	if len(stale) > 0 {
+		deleted, err := p.delete(stale, reachable, lock, latestRoots)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Deleted += deleted
+	}
+
+	result.Retained = len(reachable)
+	result.Elapsed = time.Since(start)
+	p.log.Info("state pruning deleted %v stale nodes, elapsed %v", result.Deleted, result.Elapsed)
+
+	return result, nil
+}
+
+// mark marks the trie nodes that reachable from the specified state roots, and returns
+// the number of roots not marked before.
+func (p *StatePruner) mark(roots []common.Hash, reachable map[common.Hash]struct{}) (int, error) {
+	marked := 0
+	for _, root := range roots {
+		if root.IsEmpty() {
+			continue
+		}
+
+		if _, found := reachable[root]; found {
+			continue
+		}
+
+		t, err := trie.NewTrie(root, state.TrieDbPrefix, p.accountStateDB)
+		if err != nil {
+			return 0, errors.NewStackedErrorf(err, "failed to load state trie with root %v", root)
+		}
+
+		err = t.WalkNodes(func(hash []byte) bool {
+			h := common.BytesToHash(hash)
+			if _, found := reachable[h]; found {
+				return false
+			}
+
+			reachable[h] = struct{}{}
+			return true
+		})
+
+		if err != nil {
+			return 0, errors.NewStackedErrorf(err, "failed to walk state trie with root %v", root)
+		}
+
+		marked++
+	}
+
+	return marked, nil
+}
+
+// delete deletes the specified stale nodes in a batch. For online pruning, the nodes of
+// the latest state roots are marked with the lock held, and will not be deleted.
+func (p *StatePruner) delete(keys [][]byte, reachable map[common.Hash]struct{}, lock sync.Locker, latestRoots func() ([]common.Hash, error)) (int, error) {
+	if lock != nil {
+		lock.Lock()
+		defer lock.Unlock()
+
+		roots, err := latestRoots()
+		if err != nil {
+			return 0, errors.NewStackedError(err, "failed to get latest state roots")
+		}
+
+		if _, err = p.mark(roots, reachable); err != nil {
+			return 0, err
+		}
+	}
+
+	batch := p.accountStateDB.NewBatch()
+	deleted := 0
+	for _, key := range keys {
+		if _, found := reachable[common.BytesToHash(key[len(state.TrieDbPrefix):])]; !found {
+			batch.Delete(key)
+			deleted++
+		}
+	}
+
+	if err := batch.Commit(); err != nil {
+		return 0, errors.NewStackedError(err, "failed to delete stale state nodes")
+	}
+
+	return deleted, nil
+}
+
+// EnableStatePruning enables the online state pruning of blockchain, which deletes the
+// stale state trie nodes every statePruneInterval blocks, and retains the state of all
+// unconfirmed blocks and the last specified number of confirmed blocks.
+func (bc *Blockchain) EnableStatePruning(retained uint64) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	bc.statePruner = NewStatePruner(bc.accountStateDB)
+	bc.stateRetained = retained
+}
+
+// pruneStateAsync prunes the stale state trie nodes in background with the snapshot of
+// retained state roots, and returns false if the previous pruning is still in progress.
+// It requires the blockchain lock held by caller to take the snapshot, and the pruning
+// holds the lock only when deleting a batch of stale nodes.
+func (bc *Blockchain) pruneStateAsync(headHeight uint64) bool {
+	if !atomic.CompareAndSwapInt32(&bc.statePruning, 0, 1) {
+		bc.log.Warn("skip state pruning at height %v, since the previous one is in progress", headHeight)
+		return false
+	}
+
+	roots, err := bc.retainedStateRoots(headHeight)
+	if err != nil {
+		atomic.StoreInt32(&bc.statePruning, 0)
+		bc.log.Error(errors.NewStackedErrorf(err, "failed to prune state, height = %v", headHeight).Error())
+		return false
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&bc.statePruning, 0)
+
+		result, err := bc.statePruner.PruneOnline(roots, &bc.lock, func() ([]common.Hash, error) {
+			return bc.retainedStateRoots(bc.CurrentBlock().Header.Height)
+		})
+
+		if err != nil {
+			bc.log.Error(errors.NewStackedErrorf(err, "failed to prune state, height = %v", headHeight).Error())
+		} else {
+			bc.log.Info("state pruned, height = %v, deleted = %v, elapsed = %v", headHeight, result.Deleted, result.Elapsed)
+		}
+	}()
+
+	return true
+}
+
+// retainedStateRoots returns the state roots to retain when pruning, including the forking
+// blocks. It requires the blockchain lock.
+func (bc *Blockchain) retainedStateRoots(headHeight uint64) ([]common.Hash, error) {
+	roots, err := RetainedStateRoots(bc.bcStore, headHeight, bc.stateRetained)
+	if err != nil {
+		return nil, errors.NewStackedError(err, "failed to get retained state roots")
+	}
+
+	// retains the state of forking blocks, which may become canonical later.
+	// Note, the forking blocks may be purged asynchronously at the same time.
+	for hash := range bc.blockLeaves.blockIndexMap {
+		for !hash.IsEmpty() {
+			header, err := bc.bcStore.GetBlockHeader(hash)
+			if err != nil {
+				break
+			}
+
+			if canonicalHash, err := bc.bcStore.GetBlockHash(header.Height); err == nil && canonicalHash.Equal(hash) {
+				break
+			}
+
+			roots = append(roots, header.StateHash)
+			hash = header.PreviousBlockHash
+		}
+	}
+
+	return roots, nil
+}
@@ -0,0 +1,160 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package core
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/database/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func commitTestState(t *testing.T, db database.Database, root common.Hash, update func(*state.Statedb)) common.Hash {
+	statedb, err := state.NewStatedb(root, db)
+	assert.Equal(t, err, nil)
+
+	update(statedb)
+
+	batch := db.NewBatch()
+	newRoot, err := statedb.Commit(batch)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, batch.Commit(), nil)
+
+	return newRoot
+}
+
+func countStateNodes(db database.Database) int {
+	it := db.NewIteratorWithPrefix(state.TrieDbPrefix)
+	defer it.Release()
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+
+	return count
+}
+
+func Test_StatePruner_Prune(t *testing.T) {
+	db := memory.NewMemoryDB()
+
+	var addrs []common.Address
+	for i := 0; i < 10; i++ {
+		addrs = append(addrs, *crypto.MustGenerateRandomAddress())
+	}
+
+	root1 := commitTestState(t, db, common.EmptyHash, func(statedb *state.Statedb) {
+		for _, addr := range addrs {
+			statedb.CreateAccount(addr)
+			statedb.SetBalance(addr, big.NewInt(100))
+		}
+	})
+
+	root2 := commitTestState(t, db, root1, func(statedb *state.Statedb) {
+		statedb.SetBalance(addrs[0], big.NewInt(50))
+		statedb.SetData(addrs[1], common.StringToHash("key"), []byte("value"))
+	})
+
+	nodes := countStateNodes(db)
+
+	// prune root1
+	result, err := NewStatePruner(db).Prune([]common.Hash{root2})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.Roots, 1)
+	assert.Equal(t, result.Deleted > 0, true)
+	assert.Equal(t, result.Retained+result.Deleted, nodes)
+	assert.Equal(t, countStateNodes(db), result.Retained)
+
+	// root2 is still available
+	statedb, err := state.NewStatedb(root2, db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, statedb.GetBalance(addrs[0]), big.NewInt(50))
+	assert.Equal(t, statedb.GetData(addrs[1], common.StringToHash("key")), []byte("value"))
+	for _, addr := range addrs[1:] {
+		assert.Equal(t, statedb.GetBalance(addr), big.NewInt(100))
+	}
+
+	// root1 is pruned
+	_, err = state.NewStatedb(root1, db)
+	assert.Equal(t, err != nil, true)
+
+	// prune again, nothing deleted
+	result, err = NewStatePruner(db).Prune([]common.Hash{root2})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.Deleted, 0)
+}
+
+func Test_StatePruner_PruneOnline(t *testing.T) {
+	db := memory.NewMemoryDB()
+	addr := *crypto.MustGenerateRandomAddress()
+
+	root1 := commitTestState(t, db, common.EmptyHash, func(statedb *state.Statedb) {
+		statedb.CreateAccount(addr)
+		statedb.SetBalance(addr, big.NewInt(100))
+	})
+
+	root2 := commitTestState(t, db, root1, func(statedb *state.Statedb) {
+		statedb.SetBalance(addr, big.NewInt(50))
+	})
+
+	// root1 is written again during pruning, e.g. by a new block that reverts the balance.
+	var lock sync.Mutex
+	called := 0
+	result, err := NewStatePruner(db).PruneOnline([]common.Hash{root2}, &lock, func() ([]common.Hash, error) {
+		called++
+		return []common.Hash{root2, root1}, nil
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, called > 0, true)
+	assert.Equal(t, result.Deleted, 0)
+
+	statedb, err := state.NewStatedb(root1, db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, statedb.GetBalance(addr), big.NewInt(100))
+
+	// root1 is not written again
+	result, err = NewStatePruner(db).PruneOnline([]common.Hash{root2}, &lock, func() ([]common.Hash, error) {
+		return []common.Hash{root2}, nil
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.Deleted > 0, true)
+
+	_, err = state.NewStatedb(root1, db)
+	assert.Equal(t, err != nil, true)
+}
+
+func Test_RetainedStateRoots(t *testing.T) {
+	bcStore := store.NewMemStore()
+
+	headHeight := uint64(common.ConfirmedBlockNumber + 10)
+	for height := uint64(0); height <= headHeight; height++ {
+		header := &types.BlockHeader{
+			Height:    height,
+			StateHash: common.BigToHash(new(big.Int).SetUint64(height + 1)),
+		}
+
+		block := &types.Block{HeaderHash: header.Hash(), Header: header}
+		assert.Equal(t, bcStore.PutBlock(block, big.NewInt(1), true), nil)
+	}
+
+	roots, err := RetainedStateRoots(bcStore, headHeight, 5)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(roots), common.ConfirmedBlockNumber+5)
+	assert.Equal(t, roots[0], common.BigToHash(big.NewInt(7)))
+
+	// all retained if blockchain is short
+	roots, err = RetainedStateRoots(bcStore, headHeight, 100)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(roots), int(headHeight)+1)
+}
@@ -0,0 +1,187 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/database"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+)
+
+var (
+	// keyPrefixAccountHistory + address + height + kind + index => tx/debt hash + block hash
+	keyPrefixAccountHistory = []byte("a")
+
+	// keyAccountHistoryStart => height of the first block indexed, and the blocks below are not indexed.
+	keyAccountHistoryStart = []byte("AccountHistoryStart")
+
+	// ErrAccountHistoryDisabled is returned when query account history while the index is disabled.
+	ErrAccountHistoryDisabled = errors.New("account history index is disabled")
+
+	// ErrAccountHistoryNotIndexed is returned when query account history of the blocks that not indexed,
+	// e.g. the blocks written before the index enabled.
+	ErrAccountHistoryNotIndexed = errors.New("account history is not indexed for the blocks in height range")
+)
+
+const (
+	accountHistoryKindTx   byte = 0
+	accountHistoryKindDebt byte = 1
+)
+
+// AccountHistory is an entry of the account history index, which refers to a tx or debt
+// in canonical chain that the account involved in.
+type AccountHistory struct {
+	Hash      common.Hash // tx or debt hash
+	BlockHash common.Hash
+	Height    uint64
+	Index     uint // index in block body
+	IsDebt    bool
+}
+
+func accountHistoryPrefix(account common.Address) []byte {
+	return append(common.CopyBytes(keyPrefixAccountHistory), account.Bytes()...)
+}
+
+func accountHistoryKey(account common.Address, height uint64, kind byte, index uint) []byte {
+	key := accountHistoryPrefix(account)
+	key = append(key, encodeBlockHeight(height)...)
+	key = append(key, kind)
+
+	encodedIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(encodedIndex, uint32(index))
+
+	return append(key, encodedIndex...)
+}
+
+// accountHistoryEntries returns the accounts involved in txs and debts, and the keys of index.
+func accountHistoryEntries(height uint64, txs []*types.Transaction, debts []*types.Debt, handle func(key []byte, hash common.Hash)) {
+	for i, tx := range txs {
+		from, to := tx.FromAccount(), tx.ToAccount()
+
+		// reward tx is from empty address
+		if !from.IsEmpty() {
+			handle(accountHistoryKey(from, height, accountHistoryKindTx, uint(i)), tx.Hash)
+		}
+
+		if !to.IsEmpty() && !to.Equal(from) {
+			handle(accountHistoryKey(to, height, accountHistoryKindTx, uint(i)), tx.Hash)
+		}
+	}
+
+	for i, debt := range debts {
+		if account := debt.Data.Account; !account.IsEmpty() {
+			handle(accountHistoryKey(account, height, accountHistoryKindDebt, uint(i)), debt.Hash)
+		}
+	}
+}
+
+func (store *blockchainDatabase) batchAddAccountHistory(batch database.Batch, blockHash common.Hash, header *types.BlockHeader, txs []*types.Transaction, debts []*types.Debt) error {
+	if !store.accountHistory {
+		return nil
+	}
+
+	// record the start height when index the first block, e.g. the index enabled for a non-empty chain.
+	started, err := store.db.Has(keyAccountHistoryStart)
+	if err != nil {
+		return err
+	}
+
+	if !started {
+		start := header.Height
+		if start == 1 { // genesis block has no txs
+			start = 0
+		}
+
+		batch.Put(keyAccountHistoryStart, encodeBlockHeight(start))
+	}
+
+	accountHistoryEntries(header.Height, txs, debts, func(key []byte, hash common.Hash) {
+		batch.Put(key, append(hash.Bytes(), blockHash.Bytes()...))
+	})
+
+	return nil
+}
+
+func (store *blockchainDatabase) batchDeleteAccountHistory(batch database.Batch, blockHash common.Hash, header *types.BlockHeader, txs []*types.Transaction, debts []*types.Debt) error {
+	if !store.accountHistory {
+		return nil
+	}
+
+	var err error
+	accountHistoryEntries(header.Height, txs, debts, func(key []byte, hash common.Hash) {
+		if err != nil {
+			return
+		}
+
+		// the entry may be overwritten by another block at the same height.
+		var value []byte
+		if value, err = store.db.Get(key); err == leveldbErrors.ErrNotFound {
+			err = nil
+		} else if err == nil && bytes.Equal(value[common.HashLength:], blockHash.Bytes()) {
+			batch.Delete(key)
+		}
+	})
+
+	return err
+}
+
+// GetAccountHistory retrieves the txs and debts that the specified account involved in
+// canonical blocks with height in range [fromHeight, toHeight], in ascending order of
+// height and index in block. The first offset entries are skipped, and at most limit
+// entries returned. ErrAccountHistoryNotIndexed is returned if fromHeight is lower than
+// the height that the index started from.
+func (store *blockchainDatabase) GetAccountHistory(account common.Address, fromHeight, toHeight uint64, offset, limit uint) ([]*AccountHistory, error) {
+	if !store.accountHistory {
+		return nil, ErrAccountHistoryDisabled
+	}
+
+	value, err := store.db.Get(keyAccountHistoryStart)
+	if err == leveldbErrors.ErrNotFound {
+		return nil, errors.NewStackedError(ErrAccountHistoryNotIndexed, "no block indexed yet")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if start := binary.BigEndian.Uint64(value); fromHeight < start {
+		return nil, errors.NewStackedErrorf(ErrAccountHistoryNotIndexed, "indexed from height %v", start)
+	}
+
+	var result []*AccountHistory
+	if fromHeight > toHeight || limit == 0 {
+		return result, nil
+	}
+
+	start := accountHistoryKey(account, fromHeight, 0, 0)
+	end := append(accountHistoryPrefix(account), encodeBlockHeight(toHeight)...)
+	end = append(end, 0xff, 0xff, 0xff, 0xff, 0xff)
+
+	it := store.db.NewIterator(start, end)
+	defer it.Release()
+
+	prefixLen := len(keyPrefixAccountHistory) + common.AddressLen
+	for skipped := uint(0); it.Next() && uint(len(result)) < limit; {
+		if skipped < offset {
+			skipped++
+			continue
+		}
+
+		key, value := it.Key(), it.Value()
+		result = append(result, &AccountHistory{
+			Hash:      common.BytesToHash(value[:common.HashLength]),
+			BlockHash: common.BytesToHash(value[common.HashLength:]),
+			Height:    binary.BigEndian.Uint64(key[prefixLen : prefixLen+8]),
+			Index:     uint(binary.BigEndian.Uint32(key[prefixLen+9:])),
+			IsDebt:    key[prefixLen+8] == accountHistoryKindDebt,
+		})
+	}
+
+	return result, it.Error()
+}
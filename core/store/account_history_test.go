@@ -0,0 +1,179 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package store
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAccountHistoryDatabase() (BlockchainStore, func()) {
+	db, dispose := leveldb.NewTestDatabase()
+	return NewBlockchainDatabaseWithAccountHistory(db), dispose
+}
+
+func newTestAccountHistoryBlock(height uint64, extra string, txs []*types.Transaction, debts []*types.Debt) *types.Block {
+	header := &types.BlockHeader{
+		PreviousBlockHash: crypto.MustHash(height - 1),
+		Creator:           *crypto.MustGenerateRandomAddress(),
+		TxHash:            types.MerkleRootHash(txs),
+		DebtHash:          types.DebtMerkleRootHash(debts),
+		Height:            height,
+		Difficulty:        big.NewInt(1),
+		CreateTimestamp:   big.NewInt(1),
+		ExtraData:         []byte(extra),
+	}
+
+	return &types.Block{
+		HeaderHash:   header.Hash(),
+		Header:       header,
+		Transactions: txs,
+		Debts:        debts,
+	}
+}
+
+func Test_blockchainDatabase_GetAccountHistory_Disabled(t *testing.T) {
+	bcStore, dispose := newTestBlockchainDatabase()
+	defer dispose()
+
+	block := newTestAccountHistoryBlock(1, "", []*types.Transaction{types.NewTestTxDetail(1, 1, 1)}, nil)
+	assert.Nil(t, bcStore.PutBlock(block, big.NewInt(1), true))
+
+	_, err := bcStore.GetAccountHistory(types.TestGenesisAccount.Addr, 0, 10, 0, 10)
+	assert.Equal(t, err, ErrAccountHistoryDisabled)
+}
+
+func Test_blockchainDatabase_GetAccountHistory(t *testing.T) {
+	bcStore, dispose := newTestAccountHistoryDatabase()
+	defer dispose()
+
+	from := types.TestGenesisAccount.Addr
+	var blocks []*types.Block
+	for h := uint64(1); h <= 3; h++ {
+		txs := []*types.Transaction{types.NewTestTxDetail(1, 1, 2*h-1), types.NewTestTxDetail(1, 1, 2*h)}
+		block := newTestAccountHistoryBlock(h, "", txs, []*types.Debt{types.NewTestDebt()})
+		assert.Nil(t, bcStore.PutBlock(block, big.NewInt(int64(h)), true))
+		blocks = append(blocks, block)
+	}
+
+	// all txs of sender in ascending order
+	history, err := bcStore.GetAccountHistory(from, 0, 10, 0, 100)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 6)
+	for i, entry := range history {
+		block := blocks[i/2]
+		assert.Equal(t, entry.Hash, block.Transactions[i%2].Hash)
+		assert.Equal(t, entry.BlockHash, block.HeaderHash)
+		assert.Equal(t, entry.Height, block.Header.Height)
+		assert.Equal(t, entry.Index, uint(i%2))
+		assert.Equal(t, entry.IsDebt, false)
+	}
+
+	// height range and pagination
+	history, err = bcStore.GetAccountHistory(from, 2, 3, 1, 2)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 2)
+	assert.Equal(t, history[0].Hash, blocks[1].Transactions[1].Hash)
+	assert.Equal(t, history[1].Hash, blocks[2].Transactions[0].Hash)
+
+	history, err = bcStore.GetAccountHistory(from, 3, 2, 0, 10)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 0)
+
+	// receiver of tx
+	history, err = bcStore.GetAccountHistory(blocks[1].Transactions[0].Data.To, 0, 10, 0, 10)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 1)
+	assert.Equal(t, history[0].Hash, blocks[1].Transactions[0].Hash)
+
+	// receiver of debt
+	debt := blocks[2].Debts[0]
+	history, err = bcStore.GetAccountHistory(debt.Data.Account, 0, 10, 0, 10)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 1)
+	assert.Equal(t, history[0].Hash, debt.Hash)
+	assert.Equal(t, history[0].Height, uint64(3))
+	assert.Equal(t, history[0].IsDebt, true)
+}
+
+func Test_blockchainDatabase_GetAccountHistory_Reorg(t *testing.T) {
+	bcStore, dispose := newTestAccountHistoryDatabase()
+	defer dispose()
+
+	oldTx, newTx := types.NewTestTxDetail(1, 1, 1), types.NewTestTxDetail(2, 1, 1)
+	oldBlock := newTestAccountHistoryBlock(1, "old", []*types.Transaction{oldTx}, nil)
+	newBlock := newTestAccountHistoryBlock(1, "new", []*types.Transaction{newTx}, nil)
+
+	assert.Nil(t, bcStore.PutBlock(oldBlock, big.NewInt(1), true))
+	assert.Nil(t, bcStore.PutBlock(newBlock, big.NewInt(2), true))
+
+	// entry of sender overwritten by new HEAD block
+	history, err := bcStore.GetAccountHistory(types.TestGenesisAccount.Addr, 0, 10, 0, 10)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 1)
+	assert.Equal(t, history[0].Hash, newTx.Hash)
+	assert.Equal(t, history[0].BlockHash, newBlock.HeaderHash)
+
+	// entry of receiver in old block deleted
+	history, err = bcStore.GetAccountHistory(oldTx.Data.To, 0, 10, 0, 10)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 0)
+
+	// delete the stale block will not affect the canonical entries
+	assert.Nil(t, bcStore.DeleteBlock(oldBlock.HeaderHash))
+	history, _ = bcStore.GetAccountHistory(newTx.Data.To, 0, 10, 0, 10)
+	assert.Equal(t, len(history), 1)
+
+	// delete indices of canonical block
+	assert.Nil(t, bcStore.DeleteIndices(newBlock))
+	history, _ = bcStore.GetAccountHistory(types.TestGenesisAccount.Addr, 0, 10, 0, 10)
+	assert.Equal(t, len(history), 0)
+
+	// add indices back
+	assert.Nil(t, bcStore.AddIndices(newBlock))
+	history, _ = bcStore.GetAccountHistory(newTx.Data.To, 0, 10, 0, 10)
+	assert.Equal(t, len(history), 1)
+}
+
+func Test_blockchainDatabase_GetAccountHistory_NotIndexed(t *testing.T) {
+	db, dispose := leveldb.NewTestDatabase()
+	defer dispose()
+
+	// no block indexed yet
+	bcStore := NewBlockchainDatabaseWithAccountHistory(db)
+	_, err := bcStore.GetAccountHistory(types.TestGenesisAccount.Addr, 0, 10, 0, 10)
+	assert.Equal(t, errors.IsOrContains(err, ErrAccountHistoryNotIndexed), true)
+
+	// blocks written before the index enabled
+	for h := uint64(1); h <= 2; h++ {
+		block := newTestAccountHistoryBlock(h, "", []*types.Transaction{types.NewTestTxDetail(1, 1, h)}, nil)
+		assert.Nil(t, NewBlockchainDatabase(db).PutBlock(block, big.NewInt(int64(h)), true))
+	}
+
+	block := newTestAccountHistoryBlock(3, "", []*types.Transaction{types.NewTestTxDetail(1, 1, 3)}, nil)
+	assert.Nil(t, bcStore.PutBlock(block, big.NewInt(3), true))
+
+	_, err = bcStore.GetAccountHistory(types.TestGenesisAccount.Addr, 0, 10, 0, 10)
+	assert.Equal(t, errors.IsOrContains(err, ErrAccountHistoryNotIndexed), true)
+
+	history, err := bcStore.GetAccountHistory(types.TestGenesisAccount.Addr, 3, 10, 0, 10)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 1)
+	assert.Equal(t, history[0].Hash, block.Transactions[0].Hash)
+
+	// start height is not changed by the later blocks
+	next := newTestAccountHistoryBlock(4, "", []*types.Transaction{types.NewTestTxDetail(1, 1, 4)}, nil)
+	assert.Nil(t, bcStore.PutBlock(next, big.NewInt(4), true))
+	history, err = bcStore.GetAccountHistory(types.TestGenesisAccount.Addr, 3, 10, 0, 10)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, len(history), 2)
+}
@@ -238,6 +238,11 @@ func (store *cachedStore) GetReceiptsByBlockHash(hash common.Hash) ([]*types.Rec
 	return store.raw.GetReceiptsByBlockHash(hash)
 }
 
+// GetBlockBloom retrieves the log bloom of receipts for the specified block hash.
+func (store *cachedStore) GetBlockBloom(hash common.Hash) (types.Bloom, error) {
+	return store.raw.GetBlockBloom(hash)
+}
+
 // GetReceiptByTxHash retrieves the receipt for the specified tx hash.
 func (store *cachedStore) GetReceiptByTxHash(txHash common.Hash) (*types.Receipt, error) {
 	return store.raw.GetReceiptByTxHash(txHash)
@@ -262,3 +267,9 @@ func (store *cachedStore) GetDebtIndex(txHash common.Hash) (*types.DebtIndex, er
 func (store *cachedStore) DeleteIndices(block *types.Block) error {
 	return store.raw.DeleteIndices(block)
 }
+
+// GetAccountHistory retrieves the txs and debts that the specified account involved in
+// canonical blocks with height in range [fromHeight, toHeight].
+func (store *cachedStore) GetAccountHistory(account common.Address, fromHeight, toHeight uint64, offset, limit uint) ([]*AccountHistory, error) {
+	return store.raw.GetAccountHistory(account, fromHeight, toHeight, offset, limit)
+}
@@ -26,6 +26,7 @@ var (
 	keyPrefixReceipts  = []byte("r")
 	keyPrefixTxIndex   = []byte("i")
 	keyPrefixDebtIndex = []byte("d")
+	keyPrefixBloom     = []byte("l")
 )
 
 // blockBody represents the payload of a block
@@ -36,7 +37,8 @@ type blockBody struct {
 
 // blockchainDatabase wraps a database used for the blockchain
 type blockchainDatabase struct {
-	db database.Database
+	db             database.Database
+	accountHistory bool // indicates whether to maintain the account history index
 }
 
 // NewBlockchainDatabase returns a blockchainDatabase instance.
@@ -48,8 +50,21 @@ type blockchainDatabase struct {
 //   5) keyPrefixBody + hash => block body (transactions)
 //   6) keyPrefixReceipts + hash => block receipts
 //   7) keyPrefixTxIndex + txHash => txIndex
+//   8) keyPrefixDebtIndex + debtHash => debtIndex
+//   9) keyPrefixAccountHistory + address + height + kind + index => tx/debt hash + block hash,
+//      only if account history index enabled.
+//     keyAccountHistoryStart => height that account history index started from.
+//  10) keyPrefixBloom + hash => log bloom of block receipts
 func NewBlockchainDatabase(db database.Database) BlockchainStore {
-	return &blockchainDatabase{db}
+	return &blockchainDatabase{db: db}
+}
+
+// NewBlockchainDatabaseWithAccountHistory returns a blockchainDatabase instance that also
+// maintains the account history index for the txs and debts in canonical chain. Note,
+// the blocks written before the index enabled are not indexed, and the account history
+// could only be queried from the height of the first indexed block.
+func NewBlockchainDatabaseWithAccountHistory(db database.Database) BlockchainStore {
+	return &blockchainDatabase{db: db, accountHistory: true}
 }
 
 func heightToHashKey(height uint64) []byte      { return append(keyPrefixHash, encodeBlockHeight(height)...) }
@@ -59,6 +74,7 @@ func hashToBodyKey(hash []byte) []byte          { return append(keyPrefixBody, h
 func hashToReceiptsKey(hash []byte) []byte      { return append(keyPrefixReceipts, hash...) }
 func txHashToIndexKey(txHash []byte) []byte     { return append(keyPrefixTxIndex, txHash...) }
 func debtHashToIndexKey(debtHash []byte) []byte { return append(keyPrefixDebtIndex, debtHash...) }
+func hashToBloomKey(hash []byte) []byte         { return append(keyPrefixBloom, hash...) }
 
 // GetBlockHash gets the hash of the block with the specified height in the blockchain database
 func (store *blockchainDatabase) GetBlockHash(height uint64) (common.Hash, error) {
@@ -185,12 +201,18 @@ func (store *blockchainDatabase) putBlockInternal(hash common.Hash, header *type
 
 			if err == nil {
 				store.batchDeleteIndices(batch, oldHash, oldBlock.Transactions, oldBlock.Debts)
+				if err = store.batchDeleteAccountHistory(batch, oldHash, oldBlock.Header, oldBlock.Transactions, oldBlock.Debts); err != nil {
+					return err
+				}
 			}
 		}
 
 		// add or update txs/debts indices of new HEAD block
 		if body != nil {
 			store.batchAddIndices(batch, hash, body.Txs, body.Debts)
+			if err := store.batchAddAccountHistory(batch, hash, header, body.Txs, body.Debts); err != nil {
+				return err
+			}
 		}
 
 		// update height to hash map in canonical chain and HEAD block hash
@@ -236,6 +258,9 @@ func (store *blockchainDatabase) RecoverHeightToBlockMap(block *types.Block) err
 	batch := store.db.NewBatch()
 	// add or update txs/debts indices of this block
 	store.batchAddIndices(batch, block.HeaderHash, block.Transactions, block.Debts)
+	if err := store.batchAddAccountHistory(batch, block.HeaderHash, block.Header, block.Transactions, block.Debts); err != nil {
+		return err
+	}
 	// update height to hash map in the chain
 	hashBytes := block.HeaderHash.Bytes()
 	batch.Put(heightToHashKey(block.Header.Height), hashBytes)
@@ -297,11 +322,18 @@ func (store *blockchainDatabase) DeleteBlock(hash common.Hash) error {
 	hashBytes := hash.Bytes()
 	batch := store.db.NewBatch()
 
+	// header is required to delete account history index
+	header, err := store.GetBlockHeader(hash)
+	if err != nil && err != errors.ErrNotFound {
+		return err
+	}
+
 	// delete header, TD and receipts if any.
 	headerKey := hashToHeaderKey(hashBytes)
 	tdKey := hashToTDKey(hashBytes)
 	receiptsKey := hashToReceiptsKey(hashBytes)
-	if err := store.delete(batch, headerKey, tdKey, receiptsKey); err != nil {
+	bloomKey := hashToBloomKey(hashBytes)
+	if err = store.delete(batch, headerKey, tdKey, receiptsKey, bloomKey); err != nil {
 		return err
 	}
 
@@ -331,6 +363,12 @@ func (store *blockchainDatabase) DeleteBlock(hash common.Hash) error {
 		return err
 	}
 
+	if header != nil {
+		if err = store.batchDeleteAccountHistory(batch, hash, header, body.Txs, body.Debts); err != nil {
+			return err
+		}
+	}
+
 	// delete body
 	batch.Delete(bodyKey)
 
@@ -365,16 +403,33 @@ func (store *blockchainDatabase) GetBlockByHeight(height uint64) (*types.Block,
 	return block, nil
 }
 
-// PutReceipts serializes given receipts for the specified block hash.
+// PutReceipts serializes given receipts for the specified block hash,
+// along with the log bloom of receipts.
 func (store *blockchainDatabase) PutReceipts(hash common.Hash, receipts []*types.Receipt) error {
 	encodedBytes, err := common.Serialize(receipts)
 	if err != nil {
 		return err
 	}
 
-	key := hashToReceiptsKey(hash.Bytes())
+	batch := store.db.NewBatch()
+	batch.Put(hashToReceiptsKey(hash.Bytes()), encodedBytes)
+	batch.Put(hashToBloomKey(hash.Bytes()), types.ReceiptsBloom(receipts).Bytes())
 
-	return store.db.Put(key, encodedBytes)
+	return batch.Commit()
+}
+
+// GetBlockBloom retrieves the log bloom of receipts for the specified block hash.
+func (store *blockchainDatabase) GetBlockBloom(hash common.Hash) (types.Bloom, error) {
+	value, err := store.db.Get(hashToBloomKey(hash.Bytes()))
+	if err != nil {
+		return types.Bloom{}, err
+	}
+
+	if len(value) != types.BloomByteLength {
+		return types.Bloom{}, fmt.Errorf("invalid bloom length %v of block %v", len(value), hash.Hex())
+	}
+
+	return types.BytesToBloom(value), nil
 }
 
 // GetReceiptsByBlockHash retrieves the receipts for the specified block hash.
@@ -416,6 +471,10 @@ func (store *blockchainDatabase) GetReceiptByTxHash(txHash common.Hash) (*types.
 func (store *blockchainDatabase) AddIndices(block *types.Block) error {
 	batch := store.db.NewBatch()
 	store.batchAddIndices(batch, block.HeaderHash, block.Transactions, block.Debts)
+	if err := store.batchAddAccountHistory(batch, block.HeaderHash, block.Header, block.Transactions, block.Debts); err != nil {
+		return err
+	}
+
 	return batch.Commit()
 }
 
@@ -469,6 +528,10 @@ func (store *blockchainDatabase) DeleteIndices(block *types.Block) error {
 		return err
 	}
 
+	if err := store.batchDeleteAccountHistory(batch, block.HeaderHash, block.Header, block.Transactions, block.Debts); err != nil {
+		return err
+	}
+
 	return batch.Commit()
 }
 
@@ -216,6 +216,15 @@ func (store *MemStore) GetReceiptsByBlockHash(hash common.Hash) ([]*types.Receip
 	return block.receipts, nil
 }
 
+func (store *MemStore) GetBlockBloom(hash common.Hash) (types.Bloom, error) {
+	block := store.Blocks[hash]
+	if block == nil || block.receipts == nil {
+		return types.Bloom{}, errNotFound
+	}
+
+	return types.ReceiptsBloom(block.receipts), nil
+}
+
 func (store *MemStore) GetReceiptByTxHash(txHash common.Hash) (*types.Receipt, error) {
 	txIndex, found := store.TxLookups[txHash]
 	if !found {
@@ -279,3 +288,7 @@ func (store *MemStore) DeleteIndices(block *types.Block) error {
 
 	return nil
 }
+
+func (store *MemStore) GetAccountHistory(account common.Address, fromHeight, toHeight uint64, offset, limit uint) ([]*AccountHistory, error) {
+	return nil, ErrAccountHistoryDisabled
+}
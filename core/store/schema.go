@@ -0,0 +1,72 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package store
+
+import (
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/database/migration"
+)
+
+// ChainSchema is the schema of blockchain database, whose key layout is described in db_store.go.
+// Append migration here when the key layout changed.
+var ChainSchema = migration.NewSchema("blockchain",
+	&migration.Migration{Version: 1, Description: "initial versioned layout"},
+	&migration.Migration{Version: 2, Description: "log bloom index of receipts", Migrate: migrateBloomIndex},
+)
+
+// bloomMigrationBatchSize is the number of blooms committed with checkpoint at a time.
+const bloomMigrationBatchSize = 1000
+
+// migrateBloomIndex adds the log bloom for all receipts in database.
+func migrateBloomIndex(ctx *migration.Context) error {
+	start, err := ctx.Checkpoint()
+	if err != nil {
+		return err
+	}
+
+	if start == nil {
+		start = keyPrefixReceipts
+	}
+
+	// receipts keys are in range [keyPrefixReceipts, keyPrefixReceipts + 1)
+	limit := []byte{keyPrefixReceipts[0] + 1}
+	it := ctx.DB.NewIterator(start, limit)
+	defer it.Release()
+
+	batch := ctx.DB.NewBatch()
+	count := 0
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(keyPrefixReceipts)+common.HashLength {
+			continue
+		}
+
+		var receipts []*types.Receipt
+		if err = common.Deserialize(it.Value(), &receipts); err != nil {
+			return err
+		}
+
+		batch.Put(hashToBloomKey(key[len(keyPrefixReceipts):]), types.ReceiptsBloom(receipts).Bytes())
+
+		if count++; count%bloomMigrationBatchSize == 0 {
+			// resume from the next receipts key
+			ctx.SetCheckpoint(batch, append(common.CopyBytes(key), 0))
+			if err = batch.Commit(); err != nil {
+				return err
+			}
+
+			ctx.Progress("%v blooms added", count)
+			batch = ctx.DB.NewBatch()
+		}
+	}
+
+	if err = it.Error(); err != nil {
+		return err
+	}
+
+	return batch.Commit()
+}
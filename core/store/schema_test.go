@@ -0,0 +1,46 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package store
+
+import (
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ChainSchema_MigrateBloomIndex(t *testing.T) {
+	db, dispose := leveldb.NewTestDatabase()
+	defer dispose()
+
+	// receipts written before bloom index added
+	var hashes []common.Hash
+	var receipts [][]*types.Receipt
+	for i := 0; i < bloomMigrationBatchSize+10; i++ {
+		hash := crypto.MustHash(uint(i))
+		r := []*types.Receipt{&types.Receipt{Logs: []*types.Log{&types.Log{Address: common.BytesToAddress(hash.Bytes())}}}}
+		assert.Nil(t, db.Put(hashToReceiptsKey(hash.Bytes()), common.SerializePanic(r)))
+
+		hashes = append(hashes, hash)
+		receipts = append(receipts, r)
+	}
+
+	applied, err := ChainSchema.Migrate(db)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, applied, 2)
+
+	bcStore := NewBlockchainDatabase(db)
+	for i, hash := range hashes {
+		bloom, err := bcStore.GetBlockBloom(hash)
+		assert.Equal(t, err, error(nil))
+		assert.Equal(t, bloom, types.ReceiptsBloom(receipts[i]))
+	}
+
+	assert.Nil(t, ChainSchema.Check(db))
+}
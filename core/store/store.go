@@ -67,6 +67,9 @@ type BlockchainStore interface {
 	// GetReceiptsByBlockHash retrieves the receipts for the specified block hash.
 	GetReceiptsByBlockHash(hash common.Hash) ([]*types.Receipt, error)
 
+	// GetBlockBloom retrieves the log bloom of receipts for the specified block hash.
+	GetBlockBloom(hash common.Hash) (types.Bloom, error)
+
 	// GetReceiptByTxHash retrieves the receipt for the specified tx hash.
 	GetReceiptByTxHash(txHash common.Hash) (*types.Receipt, error)
 
@@ -81,4 +84,8 @@ type BlockchainStore interface {
 
 	// DeleteIndices deletes tx/debt indices of the specified block.
 	DeleteIndices(block *types.Block) error
+
+	// GetAccountHistory retrieves the txs and debts that the specified account involved in
+	// canonical blocks with height in range [fromHeight, toHeight].
+	GetAccountHistory(account common.Address, fromHeight, toHeight uint64, offset, limit uint) ([]*AccountHistory, error)
 }
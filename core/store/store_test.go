@@ -309,3 +309,30 @@ func Test_blockchainDatabase_DeleteIndices_BlockHashMismatch(t *testing.T) {
 	debtIdx2, _ := bcStore.GetDebtIndex(debts[2].Hash)
 	assert.Equal(t, debtIdx2.BlockHash, common.StringToHash("block 2"))
 }
+
+func Test_blockchainDatabase_GetBlockBloom(t *testing.T) {
+	bcStore, dispose := newTestBlockchainDatabase()
+	defer dispose()
+
+	block := newTestFullBlock(0, 1)
+	addr := common.BytesToAddress([]byte("contract"))
+	receipts := []*types.Receipt{
+		&types.Receipt{TxHash: block.Transactions[0].Hash, Logs: []*types.Log{&types.Log{Address: addr}}},
+	}
+
+	_, err := bcStore.GetBlockBloom(block.HeaderHash)
+	assert.Equal(t, err, errors.ErrNotFound)
+
+	assert.Nil(t, bcStore.PutBlock(block, block.Header.Difficulty, true))
+	assert.Nil(t, bcStore.PutReceipts(block.HeaderHash, receipts))
+
+	bloom, err := bcStore.GetBlockBloom(block.HeaderHash)
+	assert.Equal(t, err, error(nil))
+	assert.Equal(t, bloom, types.ReceiptsBloom(receipts))
+	assert.Equal(t, bloom.TestAddress(addr), true)
+
+	// bloom deleted along with block
+	assert.Nil(t, bcStore.DeleteBlock(block.HeaderHash))
+	_, err = bcStore.GetBlockBloom(block.HeaderHash)
+	assert.Equal(t, err, errors.ErrNotFound)
+}
@@ -18,6 +18,12 @@ import (
 // NewEVMByDefaultConfig returns a new EVM. The returned EVM is not thread safe and should
 // only ever be used *once*.
 func NewEVMByDefaultConfig(tx *types.Transaction, statedb *StateDB, blockHeader *types.BlockHeader, bcStore store.BlockchainStore) *vm.EVM {
+	return NewEVM(tx, statedb, blockHeader, bcStore, vm.Config{})
+}
+
+// NewEVM returns a new EVM with the specified VM config, e.g. to trace the execution via
+// vm.Config.Tracer. The returned EVM is not thread safe and should only ever be used *once*.
+func NewEVM(tx *types.Transaction, statedb *StateDB, blockHeader *types.BlockHeader, bcStore store.BlockchainStore, vmConfig vm.Config) *vm.EVM {
 	evmContext := newEVMContext(tx, blockHeader, blockHeader.Creator, bcStore)
 	chainConfig := &params.ChainConfig{
 		ChainID:             big.NewInt(1),
@@ -31,9 +37,8 @@ func NewEVMByDefaultConfig(tx *types.Transaction, statedb *StateDB, blockHeader
 		ConstantinopleBlock: nil,
 		Ethash:              new(params.EthashConfig),
 	}
-	vmConfig := &vm.Config{}
 
-	return vm.NewEVM(*evmContext, statedb, chainConfig, *vmConfig)
+	return vm.NewEVM(*evmContext, statedb, chainConfig, vmConfig)
 }
 
 // NewEVMContext creates a new context for use in the EVM.
@@ -25,6 +25,7 @@ type Context struct {
 	Statedb     *state.Statedb
 	BlockHeader *types.BlockHeader
 	BcStore     store.BlockchainStore
+	VMConfig    *vm.Config // optional EVM config, e.g. to trace the execution
 }
 
 // Process the tx
@@ -175,7 +176,12 @@ func processEvmContract(ctx *Context, gas uint64) (*types.Receipt, error) {
 	}
 
 	statedb := &evm.StateDB{Statedb: ctx.Statedb}
-	e := evm.NewEVMByDefaultConfig(ctx.Tx, statedb, ctx.BlockHeader, ctx.BcStore)
+	var e *vm.EVM
+	if ctx.VMConfig != nil {
+		e = evm.NewEVM(ctx.Tx, statedb, ctx.BlockHeader, ctx.BcStore, *ctx.VMConfig)
+	} else {
+		e = evm.NewEVMByDefaultConfig(ctx.Tx, statedb, ctx.BlockHeader, ctx.BcStore)
+	}
 	caller := vm.AccountRef(ctx.Tx.Data.From)
 	var leftOverGas uint64
 
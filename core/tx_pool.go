@@ -6,8 +6,11 @@
 package core
 
 import (
+	"math/big"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/golang-lru"
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/common/errors"
 	"github.com/seeleteam/go-seele/core/state"
@@ -16,17 +19,42 @@ import (
 	"github.com/seeleteam/go-seele/log"
 )
 
-const transactionTimeoutDuration = 3 * time.Hour
+const (
+	transactionTimeoutDuration = 3 * time.Hour
+
+	// replacedCacheSize is the number of recently replaced txs remembered by tx pool.
+	replacedCacheSize = 4096
+)
+
+var (
+	errUnderpriced = errors.New("transaction underpriced")
+)
+
+// TransactionReplaced is the event fired when a tx in pool is replaced by another one with the same nonce.
+type TransactionReplaced struct {
+	Old *types.Transaction
+	New *types.Transaction
+}
 
 // TransactionPool is a thread-safe container for transactions received from the network or submitted locally.
 // A transaction will be removed from the pool once included in a blockchain or pending time too long (> transactionTimeoutDuration).
+// The transactions of local accounts are exempted from eviction, timeout and price limit.
 type TransactionPool struct {
 	*Pool
+	config   TransactionPoolConfig
+	locals   *accountSet // accounts that submitted txs locally or configured as local ones
+	journal  *poolJournal
+	replaced *lru.Cache // hash of recently replaced tx to the hash of its replacement
 }
 
 // NewTransactionPool creates and returns a transaction pool.
 func NewTransactionPool(config TransactionPoolConfig, chain blockchain) *TransactionPool {
 	log := log.GetLogger("txpool")
+	locals := newAccountSet()
+	for _, account := range config.Locals {
+		locals.add(account)
+	}
+
 	getObjectFromBlock := func(block *types.Block) []poolObject {
 		return txsToObjects(block.GetExcludeRewardTransactions())
 	}
@@ -37,15 +65,17 @@ func NewTransactionPool(config TransactionPoolConfig, chain blockchain) *Transac
 		txIndex, _ := chain.GetStore().GetTxIndex(item.GetHash())
 		nonce := state.GetNonce(item.FromAccount())
 		duration := nowTimestamp.Sub(item.timestamp)
+		// the txs of local accounts never timeout
+		timeout := duration > transactionTimeoutDuration && !locals.contains(item.FromAccount())
 
 		// Transactions have been processed or are too old need to delete
-		if txIndex != nil || item.Nonce() < nonce || duration > transactionTimeoutDuration {
+		if txIndex != nil || item.Nonce() < nonce || timeout {
 			if txIndex == nil {
 				if item.Nonce() < nonce {
 					log.Debug("remove tx %s because nonce too low, account %s, tx nonce %d, target nonce %d", item.GetHash().Hex(),
 						item.FromAccount().Hex(), item.Nonce(), nonce)
 					return true, false // the true stand for "not timeout"
-				} else if duration > transactionTimeoutDuration {
+				} else if timeout {
 					log.Debug("remove tx %s because not packed for more than three hours", item.GetHash().Hex())
 					return true, true
 				}
@@ -75,17 +105,67 @@ func NewTransactionPool(config TransactionPoolConfig, chain blockchain) *Transac
 	cachedTxs := NewCachedTxs(CachedCapacity)
 	cachedTxs.init(chain)
 
-	pool := NewPool(config.Capacity, chain, getObjectFromBlock, canRemove, log, objectValidation, afterAdd, cachedTxs)
+	pool := &TransactionPool{
+		Pool:   NewPool(config.Capacity, chain, getObjectFromBlock, canRemove, log, objectValidation, afterAdd, cachedTxs),
+		config: config,
+		locals: locals,
+	}
+
+	pool.setLocals(locals.contains)
 
-	return &TransactionPool{pool}
+	pool.replaced, _ = lru.New(replacedCacheSize)
+	pool.setAfterReplace(func(old, new poolObject) {
+		log.Debug("transaction %v replaced by %v", old.GetHash().Hex(), new.GetHash().Hex())
+		pool.replaced.Add(old.GetHash(), new.GetHash())
+
+		// fire event
+		event.TransactionReplacedEventManager.Fire(&TransactionReplaced{old.(*types.Transaction), new.(*types.Transaction)})
+	})
+
+	// txs with future nonce are queued until the nonce gap closed
+	pool.setLimits(poolLimits{
+		accountSlots: config.AccountSlots,
+		accountQueue: config.AccountQueue,
+		globalQueue:  config.GlobalQueue,
+		priceBump:    config.PriceBump,
+	})
+
+	if len(config.Journal) > 0 {
+		pool.journal = newPoolJournal(config.Journal, func() poolObject { return new(types.Transaction) }, log)
+
+		// the journaled txs are treated as local ones
+		add := func(obj poolObject) error { return pool.AddLocalTransaction(obj.(*types.Transaction)) }
+		if err := pool.journal.open(add, pool.journaledObjects, config.Rejournal); err != nil {
+			log.Warn("failed to open tx journal, %s", err)
+		}
+	}
+
+	return pool
 }
 
-// AddTransaction adds a single transaction into the pool if it is valid and returns nil.
+// AddTransaction adds a single transaction received from network into the pool if it is valid and returns nil.
 // Otherwise, return the error.
 func (pool *TransactionPool) AddTransaction(tx *types.Transaction) error {
+	return pool.addTransaction(tx, false, false)
+}
+
+// AddLocalTransaction adds a single transaction submitted locally into the pool if it is valid and returns nil.
+// Otherwise, return the error. Local transactions are persisted in journal if enabled.
+func (pool *TransactionPool) AddLocalTransaction(tx *types.Transaction) error {
+	return pool.addTransaction(tx, true, false)
+}
+
+// addTransaction adds the transaction into the pool, and the transaction must replace a pending
+// or queued one with the same nonce if replace is true.
+func (pool *TransactionPool) addTransaction(tx *types.Transaction, local, replace bool) error {
 	if tx == nil {
 		return nil
 	}
+
+	if !local && !pool.locals.contains(tx.Data.From) && tx.Data.GasPrice != nil && tx.Data.GasPrice.Cmp(new(big.Int).SetUint64(pool.config.PriceLimit)) < 0 {
+		return errUnderpriced
+	}
+
 	if pool.cachedTxs.has(tx.Hash) {
 		pool.cachedTxs.log.Debug("Txs %s already exist, blocked it", tx.Hash)
 		return errDuplicateTx
@@ -96,7 +176,89 @@ func (pool *TransactionPool) AddTransaction(tx *types.Transaction) error {
 
 	// be noted: soft forking reverseBCstore will directly use pool.addObjectArray which will call pool.addObject(tx)
 	// so cachedTxs check won't have any effect to reinject txs
-	return pool.addObject(tx)
+	add := pool.addObject
+	if replace {
+		add = pool.replaceObject
+	}
+
+	if err := add(tx); err != nil {
+		return err
+	}
+
+	// account becomes local only if its tx accepted, so that invalid txs never exempt the account.
+	if local {
+		pool.locals.add(tx.Data.From)
+	}
+
+	if pool.journal != nil && pool.isJournaled(tx) {
+		if err := pool.journal.insert(tx); err != nil && err != errNoActiveJournal {
+			pool.log.Warn("failed to journal tx %v, %s", tx.Hash.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// ReplaceTransaction replaces the pending or queued transaction with the same nonce by the specified
+// transaction submitted locally, which requires the gas price bumped enough.
+func (pool *TransactionPool) ReplaceTransaction(tx *types.Transaction) error {
+	return pool.addTransaction(tx, true, true)
+}
+
+// GetReplaceableTransaction returns the pending or queued transaction of the specified account and nonce,
+// which could be replaced by another one with higher gas price.
+func (pool *TransactionPool) GetReplaceableTransaction(account common.Address, nonce uint64) *types.Transaction {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	if poolTx := pool.getReplaceable(account, nonce); poolTx != nil {
+		return poolTx.poolObject.(*types.Transaction)
+	}
+
+	return nil
+}
+
+// ReplacementPrice returns the minimum gas price to replace a transaction of the specified gas price.
+func (pool *TransactionPool) ReplacementPrice(price *big.Int) *big.Int {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	return pool.replacementPrice(price)
+}
+
+// GetReplacement returns the hash of transaction that replaced the specified transaction recently.
+func (pool *TransactionPool) GetReplacement(txHash common.Hash) (common.Hash, bool) {
+	if v, ok := pool.replaced.Get(txHash); ok {
+		return v.(common.Hash), true
+	}
+
+	return common.EmptyHash, false
+}
+
+// isJournaled returns true if the tx should be persisted in journal.
+func (pool *TransactionPool) isJournaled(tx *types.Transaction) bool {
+	return pool.config.JournalRemotes || pool.locals.contains(tx.Data.From)
+}
+
+// journaledObjects returns the txs in pool that should be persisted in journal.
+func (pool *TransactionPool) journaledObjects() []poolObject {
+	var objects []poolObject
+	for _, obj := range append(pool.getObjects(true, true), pool.getQueuedObjects()...) {
+		if pool.isJournaled(obj.(*types.Transaction)) {
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects
+}
+
+// Stop stops the tx pool and closes the journal if enabled.
+func (pool *TransactionPool) Stop() {
+	if pool.journal != nil {
+		if err := pool.journal.close(); err != nil {
+			pool.log.Warn("failed to close tx journal, %s", err)
+		}
+	}
 }
 
 // GetTransaction returns a transaction if it is contained in the pool and nil otherwise.
@@ -131,6 +293,16 @@ func (pool *TransactionPool) GetPendingTxCount() int {
 	return pool.getObjectCount(false, true)
 }
 
+// GetQueuedTxCount returns the number of transactions that are not executable due to nonce gap.
+func (pool *TransactionPool) GetQueuedTxCount() int {
+	return pool.getQueuedCount()
+}
+
+// GetQueuedTransactions returns the transactions that are not executable due to nonce gap.
+func (pool *TransactionPool) GetQueuedTransactions() []*types.Transaction {
+	return poolObjectToTxs(pool.getQueuedObjects())
+}
+
 // GetTxCount returns the total number of transactions in the transaction pool.
 func (pool *TransactionPool) GetTxCount() int {
 	return pool.getObjectCount(true, true)
@@ -159,3 +331,28 @@ func txsToObjects(txs []*types.Transaction) []poolObject {
 
 	return objects
 }
+
+// accountSet is a thread-safe set of accounts.
+type accountSet struct {
+	mutex    sync.RWMutex
+	accounts map[common.Address]struct{}
+}
+
+func newAccountSet() *accountSet {
+	return &accountSet{accounts: make(map[common.Address]struct{})}
+}
+
+func (set *accountSet) add(account common.Address) {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	set.accounts[account] = struct{}{}
+}
+
+func (set *accountSet) contains(account common.Address) bool {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	_, ok := set.accounts[account]
+	return ok
+}
@@ -80,7 +80,7 @@ func Test_GetReinjectTransaction(t *testing.T) {
 	}
 
 	b1 := newTestBlock(bc, bc.genesisBlock.HeaderHash, 1, state.GetNonce(types.TestGenesisAccount.Addr), 4*types.TransactionPreSize)
-	bc.WriteBlock(b1)
+	bc.WriteBlock(b1, nil)
 
 	state, err = bc.GetCurrentState()
 	if err != nil {
@@ -88,7 +88,7 @@ func Test_GetReinjectTransaction(t *testing.T) {
 	}
 
 	b2 := newTestBlock(bc, bc.genesisBlock.HeaderHash, 1, state.GetNonce(types.TestGenesisAccount.Addr), 3*types.TransactionPreSize)
-	bc.WriteBlock(b2)
+	bc.WriteBlock(b2, nil)
 
 	reinject := pool.getReinjectObject(b1.HeaderHash, b2.HeaderHash)
 
@@ -316,3 +316,188 @@ func newTxs(t *testing.T, amount, price, nonce, number int64, chain *mockBlockch
 	}
 	return txs
 }
+
+func Test_TransactionPool_Queue(t *testing.T) {
+	pool, chain := newTestTransactionPool(DefaultTxPoolConfig())
+	defer chain.dispose()
+
+	fromPrivKey, fromAddress := randomAccount(t)
+	chain.addAccount(fromAddress, 1000000, 0)
+	txs := make([]*poolItem, 3)
+	for i := range txs {
+		txs[i] = newTestPoolEx(t, fromPrivKey, fromAddress, 10, uint64(i), 1)
+	}
+
+	// queued due to nonce gap
+	assert.Equal(t, pool.addObject(txs[2].poolObject), nil)
+	assert.Equal(t, pool.GetPendingTxCount(), 0)
+	assert.Equal(t, pool.GetQueuedTxCount(), 1)
+	assert.Equal(t, pool.addObject(newTestPoolEx(t, fromPrivKey, fromAddress, 10, 2, 1).poolObject), errObjectNonceUsed)
+
+	assert.Equal(t, pool.addObject(txs[0].poolObject), nil)
+	assert.Equal(t, pool.GetPendingTxCount(), 1)
+	assert.Equal(t, pool.GetQueuedTxCount(), 1)
+
+	// promoted once the gap closed
+	assert.Equal(t, pool.addObject(txs[1].poolObject), nil)
+	assert.Equal(t, pool.GetPendingTxCount(), 3)
+	assert.Equal(t, pool.GetQueuedTxCount(), 0)
+
+	// demoted once the gap opened, e.g. tx removed
+	pool.removeOject(txs[1].GetHash())
+	pool.reorganize(chain.statedb)
+	assert.Equal(t, pool.GetPendingTxCount(), 1)
+	assert.Equal(t, pool.GetQueuedTransactions(), []*types.Transaction{txs[2].poolObject.(*types.Transaction)})
+
+	// stale queued tx dropped once the nonce used
+	chain.statedb.SetNonce(fromAddress, 3)
+	pool.reorganize(chain.statedb)
+	assert.Equal(t, pool.GetQueuedTxCount(), 0)
+	assert.Nil(t, pool.hashToTxMap[txs[2].GetHash()])
+}
+
+func Test_TransactionPool_Queue_Limits(t *testing.T) {
+	config := DefaultTxPoolConfig()
+	config.AccountQueue = 2
+	config.GlobalQueue = 3
+	pool, chain := newTestTransactionPool(config)
+	defer chain.dispose()
+
+	privKey1, account1 := randomAccount(t)
+	chain.addAccount(account1, 1000000, 0)
+	privKey2, account2 := randomAccount(t)
+	chain.addAccount(account2, 1000000, 0)
+
+	// account queue limit
+	assert.Equal(t, pool.addObject(newTestPoolEx(t, privKey1, account1, 10, 2, 1).poolObject), nil)
+	assert.Equal(t, pool.addObject(newTestPoolEx(t, privKey1, account1, 10, 3, 1).poolObject), nil)
+	assert.Equal(t, pool.addObject(newTestPoolEx(t, privKey1, account1, 10, 4, 1).poolObject), errAccountQueueFull)
+
+	// global queue limit, evict the account that has the most queued txs
+	assert.Equal(t, pool.addObject(newTestPoolEx(t, privKey2, account2, 10, 5, 1).poolObject), nil)
+	assert.Equal(t, pool.addObject(newTestPoolEx(t, privKey2, account2, 10, 6, 1).poolObject), nil)
+	assert.Equal(t, pool.GetQueuedTxCount(), 3)
+	assert.Equal(t, pool.queue[account1].len(), 1)
+	assert.Nil(t, pool.getQueued(account1, 3))
+}
+
+func Test_TransactionPool_Full_AccountSlots(t *testing.T) {
+	config := DefaultTxPoolConfig()
+	config.Capacity = 4
+	config.AccountSlots = 2
+	pool, chain := newTestTransactionPool(config)
+	defer chain.dispose()
+
+	privKey1, account1 := randomAccount(t)
+	chain.addAccount(account1, 1000000, 0)
+	for i := uint64(0); i < 4; i++ {
+		assert.Equal(t, pool.addObject(newTestPoolEx(t, privKey1, account1, 10, i, 1).poolObject), nil)
+	}
+
+	// the account over slots quota cannot evict its own txs
+	assert.Equal(t, pool.addObject(newTestPoolEx(t, privKey1, account1, 10, 5, 1).poolObject), errObjectPoolFull)
+
+	// evict the tx with the highest nonce of account over slots quota
+	privKey2, account2 := randomAccount(t)
+	chain.addAccount(account2, 1000000, 0)
+	tx := newTestPoolEx(t, privKey2, account2, 10, 0, 1)
+	assert.Equal(t, pool.addObject(tx.poolObject), nil)
+	assert.Equal(t, pool.GetPendingTxCount(), 4)
+	assert.Nil(t, pool.pendingQueue.get(account1, 3))
+	assert.NotNil(t, pool.pendingQueue.get(account2, 0))
+}
+
+func Test_TransactionPool_Locals(t *testing.T) {
+	config := DefaultTxPoolConfig()
+	config.Capacity = 2
+	config.PriceLimit = 5
+	pool, chain := newTestTransactionPool(config)
+	defer chain.dispose()
+
+	localPrivKey, localAccount := randomAccount(t)
+	chain.addAccount(localAccount, 1000000, 0)
+	remotePrivKey, remoteAccount := randomAccount(t)
+	chain.addAccount(remoteAccount, 100000000, 0)
+
+	// price limit only applies to remote txs
+	remoteTx := newTestPoolEx(t, remotePrivKey, remoteAccount, 10, 0, 1).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.AddTransaction(remoteTx), errUnderpriced)
+
+	// rejected local tx does not make the account local
+	unfundedTx := newTestPoolEx(t, localPrivKey, localAccount, 100000000, 0, 1).poolObject.(*types.Transaction)
+	assert.Error(t, pool.AddLocalTransaction(unfundedTx))
+	assert.Equal(t, pool.isLocalAccount(localAccount), false)
+
+	localTx := newTestPoolEx(t, localPrivKey, localAccount, 10, 0, 1).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.AddLocalTransaction(localTx), nil)
+	assert.Equal(t, pool.isLocalAccount(localAccount), true)
+
+	// account becomes local once submitted tx locally
+	localTx2 := newTestPoolEx(t, localPrivKey, localAccount, 10, 1, 1).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.AddTransaction(localTx2), nil)
+
+	// local txs are not evicted by higher price
+	remoteTx = newTestPoolEx(t, remotePrivKey, remoteAccount, 10, 0, 100).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.AddTransaction(remoteTx), errObjectPoolFull)
+	assert.Equal(t, pool.GetPendingTxCount(), 2)
+
+	// local txs never timeout
+	for _, ptx := range pool.hashToTxMap {
+		ptx.timestamp = ptx.timestamp.Add(-transactionTimeoutDuration - time.Second)
+	}
+
+	pool.removeObjects()
+	assert.Equal(t, pool.GetPendingTxCount(), 2)
+}
+
+func Test_TransactionPool_Locals_Whitelist(t *testing.T) {
+	_, account := randomAccount(t)
+	config := DefaultTxPoolConfig()
+	config.Locals = []common.Address{account}
+	pool, chain := newTestTransactionPool(config)
+	defer chain.dispose()
+
+	assert.Equal(t, pool.isLocalAccount(account), true)
+	assert.Equal(t, pool.isLocalAccount(common.EmptyAddress), false)
+}
+
+func Test_TransactionPool_Replace(t *testing.T) {
+	config := DefaultTxPoolConfig()
+	config.PriceBump = 10
+	pool, chain := newTestTransactionPool(config)
+	defer chain.dispose()
+
+	privKey, account := randomAccount(t)
+	chain.addAccount(account, 1000000000, 0)
+
+	tx := newTestPoolEx(t, privKey, account, 10, 0, 100).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.AddTransaction(tx), nil)
+	assert.Equal(t, pool.GetReplaceableTransaction(account, 0), tx)
+	assert.Equal(t, pool.ReplacementPrice(big.NewInt(100)), big.NewInt(110))
+
+	// same price
+	samePriceTx := newTestPoolEx(t, privKey, account, 20, 0, 100).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.AddTransaction(samePriceTx), errObjectNonceUsed)
+
+	// price not bumped enough
+	underpricedTx := newTestPoolEx(t, privKey, account, 20, 0, 105).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.ReplaceTransaction(underpricedTx), errReplaceUnderpriced)
+
+	// replaced
+	newTx := newTestPoolEx(t, privKey, account, 20, 0, 110).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.ReplaceTransaction(newTx), nil)
+	assert.Equal(t, pool.GetPendingTxCount(), 1)
+	assert.Equal(t, pool.GetTransaction(tx.Hash) == nil, true)
+	assert.Equal(t, pool.GetTransaction(newTx.Hash), newTx)
+
+	replacement, found := pool.GetReplacement(tx.Hash)
+	assert.Equal(t, found, true)
+	assert.Equal(t, replacement, newTx.Hash)
+
+	_, found = pool.GetReplacement(newTx.Hash)
+	assert.Equal(t, found, false)
+
+	// nothing to replace
+	futureTx := newTestPoolEx(t, privKey, account, 10, 1, 200).poolObject.(*types.Transaction)
+	assert.Equal(t, pool.ReplaceTransaction(futureTx), errNoReplaceable)
+}
@@ -0,0 +1,96 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package types
+
+import (
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+)
+
+const (
+	// BloomByteLength is the number of bytes of log bloom.
+	BloomByteLength = 256
+
+	// bloomBitLength is the number of bits of log bloom.
+	bloomBitLength = 8 * BloomByteLength
+)
+
+// Bloom represents a 2048 bits bloom filter of the contract addresses and topics in logs.
+// Note, it is derived from receipts and not secured by consensus.
+type Bloom [BloomByteLength]byte
+
+// BytesToBloom converts the specified bytes to bloom. It panics if the length is invalid.
+func BytesToBloom(b []byte) Bloom {
+	if len(b) != BloomByteLength {
+		panic("invalid bloom length")
+	}
+
+	var bloom Bloom
+	copy(bloom[:], b)
+	return bloom
+}
+
+// bloomBits returns the 3 bits that the data mapped to in bloom.
+func bloomBits(data []byte) [3]uint {
+	hash := crypto.Keccak256(data)
+
+	var bits [3]uint
+	for i := range bits {
+		bits[i] = (uint(hash[2*i])<<8 | uint(hash[2*i+1])) % bloomBitLength
+	}
+
+	return bits
+}
+
+// Add adds the specified data into bloom.
+func (b *Bloom) Add(data []byte) {
+	for _, bit := range bloomBits(data) {
+		b[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test returns true if the specified data may be in bloom, otherwise false.
+func (b *Bloom) Test(data []byte) bool {
+	for _, bit := range bloomBits(data) {
+		if b[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TestAddress returns true if the specified contract address may be in bloom.
+func (b *Bloom) TestAddress(address common.Address) bool {
+	return b.Test(address.Bytes())
+}
+
+// TestTopic returns true if the specified log topic may be in bloom.
+func (b *Bloom) TestTopic(topic common.Hash) bool {
+	return b.Test(topic.Bytes())
+}
+
+// Bytes returns the bloom bytes.
+func (b Bloom) Bytes() []byte {
+	return b[:]
+}
+
+// ReceiptsBloom returns the bloom of contract addresses and topics of all logs in the specified receipts.
+func ReceiptsBloom(receipts []*Receipt) Bloom {
+	var bloom Bloom
+
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			bloom.Add(log.Address.Bytes())
+
+			for _, topic := range log.Topics {
+				bloom.Add(topic.Bytes())
+			}
+		}
+	}
+
+	return bloom
+}
@@ -0,0 +1,43 @@
+/**
+* @file
+* @copyright defined in go-seele/LICENSE
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bloom(t *testing.T) {
+	var bloom Bloom
+	assert.Equal(t, bloom.Test([]byte("data")), false)
+
+	bloom.Add([]byte("data"))
+	assert.Equal(t, bloom.Test([]byte("data")), true)
+	assert.Equal(t, bloom.Test([]byte("data2")), false)
+
+	assert.Equal(t, BytesToBloom(bloom.Bytes()), bloom)
+	assert.Panics(t, func() { BytesToBloom([]byte("invalid")) })
+}
+
+func Test_ReceiptsBloom(t *testing.T) {
+	addr := common.BytesToAddress([]byte("contract"))
+	topic1, topic2 := common.StringToHash("topic 1"), common.StringToHash("topic 2")
+
+	receipts := []*Receipt{
+		&Receipt{},
+		&Receipt{Logs: []*Log{&Log{Address: addr, Topics: []common.Hash{topic1}}}},
+	}
+
+	bloom := ReceiptsBloom(receipts)
+	assert.Equal(t, bloom.TestAddress(addr), true)
+	assert.Equal(t, bloom.TestTopic(topic1), true)
+	assert.Equal(t, bloom.TestTopic(topic2), false)
+	assert.Equal(t, bloom.TestAddress(common.BytesToAddress([]byte("contract 2"))), false)
+
+	assert.Equal(t, ReceiptsBloom(nil), Bloom{})
+}
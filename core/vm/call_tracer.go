@@ -0,0 +1,131 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package vm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/seeleteam/go-seele/common"
+)
+
+// CallFrame is a call frame of transaction execution, e.g. CALL, CREATE, DELEGATECALL,
+// STATICCALL or SELFDESTRUCT, including the internal call frames in Calls.
+type CallFrame struct {
+	Type    string         `json:"type"`
+	From    common.Address `json:"from"`
+	To      common.Address `json:"to"`
+	Value   *hexutil.Big   `json:"value,omitempty"`
+	Gas     hexutil.Uint64 `json:"gas"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Input   hexutil.Bytes  `json:"input"`
+	Output  hexutil.Bytes  `json:"output,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Calls   []*CallFrame   `json:"calls,omitempty"`
+}
+
+// CallTracer is an EVM tracer that captures the tree of call frames, so that the
+// internal value transfers are visible.
+type CallTracer struct {
+	callstack []*CallFrame // the root frame is at the bottom of call stack
+}
+
+// NewCallTracer returns a new call tracer.
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+func newCallFrame(typ OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) *CallFrame {
+	frame := &CallFrame{
+		Type:  typ.String(),
+		From:  from,
+		To:    to,
+		Gas:   hexutil.Uint64(gas),
+		Input: common.CopyBytes(input),
+	}
+
+	if value != nil {
+		frame.Value = (*hexutil.Big)(new(big.Int).Set(value))
+	}
+
+	return frame
+}
+
+func (frame *CallFrame) exit(output []byte, gasUsed uint64, err error) {
+	frame.GasUsed = hexutil.Uint64(gasUsed)
+	frame.Output = common.CopyBytes(output)
+
+	if err != nil {
+		frame.Error = err.Error()
+
+		// only reverted frames have the output
+		if err != errExecutionReverted {
+			frame.Output = nil
+		}
+	}
+}
+
+// CaptureStart implements the Tracer interface to create the root call frame.
+func (t *CallTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := CALL
+	if create {
+		typ = CREATE
+	}
+
+	t.callstack = []*CallFrame{newCallFrame(typ, from, to, input, gas, value)}
+
+	return nil
+}
+
+// CaptureState implements the Tracer interface, and opcodes are not traced.
+func (t *CallTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureFault implements the Tracer interface, and opcodes are not traced.
+func (t *CallTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnter implements the Tracer interface to push an internal call frame into call stack.
+func (t *CallTracer) CaptureEnter(typ OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.callstack = append(t.callstack, newCallFrame(typ, from, to, input, gas, value))
+}
+
+// CaptureExit implements the Tracer interface to pop the internal call frame from call stack,
+// and add it into the calls of its parent frame.
+func (t *CallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	size := len(t.callstack)
+	if size <= 1 {
+		return
+	}
+
+	frame := t.callstack[size-1]
+	frame.exit(output, gasUsed, err)
+
+	t.callstack = t.callstack[:size-1]
+	parent := t.callstack[size-2]
+	parent.Calls = append(parent.Calls, frame)
+}
+
+// CaptureEnd implements the Tracer interface to finalize the root call frame.
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, duration time.Duration, err error) error {
+	if len(t.callstack) > 0 {
+		t.callstack[0].exit(output, gasUsed, err)
+	}
+
+	return nil
+}
+
+// Result returns the root call frame, or nil if nothing captured, e.g. non-EVM transaction.
+func (t *CallTracer) Result() *CallFrame {
+	if len(t.callstack) == 0 {
+		return nil
+	}
+
+	return t.callstack[0]
+}
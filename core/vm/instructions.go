@@ -878,7 +878,13 @@ func opStop(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory
 
 func opSuicide(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
 	balance := interpreter.evm.StateDB.GetBalance(contract.Address())
-	interpreter.evm.StateDB.AddBalance(common.BigToAddress(stack.pop()), balance)
+	beneficiary := common.BigToAddress(stack.pop())
+	interpreter.evm.StateDB.AddBalance(beneficiary, balance)
+
+	if interpreter.cfg.Debug {
+		interpreter.evm.captureEnter(SELFDESTRUCT, contract.Address(), beneficiary, nil, 0, balance)
+		interpreter.cfg.Tracer.CaptureExit(nil, 0, nil)
+	}
 
 	interpreter.evm.StateDB.Suicide(contract.Address())
 	return nil, nil
@@ -17,6 +17,14 @@ type Database interface {
 	Delete(key []byte) error
 	DeleteSring(key string) error
 	NewBatch() Batch
+
+	// NewIterator returns an iterator over the key range [start, limit).
+	// A nil start means the first key and a nil limit means the last key.
+	NewIterator(start []byte, limit []byte) Iterator
+	// NewIteratorWithPrefix returns an iterator over the keys with the given prefix.
+	NewIteratorWithPrefix(prefix []byte) Iterator
+	// GetSnapshot returns a read-only view of the current database state.
+	GetSnapshot() (Snapshot, error)
 }
 
 // Batch is the interface of batch for database
@@ -26,3 +34,29 @@ type Batch interface {
 	Commit() error
 	Rollback()
 }
+
+// Iterator iterates over key/value pairs in ascending key order.
+// It is not positioned at any pair when created, so Next (or Last
+// for reverse order) must be called before reading Key and Value.
+// The returned key and value slices are only valid until the next move.
+type Iterator interface {
+	First() bool
+	Last() bool
+	Seek(key []byte) bool
+	Next() bool
+	Prev() bool
+	Key() []byte
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// Snapshot is a frozen view of the database, reads on it are consistent
+// regardless of subsequent writes. It must be released after use.
+type Snapshot interface {
+	Get(key []byte) ([]byte, error)
+	Has(key []byte) (ret bool, err error)
+	NewIterator(start []byte, limit []byte) Iterator
+	NewIteratorWithPrefix(prefix []byte) Iterator
+	Release()
+}
@@ -0,0 +1,62 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package leveldb
+
+import (
+	"github.com/seeleteam/go-seele/database"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Snapshot wraps the leveldb snapshot
+type Snapshot struct {
+	snapshot *leveldb.Snapshot
+}
+
+// NewIterator returns an iterator over the key range [start, limit).
+func (db *LevelDB) NewIterator(start []byte, limit []byte) database.Iterator {
+	return db.db.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+}
+
+// NewIteratorWithPrefix returns an iterator over the keys with the given prefix.
+func (db *LevelDB) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.db.NewIterator(util.BytesPrefix(prefix), nil)
+}
+
+// GetSnapshot returns a snapshot of the current db state.
+func (db *LevelDB) GetSnapshot() (database.Snapshot, error) {
+	snapshot, err := db.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{snapshot}, nil
+}
+
+// Get gets the value for the given key
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	return s.snapshot.Get(key, nil)
+}
+
+// Has returns true if the snapshot does contain the given key.
+func (s *Snapshot) Has(key []byte) (ret bool, err error) {
+	return s.snapshot.Has(key, nil)
+}
+
+// NewIterator returns an iterator over the key range [start, limit) of the snapshot.
+func (s *Snapshot) NewIterator(start []byte, limit []byte) database.Iterator {
+	return s.snapshot.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+}
+
+// NewIteratorWithPrefix returns an iterator over the keys with the given prefix of the snapshot.
+func (s *Snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.snapshot.NewIterator(util.BytesPrefix(prefix), nil)
+}
+
+// Release releases the snapshot
+func (s *Snapshot) Release() {
+	s.snapshot.Release()
+}
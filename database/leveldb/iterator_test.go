@@ -0,0 +1,91 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package leveldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareIteratorData(t *testing.T) (*LevelDB, func()) {
+	db, dispose := NewTestDatabase()
+	for _, k := range []string{"a1", "a2", "a3", "b1", "b2", "c1"} {
+		assert.Equal(t, db.PutString(k, "v"+k), nil)
+	}
+
+	return db.(*LevelDB), dispose
+}
+
+func Test_Iterator_Range(t *testing.T) {
+	db, dispose := prepareIteratorData(t)
+	defer dispose()
+
+	// forward
+	var keys []string
+	it := db.NewIterator([]byte("a2"), []byte("b2"))
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+		assert.Equal(t, string(it.Value()), "v"+string(it.Key()))
+	}
+	it.Release()
+	assert.Equal(t, it.Error(), nil)
+	assert.Equal(t, keys, []string{"a2", "a3", "b1"})
+
+	// reverse
+	keys = nil
+	it = db.NewIterator(nil, nil)
+	for ok := it.Last(); ok; ok = it.Prev() {
+		keys = append(keys, string(it.Key()))
+	}
+	it.Release()
+	assert.Equal(t, keys, []string{"c1", "b2", "b1", "a3", "a2", "a1"})
+}
+
+func Test_Iterator_Prefix(t *testing.T) {
+	db, dispose := prepareIteratorData(t)
+	defer dispose()
+
+	var keys []string
+	it := db.NewIteratorWithPrefix([]byte("b"))
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	it.Release()
+	assert.Equal(t, keys, []string{"b1", "b2"})
+
+	it = db.NewIteratorWithPrefix([]byte("d"))
+	assert.Equal(t, it.Next(), false)
+	it.Release()
+}
+
+func Test_Snapshot(t *testing.T) {
+	db, dispose := prepareIteratorData(t)
+	defer dispose()
+
+	snapshot, err := db.GetSnapshot()
+	assert.Equal(t, err, nil)
+	defer snapshot.Release()
+
+	db.PutString("b3", "vb3")
+	db.DeleteSring("b1")
+
+	value, err := snapshot.Get([]byte("b1"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(value), "vb1")
+
+	found, err := snapshot.Has([]byte("b3"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, found, false)
+
+	var keys []string
+	it := snapshot.NewIteratorWithPrefix([]byte("b"))
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	it.Release()
+	assert.Equal(t, keys, []string{"b1", "b2"})
+}
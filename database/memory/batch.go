@@ -0,0 +1,49 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package memory
+
+type batchOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// Batch implements batch for MemoryDB
+type Batch struct {
+	db  *MemoryDB
+	ops []batchOp
+}
+
+// Put sets the value for the given key
+func (b *Batch) Put(key []byte, value []byte) {
+	b.ops = append(b.ops, batchOp{copyBytes(key), copyBytes(value), false})
+}
+
+// Delete deletes the value for the given key.
+func (b *Batch) Delete(key []byte) {
+	b.ops = append(b.ops, batchOp{copyBytes(key), nil, true})
+}
+
+// Commit commits batch operation atomically.
+func (b *Batch) Commit() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	for _, op := range b.ops {
+		if op.delete {
+			delete(b.db.db, string(op.key))
+		} else {
+			b.db.db[string(op.key)] = op.value
+		}
+	}
+
+	return nil
+}
+
+// Rollback rollbacks batch operation.
+func (b *Batch) Rollback() {
+	b.ops = nil
+}
@@ -0,0 +1,56 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func Test_Batch_Commit(t *testing.T) {
+	db := NewMemoryDB()
+	batch := db.NewBatch()
+
+	batch.Put([]byte("1"), []byte("11"))
+	batch.Put([]byte("2"), []byte("22"))
+	batch.Put([]byte("3"), []byte("33"))
+	batch.Delete([]byte("2"))
+
+	// not found, just still not committed
+	_, err := db.GetString("1")
+	assert.Equal(t, err, leveldb.ErrNotFound)
+
+	err = batch.Commit()
+	assert.Equal(t, err, nil)
+
+	value, err := db.GetString("1")
+	assert.Equal(t, value, "11")
+	_, err = db.GetString("2")
+	assert.Equal(t, err, leveldb.ErrNotFound)
+	value, err = db.GetString("3")
+	assert.Equal(t, value, "33")
+}
+
+func Test_Batch_Rollback(t *testing.T) {
+	db := NewMemoryDB()
+	batch := db.NewBatch()
+
+	batch.Put([]byte("1"), []byte("11"))
+	batch.Commit()
+
+	batch.Put([]byte("1"), []byte("1111"))
+	batch.Delete([]byte("1"))
+	batch.Rollback()
+
+	err := batch.Commit()
+	assert.Equal(t, err, nil)
+
+	value, err := db.GetString("1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, value, "11")
+}
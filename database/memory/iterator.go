@@ -0,0 +1,171 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package memory
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/seeleteam/go-seele/database"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Iterator iterates over a sorted copy of the key/value pairs,
+// so that it is not affected by the subsequent writes.
+type Iterator struct {
+	keys   []string
+	values [][]byte
+	index  int // -1 or len(keys) means not positioned at any pair
+}
+
+// Snapshot is a frozen copy of MemoryDB
+type Snapshot struct {
+	db *MemoryDB
+}
+
+// NewIterator returns an iterator over the key range [start, limit).
+func (db *MemoryDB) NewIterator(start []byte, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	it := &Iterator{index: -1}
+	for key := range db.db {
+		if start != nil && bytes.Compare([]byte(key), start) < 0 {
+			continue
+		}
+
+		if limit != nil && bytes.Compare([]byte(key), limit) >= 0 {
+			continue
+		}
+
+		it.keys = append(it.keys, key)
+	}
+
+	sort.Strings(it.keys)
+
+	it.values = make([][]byte, len(it.keys))
+	for i, key := range it.keys {
+		it.values[i] = db.db[key]
+	}
+
+	return it
+}
+
+// NewIteratorWithPrefix returns an iterator over the keys with the given prefix.
+func (db *MemoryDB) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	r := util.BytesPrefix(prefix)
+	return db.NewIterator(r.Start, r.Limit)
+}
+
+// GetSnapshot returns a snapshot of the current db state.
+func (db *MemoryDB) GetSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	copied := &MemoryDB{
+		db: make(map[string][]byte, len(db.db)),
+	}
+
+	// values are never modified in place, so only the map is copied.
+	for k, v := range db.db {
+		copied.db[k] = v
+	}
+
+	return &Snapshot{copied}, nil
+}
+
+// First moves the iterator to the first key/value pair.
+func (it *Iterator) First() bool {
+	it.index = 0
+	return it.valid()
+}
+
+// Last moves the iterator to the last key/value pair.
+func (it *Iterator) Last() bool {
+	it.index = len(it.keys) - 1
+	return it.valid()
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater than or equal to the given key.
+func (it *Iterator) Seek(key []byte) bool {
+	it.index = sort.SearchStrings(it.keys, string(key))
+	return it.valid()
+}
+
+// Next moves the iterator to the next key/value pair.
+func (it *Iterator) Next() bool {
+	if it.index < len(it.keys) {
+		it.index++
+	}
+
+	return it.valid()
+}
+
+// Prev moves the iterator to the previous key/value pair.
+func (it *Iterator) Prev() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+
+	return it.valid()
+}
+
+// Key returns the key of the current key/value pair, or nil if done.
+func (it *Iterator) Key() []byte {
+	if !it.valid() {
+		return nil
+	}
+
+	return []byte(it.keys[it.index])
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (it *Iterator) Value() []byte {
+	if !it.valid() {
+		return nil
+	}
+
+	return it.values[it.index]
+}
+
+// Error returns any accumulated error, always nil for MemoryDB.
+func (it *Iterator) Error() error {
+	return nil
+}
+
+// Release releases the iterator.
+func (it *Iterator) Release() {
+	it.keys, it.values, it.index = nil, nil, -1
+}
+
+func (it *Iterator) valid() bool {
+	return it.index >= 0 && it.index < len(it.keys)
+}
+
+// Get gets the value for the given key
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	return s.db.Get(key)
+}
+
+// Has returns true if the snapshot does contain the given key.
+func (s *Snapshot) Has(key []byte) (ret bool, err error) {
+	return s.db.Has(key)
+}
+
+// NewIterator returns an iterator over the key range [start, limit) of the snapshot.
+func (s *Snapshot) NewIterator(start []byte, limit []byte) database.Iterator {
+	return s.db.NewIterator(start, limit)
+}
+
+// NewIteratorWithPrefix returns an iterator over the keys with the given prefix of the snapshot.
+func (s *Snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.NewIteratorWithPrefix(prefix)
+}
+
+// Release releases the snapshot
+func (s *Snapshot) Release() {
+	s.db = &MemoryDB{db: make(map[string][]byte)}
+}
@@ -0,0 +1,122 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package memory
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/seeleteam/go-seele/database"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+)
+
+var (
+	// ErrEmptyKey key is empty
+	ErrEmptyKey = errors.New("key could not be empty")
+
+	// errNotFound is the same error as leveldb so that callers could handle both databases in the same way.
+	errNotFound = leveldbErrors.ErrNotFound
+)
+
+// MemoryDB is an in-memory database that nothing is persisted to disk.
+type MemoryDB struct {
+	db   map[string][]byte
+	lock sync.RWMutex
+}
+
+// NewMemoryDB constructs and returns a MemoryDB instance
+func NewMemoryDB() database.Database {
+	return &MemoryDB{
+		db: make(map[string][]byte),
+	}
+}
+
+// Close is used to close the db when not used
+func (db *MemoryDB) Close() {}
+
+// GetString gets the value for the given key
+func (db *MemoryDB) GetString(key string) (string, error) {
+	value, err := db.Get([]byte(key))
+
+	return string(value), err
+}
+
+// Get gets the value for the given key
+func (db *MemoryDB) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if value, found := db.db[string(key)]; found {
+		return copyBytes(value), nil
+	}
+
+	return nil, errNotFound
+}
+
+// Put sets the value for the given key
+func (db *MemoryDB) Put(key []byte, value []byte) error {
+	if len(key) < 1 {
+		return ErrEmptyKey
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.db[string(key)] = copyBytes(value)
+
+	return nil
+}
+
+// PutString sets the value for the given key
+func (db *MemoryDB) PutString(key string, value string) error {
+	return db.Put([]byte(key), []byte(value))
+}
+
+// Has returns true if the DB does contain the given key.
+func (db *MemoryDB) Has(key []byte) (ret bool, err error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	_, found := db.db[string(key)]
+
+	return found, nil
+}
+
+// HasString returns true if the DB does contain the given key.
+func (db *MemoryDB) HasString(key string) (ret bool, err error) {
+	return db.Has([]byte(key))
+}
+
+// Delete deletes the value for the given key.
+func (db *MemoryDB) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	delete(db.db, string(key))
+
+	return nil
+}
+
+// DeleteSring deletes the value for the given key.
+func (db *MemoryDB) DeleteSring(key string) error {
+	return db.Delete([]byte(key))
+}
+
+// NewBatch constructs and returns a batch object
+func (db *MemoryDB) NewBatch() database.Batch {
+	return &Batch{db: db}
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	copied := make([]byte, len(b))
+	copy(copied, b)
+
+	return copied
+}
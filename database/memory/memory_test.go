@@ -0,0 +1,125 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func Test_MemoryDB_PutGet(t *testing.T) {
+	db := NewMemoryDB()
+	defer db.Close()
+
+	err := db.PutString("1", "2")
+	assert.Equal(t, err, nil)
+
+	value, err := db.GetString("1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, value, "2")
+
+	// update
+	db.PutString("1", "3")
+	value, err = db.GetString("1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, value, "3")
+
+	// not found
+	_, err = db.GetString("2")
+	assert.Equal(t, err, leveldb.ErrNotFound)
+
+	// Put empty key
+	err = db.PutString("", "2")
+	assert.Equal(t, err, ErrEmptyKey)
+}
+
+func Test_MemoryDB_HasDelete(t *testing.T) {
+	db := NewMemoryDB()
+	defer db.Close()
+
+	db.PutString("1", "1")
+	exist, err := db.HasString("1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, exist, true)
+
+	err = db.DeleteSring("1")
+	assert.Equal(t, err, nil)
+
+	exist, err = db.HasString("1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, exist, false)
+}
+
+func Test_MemoryDB_ValueCopied(t *testing.T) {
+	db := NewMemoryDB()
+
+	value := []byte("value")
+	db.Put([]byte("key"), value)
+	value[0] = 'V'
+
+	stored, _ := db.Get([]byte("key"))
+	assert.Equal(t, string(stored), "value")
+
+	stored[0] = 'V'
+	stored, _ = db.Get([]byte("key"))
+	assert.Equal(t, string(stored), "value")
+}
+
+func Test_MemoryDB_Iterator(t *testing.T) {
+	db := NewMemoryDB()
+	for _, k := range []string{"b1", "a2", "c1", "a1", "b2", "a3"} {
+		db.PutString(k, "v"+k)
+	}
+
+	// forward by range
+	var keys []string
+	it := db.NewIterator([]byte("a2"), []byte("b2"))
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+		assert.Equal(t, string(it.Value()), "v"+string(it.Key()))
+	}
+	it.Release()
+	assert.Equal(t, keys, []string{"a2", "a3", "b1"})
+
+	// reverse
+	keys = nil
+	it = db.NewIterator(nil, nil)
+	for ok := it.Last(); ok; ok = it.Prev() {
+		keys = append(keys, string(it.Key()))
+	}
+	it.Release()
+	assert.Equal(t, keys, []string{"c1", "b2", "b1", "a3", "a2", "a1"})
+
+	// prefix and seek
+	it = db.NewIteratorWithPrefix([]byte("a"))
+	assert.Equal(t, it.Seek([]byte("a15")), true)
+	assert.Equal(t, string(it.Key()), "a2")
+	assert.Equal(t, it.Next(), true)
+	assert.Equal(t, it.Next(), false)
+	assert.Equal(t, it.Key() == nil, true)
+	it.Release()
+}
+
+func Test_MemoryDB_Snapshot(t *testing.T) {
+	db := NewMemoryDB()
+	db.PutString("1", "1")
+
+	snapshot, err := db.GetSnapshot()
+	assert.Equal(t, err, nil)
+	defer snapshot.Release()
+
+	db.PutString("1", "11")
+	db.PutString("2", "2")
+
+	value, err := snapshot.Get([]byte("1"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(value), "1")
+
+	exist, _ := snapshot.Has([]byte("2"))
+	assert.Equal(t, exist, false)
+}
@@ -0,0 +1,221 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package migration
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/log"
+)
+
+var (
+	// keySchemaVersion is the key of schema version, which is prefixed with "_" to avoid conflict with data keys.
+	keySchemaVersion = []byte("_SchemaVersion")
+
+	// keyPrefixCheckpoint + version => checkpoint of the interrupted migration
+	keyPrefixCheckpoint = []byte("_SchemaMigration")
+
+	// ErrUnknownVersion is returned when the schema version of database is newer than the latest known version.
+	ErrUnknownVersion = errors.New("unknown database schema version")
+
+	// ErrMigrationRequired is returned when the schema version of database is older than the latest version.
+	ErrMigrationRequired = errors.New("database schema migration required")
+
+	migrationLog = log.GetLogger("migration")
+)
+
+// Migration upgrades the database layout in place from the previous version to Version.
+type Migration struct {
+	Version     uint
+	Description string
+
+	// Migrate upgrades the database layout. It could be nil if only the version changed.
+	// Migrate may be interrupted and run again, so it should save checkpoint in context
+	// to resume from the interrupted position, or be idempotent.
+	Migrate func(ctx *Context) error
+}
+
+// Context is the context of a running migration.
+type Context struct {
+	DB      database.Database
+	Version uint // target version of the running migration
+
+	schema string
+}
+
+// Checkpoint returns the checkpoint saved by the interrupted migration, or nil if none.
+func (ctx *Context) Checkpoint() ([]byte, error) {
+	key := checkpointKey(ctx.Version)
+
+	found, err := ctx.DB.Has(key)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return ctx.DB.Get(key)
+}
+
+// SetCheckpoint puts the checkpoint into batch, so that it is committed atomically with the migrated data.
+func (ctx *Context) SetCheckpoint(batch database.Batch, checkpoint []byte) {
+	batch.Put(checkpointKey(ctx.Version), checkpoint)
+}
+
+// Progress logs the progress of the running migration.
+func (ctx *Context) Progress(format string, args ...interface{}) {
+	migrationLog.Info("%v schema migration to version %v: %v", ctx.schema, ctx.Version, fmt.Sprintf(format, args...))
+}
+
+// Schema is the schema of a database with ordered migrations.
+type Schema struct {
+	name       string
+	migrations []*Migration
+}
+
+// NewSchema returns a schema with the specified migrations, whose versions
+// must be consecutive and start from 1. Otherwise, it panics.
+func NewSchema(name string, migrations ...*Migration) *Schema {
+	for i, m := range migrations {
+		if m.Version != uint(i+1) {
+			panic(fmt.Sprintf("invalid migration version %v of %v schema, expected %v", m.Version, name, i+1))
+		}
+	}
+
+	return &Schema{name, migrations}
+}
+
+// Name returns the schema name.
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// LatestVersion returns the latest schema version.
+func (s *Schema) LatestVersion() uint {
+	return uint(len(s.migrations))
+}
+
+// Version returns the schema version of the specified database. The version is 0
+// if the database was created before schema versioning, or the database is empty.
+func (s *Schema) Version(db database.Database) (uint, error) {
+	found, err := db.Has(keySchemaVersion)
+	if err != nil || !found {
+		return 0, err
+	}
+
+	value, err := db.Get(keySchemaVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid %v schema version %x", s.name, value)
+	}
+
+	return uint(binary.BigEndian.Uint64(value)), nil
+}
+
+// Check checks the schema version of the specified database, and returns error if
+// the version is unknown or the database requires migration. Empty database is
+// considered as the latest version.
+func (s *Schema) Check(db database.Database) error {
+	version, empty, err := s.version(db)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case version > s.LatestVersion():
+		return errors.NewStackedErrorf(ErrUnknownVersion, "%v schema version %v, latest version %v", s.name, version, s.LatestVersion())
+	case version < s.LatestVersion() && !empty:
+		return errors.NewStackedErrorf(ErrMigrationRequired, "%v schema version %v, latest version %v", s.name, version, s.LatestVersion())
+	default:
+		return nil
+	}
+}
+
+// Migrate upgrades the specified database to the latest version, and returns the number of
+// migrations applied. Empty database is marked as the latest version without any migration.
+// The version is updated after each migration, so that an interrupted upgrade could resume
+// from the last succeeded migration.
+func (s *Schema) Migrate(db database.Database) (int, error) {
+	version, empty, err := s.version(db)
+	if err != nil {
+		return 0, err
+	}
+
+	if version > s.LatestVersion() {
+		return 0, errors.NewStackedErrorf(ErrUnknownVersion, "%v schema version %v, latest version %v", s.name, version, s.LatestVersion())
+	}
+
+	if empty {
+		return 0, s.putVersion(db, s.LatestVersion())
+	}
+
+	applied := 0
+	for _, m := range s.migrations[version:] {
+		migrationLog.Info("%v schema migration from version %v to %v started: %v", s.name, m.Version-1, m.Version, m.Description)
+		start := time.Now()
+
+		if m.Migrate != nil {
+			if err = m.Migrate(&Context{db, m.Version, s.name}); err != nil {
+				return applied, errors.NewStackedErrorf(err, "failed to migrate %v schema to version %v", s.name, m.Version)
+			}
+		}
+
+		if err = s.putVersion(db, m.Version); err != nil {
+			return applied, err
+		}
+
+		applied++
+		migrationLog.Info("%v schema migration to version %v completed, elapsed: %v", s.name, m.Version, time.Since(start))
+	}
+
+	return applied, nil
+}
+
+// version returns the schema version, and whether the database is empty without any data.
+func (s *Schema) version(db database.Database) (uint, bool, error) {
+	version, err := s.Version(db)
+	if err != nil || version > 0 {
+		return version, false, err
+	}
+
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	empty := !it.Next()
+
+	return 0, empty, it.Error()
+}
+
+// putVersion writes the version and removes the checkpoint of the migration if any.
+func (s *Schema) putVersion(db database.Database, version uint) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(version))
+
+	batch := db.NewBatch()
+	batch.Put(keySchemaVersion, value)
+	if version > 0 {
+		batch.Delete(checkpointKey(version))
+	}
+
+	if err := batch.Commit(); err != nil {
+		return errors.NewStackedErrorf(err, "failed to put %v schema version %v", s.name, version)
+	}
+
+	return nil
+}
+
+func checkpointKey(version uint) []byte {
+	key := make([]byte, len(keyPrefixCheckpoint)+8)
+	copy(key, keyPrefixCheckpoint)
+	binary.BigEndian.PutUint64(key[len(keyPrefixCheckpoint):], uint64(version))
+
+	return key
+}
@@ -0,0 +1,163 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package migration
+
+import (
+	"errors"
+	"testing"
+
+	commonErrors "github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/database/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSchema(applied *[]uint) *Schema {
+	migrate := func(ctx *Context) error {
+		*applied = append(*applied, ctx.Version)
+		return nil
+	}
+
+	return NewSchema("test",
+		&Migration{Version: 1, Description: "v1"},
+		&Migration{Version: 2, Description: "v2", Migrate: migrate},
+		&Migration{Version: 3, Description: "v3", Migrate: migrate},
+	)
+}
+
+func Test_Schema_NewSchema(t *testing.T) {
+	assert.Equal(t, NewSchema("empty").LatestVersion(), uint(0))
+
+	assert.Panics(t, func() { NewSchema("test", &Migration{Version: 2}) })
+	assert.Panics(t, func() { NewSchema("test", &Migration{Version: 1}, &Migration{Version: 1}) })
+}
+
+func Test_Schema_EmptyDB(t *testing.T) {
+	var applied []uint
+	schema := newTestSchema(&applied)
+	db := memory.NewMemoryDB()
+
+	assert.Equal(t, schema.Check(db), nil)
+
+	count, err := schema.Migrate(db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, count, 0)
+	assert.Equal(t, len(applied), 0)
+
+	version, err := schema.Version(db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, version, uint(3))
+}
+
+func Test_Schema_LegacyDB(t *testing.T) {
+	var applied []uint
+	schema := newTestSchema(&applied)
+	db := memory.NewMemoryDB()
+	db.PutString("key", "value")
+
+	version, err := schema.Version(db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, version, uint(0))
+	assert.Equal(t, commonErrors.IsOrContains(schema.Check(db), ErrMigrationRequired), true)
+
+	count, err := schema.Migrate(db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, count, 3)
+	assert.Equal(t, applied, []uint{2, 3})
+	assert.Equal(t, schema.Check(db), nil)
+
+	// nothing to migrate
+	count, err = schema.Migrate(db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, count, 0)
+	assert.Equal(t, applied, []uint{2, 3})
+}
+
+func Test_Schema_UnknownVersion(t *testing.T) {
+	var applied []uint
+	db := memory.NewMemoryDB()
+	_, err := newTestSchema(&applied).Migrate(db)
+	assert.Equal(t, err, nil)
+
+	older := NewSchema("test", &Migration{Version: 1})
+	assert.Equal(t, commonErrors.IsOrContains(older.Check(db), ErrUnknownVersion), true)
+
+	_, err = older.Migrate(db)
+	assert.Equal(t, commonErrors.IsOrContains(err, ErrUnknownVersion), true)
+}
+
+func Test_Schema_Resume(t *testing.T) {
+	db := memory.NewMemoryDB()
+	for _, k := range []string{"a", "b", "c", "d"} {
+		db.PutString(k, k)
+	}
+
+	errInterrupted := errors.New("interrupted")
+	interrupt := true
+	var migrated []string
+
+	// migrates keys one by one, and interrupted after the 2nd key.
+	schema := NewSchema("test",
+		&Migration{Version: 1},
+		&Migration{Version: 2, Migrate: func(ctx *Context) error {
+			checkpoint, err := ctx.Checkpoint()
+			if err != nil {
+				return err
+			}
+
+			for _, k := range []string{"a", "b", "c", "d"} {
+				if checkpoint != nil && k <= string(checkpoint) {
+					continue
+				}
+
+				if interrupt && k == "c" {
+					return errInterrupted
+				}
+
+				batch := ctx.DB.NewBatch()
+				batch.Put([]byte(k), []byte(k+k))
+				ctx.SetCheckpoint(batch, []byte(k))
+				if err = batch.Commit(); err != nil {
+					return err
+				}
+
+				migrated = append(migrated, k)
+				ctx.Progress("migrated key %v", k)
+			}
+
+			return nil
+		}},
+	)
+
+	count, err := schema.Migrate(db)
+	assert.Equal(t, commonErrors.IsOrContains(err, errInterrupted), true)
+	assert.Equal(t, count, 1)
+	assertVersion(t, schema, db, 1)
+
+	interrupt = false
+	count, err = schema.Migrate(db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, count, 1)
+	assertVersion(t, schema, db, 2)
+	assert.Equal(t, migrated, []string{"a", "b", "c", "d"})
+
+	for _, k := range []string{"a", "b", "c", "d"} {
+		value, err := db.GetString(k)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, value, k+k)
+	}
+
+	// checkpoint removed
+	found, err := db.Has(checkpointKey(2))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, found, false)
+}
+
+func assertVersion(t *testing.T, schema *Schema, db database.Database, expected uint) {
+	version, err := schema.Version(db)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, version, expected)
+}
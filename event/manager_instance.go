@@ -21,6 +21,9 @@ var BlockMinedEventManager = NewEventManager()
 // TransactionInsertedEventManager represents the event that a new transaction is inserted into txpool
 var TransactionInsertedEventManager = NewEventManager()
 
+// TransactionReplacedEventManager represents the event that a transaction in txpool is replaced by another one with the same nonce
+var TransactionReplacedEventManager = NewEventManager()
+
 // ChainHeaderChangedEventMananger represents the event that chain header is changed
 var ChainHeaderChangedEventMananger = NewEventManager()
 
@@ -14,7 +14,6 @@ import (
 	"github.com/seeleteam/go-seele/core"
 	"github.com/seeleteam/go-seele/core/store"
 	"github.com/seeleteam/go-seele/database"
-	"github.com/seeleteam/go-seele/database/leveldb"
 	"github.com/seeleteam/go-seele/log"
 	"github.com/seeleteam/go-seele/node"
 	"github.com/seeleteam/go-seele/p2p"
@@ -51,7 +50,7 @@ func NewServiceClient(ctx context.Context, conf *node.Config, log *log.SeeleLog,
 	// Initialize blockchain DB.
 	chainDBPath := filepath.Join(serviceContext.DataDir, dbFolder)
 	log.Info("NewServiceClient BlockChain datadir is %s", chainDBPath)
-	s.lightDB, err = leveldb.NewLevelDB(chainDBPath)
+	s.lightDB, err = conf.BasicConfig.OpenDatabase(chainDBPath)
 	if err != nil {
 		log.Error("NewServiceClient Create lightDB err. %s", err)
 		return nil, err
@@ -62,6 +62,11 @@ func newTxPool(chain BlockChain, odrBackend *odrBackend, headerChangedEventManag
 	return pool
 }
 
+// AddLocalTransaction is the same as AddTransaction, since the light node has no tx journal.
+func (pool *txPool) AddLocalTransaction(tx *types.Transaction) error {
+	return pool.AddTransaction(tx)
+}
+
 // AddTransaction sends the specified tx to remote peer via odr backend.
 func (pool *txPool) AddTransaction(tx *types.Transaction) error {
 	if tx == nil {
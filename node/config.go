@@ -10,11 +10,18 @@ import (
 
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/database"
+	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/seeleteam/go-seele/database/memory"
 	"github.com/seeleteam/go-seele/log/comm"
 	"github.com/seeleteam/go-seele/metrics"
 	"github.com/seeleteam/go-seele/p2p"
 )
 
+// MemoryDataDirMode is the data dir mode that keeps all databases in memory,
+// which is used by throwaway nodes that should not touch the disk.
+const MemoryDataDirMode = "memory"
+
 // Config is the Configuration of node
 type Config struct {
 	//Config is the Configuration of log
@@ -58,6 +65,15 @@ type BasicConfig struct {
 	// The file system path of the node, used to store data
 	DataDir string `json:"dataDir"`
 
+	// The storage mode of the data dir, empty to store on disk or "memory"
+	DataDirMode string `json:"dataDirMode"`
+
+	// The garbage collection mode of state trie, "archive" (default) or "prune"
+	StateGCMode string `json:"stateGCMode"`
+
+	// The number of confirmed block states retained in prune mode
+	StateRetained uint64 `json:"stateRetained"`
+
 	// The file system path of the temporary dataset, used for spow
 	DataSetDir string `json:"dataSetDir"`
 
@@ -72,6 +88,59 @@ type BasicConfig struct {
 
 	// MinerAlgorithm miner algorithm
 	MinerAlgorithm string `json:"algorithm"`
+
+	// AccountHistory indicates whether to maintain the per-account tx/debt history index.
+	// Note, the index is not backfilled for the blocks written before it enabled, so the
+	// account history could only be queried from the height that the index started from.
+	AccountHistory bool `json:"accountHistory"`
+
+	// NoTxJournal disables persisting the local txs and in-flight debts of pools across restarts
+	NoTxJournal bool `json:"noTxJournal"`
+
+	// TxJournalRemotes indicates whether to persist the txs received from network as well
+	TxJournalRemotes bool `json:"txJournalRemotes"`
+
+	// TxLocals is the accounts whose txs are treated as local ones, which are exempted from eviction and timeout in tx pool
+	TxLocals []common.Address `json:"txLocals"`
+
+	// TxPriceLimit is the minimum gas price in Fan of the txs from non-local accounts, 1 if 0
+	TxPriceLimit uint64 `json:"txPriceLimit"`
+
+	// TxPriceBump is the minimum gas price bump percentage to replace a tx with the same nonce in tx pool, 10 if 0
+	TxPriceBump uint64 `json:"txPriceBump"`
+
+	// GasPriceBlocks is the number of recent blocks sampled to suggest gas price, 20 if 0
+	GasPriceBlocks int `json:"gasPriceBlocks"`
+
+	// GasPricePercentile is the percentile of sampled gas prices to suggest, 60 if 0
+	GasPricePercentile int `json:"gasPricePercentile"`
+
+	// EthChainID is the chain id of Ethereum-compatible RPC namespaces eth/net/web3, which are disabled if 0
+	EthChainID uint64 `json:"ethChainID"`
+}
+
+// IsMemoryMode returns true if all databases are kept in memory.
+func (c *BasicConfig) IsMemoryMode() bool {
+	return c.DataDirMode == MemoryDataDirMode
+}
+
+// OpenDatabase opens the leveldb database under the given path,
+// or creates an in-memory database if in memory mode.
+func (c *BasicConfig) OpenDatabase(path string) (database.Database, error) {
+	if c.IsMemoryMode() {
+		return memory.NewMemoryDB(), nil
+	}
+
+	return leveldb.NewLevelDB(path)
+}
+
+// GetStateRetained returns the number of confirmed block states retained in prune mode.
+func (c *BasicConfig) GetStateRetained() uint64 {
+	if c.StateRetained == 0 {
+		return common.DefaultStateRetained
+	}
+
+	return c.StateRetained
 }
 
 // HTTPServer config for http server
@@ -12,8 +12,13 @@ import (
 	"runtime"
 	"runtime/pprof"
 
+	ethHexutil "github.com/ethereum/go-ethereum/common/hexutil"
+	api2 "github.com/seeleteam/go-seele/api"
 	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/state"
 	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/core/vm"
 )
 
 // PrivateDebugAPI provides an API to access full node-related information for debug.
@@ -36,16 +41,8 @@ func (api *PrivateDebugAPI) PrintBlock(height int64) (*types.Block, error) {
 	return block, nil
 }
 
-// TpsInfo tps detail info
-type TpsInfo struct {
-	StartHeight uint64
-	EndHeight   uint64
-	Count       uint64
-	Duration    uint64
-}
-
 // GetTPS get tps info
-func (api *PrivateDebugAPI) GetTPS() (*TpsInfo, error) {
+func (api *PrivateDebugAPI) GetTPS() (*api2.TpsInfo, error) {
 	chain := api.s.BlockChain()
 	block := chain.CurrentBlock()
 	timeInterval := uint64(150)
@@ -89,7 +86,7 @@ func (api *PrivateDebugAPI) GetTPS() (*TpsInfo, error) {
 		}
 	}
 
-	return &TpsInfo{
+	return &api2.TpsInfo{
 		StartHeight: endHeight,
 		EndHeight:   uint64(startHeight),
 		Count:       count,
@@ -116,3 +113,232 @@ func (api *PrivateDebugAPI) DumpHeap(fileName string, gcBeforeDump bool) (string
 	return flie, pprof.WriteHeapProfile(f)
 }
 
+// maxDumpStateAccounts is the maximum number of accounts in a page of state dump.
+const maxDumpStateAccounts = 1000
+
+var errDumpPageFull = errors.New("dump page full")
+
+// DumpState returns a page of accounts of the state at the specified block height in the order of
+// address hash, starting from the specified address hash, and the next page starts from the next
+// address hash in result. When height is -1 the chain head is used. At most 1000 accounts returned,
+// which is also the default limit if not positive.
+func (api *PrivateDebugAPI) DumpState(height int64, start common.Hash, limit int) (*api2.StateDump, error) {
+	block, err := getBlock(api.s.chain, height)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxDumpStateAccounts {
+		limit = maxDumpStateAccounts
+	}
+
+	result := &api2.StateDump{Accounts: make([]*state.DumpAccount, 0)}
+	err = state.DumpStateFrom(block.Header.StateHash, api.s.accountStateDB, start, func(account *state.DumpAccount) error {
+		if len(result.Accounts) == limit {
+			result.Next = &account.AddressHash
+			return errDumpPageFull
+		}
+
+		result.Accounts = append(result.Accounts, account)
+		return nil
+	})
+
+	if err != nil && err != errDumpPageFull {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// callTracer is the name of tracer that captures the tree of call frames.
+const callTracer = "callTracer"
+
+// TxTraceResult is the trace result of a transaction in block.
+type TxTraceResult struct {
+	TxHash common.Hash `json:"txHash"`
+	Result interface{} `json:"result"`
+}
+
+// txTracer traces the execution of a transaction, and returns the result.
+type txTracer interface {
+	vm.Tracer
+	result(tx *types.Transaction, receipt *types.Receipt) interface{}
+}
+
+type structTracer struct {
+	*vm.StructLogger
+}
+
+func (t structTracer) result(tx *types.Transaction, receipt *types.Receipt) interface{} {
+	return &api2.ExecutionResult{
+		Gas:         receipt.UsedGas,
+		Failed:      receipt.Failed,
+		ReturnValue: fmt.Sprintf("%x", t.Output()),
+		StructLogs:  formatStructLogs(t.StructLogs()),
+	}
+}
+
+type callFrameTracer struct {
+	*vm.CallTracer
+}
+
+func (t callFrameTracer) result(tx *types.Transaction, receipt *types.Receipt) interface{} {
+	frame := t.Result()
+	if frame == nil {
+		// tx not executed in EVM, e.g. system contract or cross shard tx.
+		frame = &vm.CallFrame{
+			Type:  vm.CALL.String(),
+			From:  tx.Data.From,
+			To:    tx.Data.To,
+			Value: (*ethHexutil.Big)(tx.Data.Amount),
+			Input: ethHexutil.Bytes(tx.Data.Payload),
+		}
+
+		if receipt.Failed {
+			frame.Error = string(receipt.Result)
+		} else {
+			frame.Output = ethHexutil.Bytes(receipt.Result)
+		}
+	}
+
+	// the root frame includes the intrinsic gas and refund.
+	frame.Gas = ethHexutil.Uint64(tx.Data.GasLimit)
+	frame.GasUsed = ethHexutil.Uint64(receipt.UsedGas)
+
+	return frame
+}
+
+// newTxTracer creates a tracer of the specified config.
+func newTxTracer(config *api2.TraceConfig) (txTracer, error) {
+	switch config.Tracer {
+	case "":
+		return structTracer{vm.NewStructLogger(&vm.LogConfig{
+			DisableMemory:  config.DisableMemory,
+			DisableStack:   config.DisableStack,
+			DisableStorage: config.DisableStorage,
+			Limit:          config.Limit,
+		})}, nil
+	case callTracer:
+		return callFrameTracer{vm.NewCallTracer()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tracer %v", config.Tracer)
+	}
+}
+
+// TraceTransaction re-executes the block that contains the specified tx on the state of its parent
+// block until the tx, and returns the traces of the tx, which are per-opcode traces by default, or the
+// tree of call frames if tracer is "callTracer" in config. Memory, stack and storage capture of
+// per-opcode traces could be disabled in config, which is optional.
+func (api *PrivateDebugAPI) TraceTransaction(txHash common.Hash, config *api2.TraceConfig) (interface{}, error) {
+	if config == nil {
+		config = &api2.TraceConfig{}
+	}
+
+	tracer, err := newTxTracer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	bcStore := api.s.chain.GetStore()
+	txIndex, err := bcStore.GetTxIndex(txHash)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to get tx index by hash %v", txHash.Hex())
+	}
+
+	if txIndex.Index == 0 {
+		return nil, errors.New("reward tx cannot be traced")
+	}
+
+	block, err := bcStore.GetBlock(txIndex.BlockHash)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to get block by hash %v", txIndex.BlockHash.Hex())
+	}
+
+	txCount := int(txIndex.Index) + 1
+	_, receipts, err := api.s.chain.ReplayBlock(block, txCount, func(i int) *vm.Config {
+		if i != int(txIndex.Index) {
+			return nil
+		}
+
+		return &vm.Config{Debug: true, Tracer: tracer}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tracer.result(block.Transactions[txIndex.Index], receipts[txIndex.Index]), nil
+}
+
+// TraceBlock re-executes the block of the specified height on the state of its parent block, and
+// returns the traces of all txs except the reward tx. When height is -1 the chain head is used.
+// The config is the same as TraceTransaction, which is optional.
+func (api *PrivateDebugAPI) TraceBlock(height int64, config *api2.TraceConfig) ([]*TxTraceResult, error) {
+	if config == nil {
+		config = &api2.TraceConfig{}
+	}
+
+	block, err := getBlock(api.s.chain, height)
+	if err != nil {
+		return nil, err
+	}
+
+	tracers := make([]txTracer, len(block.Transactions))
+	for i := 1; i < len(tracers); i++ {
+		if tracers[i], err = newTxTracer(config); err != nil {
+			return nil, err
+		}
+	}
+
+	_, receipts, err := api.s.chain.ReplayBlock(block, -1, func(i int) *vm.Config {
+		return &vm.Config{Debug: true, Tracer: tracers[i]}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*TxTraceResult, 0, len(block.Transactions))
+	for i, tx := range block.Transactions[1:] {
+		results = append(results, &TxTraceResult{tx.Hash, tracers[i+1].result(tx, receipts[i+1])})
+	}
+
+	return results, nil
+}
+
+// formatStructLogs formats the opcode traces in 32 bytes hex format.
+func formatStructLogs(logs []vm.StructLog) []api2.StructLogRes {
+	formatted := make([]api2.StructLogRes, len(logs))
+	for i, log := range logs {
+		formatted[i] = api2.StructLogRes{
+			Pc:      log.Pc,
+			Op:      log.Op.String(),
+			Gas:     log.Gas,
+			GasCost: log.GasCost,
+			Depth:   log.Depth,
+			Error:   log.ErrorString(),
+		}
+
+		if log.Stack != nil {
+			formatted[i].Stack = make([]string, len(log.Stack))
+			for j, item := range log.Stack {
+				formatted[i].Stack[j] = fmt.Sprintf("%x", common.LeftPadBytes(item.Bytes(), 32))
+			}
+		}
+
+		if log.Memory != nil {
+			for j := 0; j+32 <= len(log.Memory); j += 32 {
+				formatted[i].Memory = append(formatted[i].Memory, fmt.Sprintf("%x", log.Memory[j:j+32]))
+			}
+		}
+
+		if log.Storage != nil {
+			formatted[i].Storage = make(map[string]string, len(log.Storage))
+			for key, value := range log.Storage {
+				formatted[i].Storage[fmt.Sprintf("%x", key)] = fmt.Sprintf("%x", value)
+			}
+		}
+	}
+
+	return formatted
+}
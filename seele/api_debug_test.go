@@ -0,0 +1,218 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/txs"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/core/vm"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// putTestBlock commits the statedb and puts a new HEAD block with the specified regular txs.
+func putTestBlock(t *testing.T, api *PublicSeeleAPI, statedb *state.Statedb, regularTxs ...*types.Transaction) *types.Block {
+	bcStore := api.s.chain.GetStore()
+	headHash, err := bcStore.GetHeadBlockHash()
+	assert.Equal(t, err, nil)
+	parent, err := bcStore.GetBlock(headHash)
+	assert.Equal(t, err, nil)
+
+	header := parent.Header.Clone()
+	header.Height++
+	header.PreviousBlockHash = parent.HeaderHash
+	header.CreateTimestamp = big.NewInt(int64(header.Height))
+
+	batch := api.s.accountStateDB.NewBatch()
+	header.StateHash, _ = statedb.Commit(batch)
+	assert.Nil(t, batch.Commit())
+
+	rewardTx, err := txs.NewRewardTx(header.Creator, big.NewInt(1), header.Height)
+	assert.Equal(t, err, nil)
+
+	block := &types.Block{
+		HeaderHash:   header.Hash(),
+		Header:       header,
+		Transactions: append([]*types.Transaction{rewardTx}, regularTxs...),
+	}
+	assert.Nil(t, bcStore.PutBlock(block, big.NewInt(int64(header.Height)), true))
+
+	return block
+}
+
+func Test_PrivateDebugAPI_TraceTransaction(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".TraceTransaction")
+	seeleAPI := newTestAPI(t, dbPath)
+	defer func() {
+		seeleAPI.s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	// block 1 funds the sender, and block 2 creates contract/solidity/simple_storage.sol contract
+	statedb, _ := seeleAPI.s.chain.GetCurrentState()
+	from := getFromAddress(statedb)
+	putTestBlock(t, seeleAPI, statedb)
+
+	bytecode, _ := hexutil.HexToBytes("0x608060405234801561001057600080fd5b50600560008190555060df806100276000396000f3006080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806360fe47b114604e5780636d4ce63c146078575b600080fd5b348015605957600080fd5b5060766004803603810190808035906020019092919050505060a0565b005b348015608357600080fd5b50608a60aa565b6040518082815260200191505060405180910390f35b8060008190555050565b600080549050905600a165627a7a723058207f6dc43a0d648e9f5a0cad5071cde46657de72eb87ab4cded53a7f1090f51e6d0029")
+	createContractTx, _ := types.NewContractTransaction(from, big.NewInt(0), big.NewInt(1), 500000, 0, bytecode)
+	block := putTestBlock(t, seeleAPI, statedb, createContractTx)
+
+	api := NewPrivateDebugAPI(seeleAPI.s)
+	trace, err := api.TraceTransaction(createContractTx.Hash, nil)
+	assert.Equal(t, err, nil)
+	result := trace.(*api2.ExecutionResult)
+	assert.Equal(t, result.Failed, false)
+	assert.Equal(t, result.Gas > 0, true)
+	assert.Equal(t, result.ReturnValue, "6080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806360fe47b114604e5780636d4ce63c146078575b600080fd5b348015605957600080fd5b5060766004803603810190808035906020019092919050505060a0565b005b348015608357600080fd5b50608a60aa565b6040518082815260200191505060405180910390f35b8060008190555050565b600080549050905600a165627a7a723058207f6dc43a0d648e9f5a0cad5071cde46657de72eb87ab4cded53a7f1090f51e6d0029")
+	assert.Equal(t, result.StructLogs[0].Op, "PUSH1")
+	assert.Equal(t, result.StructLogs[0].Depth, 1)
+	assert.Equal(t, len(result.StructLogs[0].Stack), 0)
+	assert.Equal(t, result.StructLogs[len(result.StructLogs)-1].Op, "RETURN")
+
+	// SSTORE 5 into slot 0 in constructor
+	var sstore *api2.StructLogRes
+	for i, log := range result.StructLogs {
+		if log.Op == "SSTORE" {
+			sstore = &result.StructLogs[i]
+		}
+	}
+	assert.Equal(t, sstore != nil, true)
+	assert.Equal(t, sstore.Stack, []string{
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	})
+
+	// capture disabled
+	trace, err = api.TraceTransaction(createContractTx.Hash, &api2.TraceConfig{DisableMemory: true, DisableStack: true, Limit: 2})
+	assert.Equal(t, err, nil)
+	result = trace.(*api2.ExecutionResult)
+	assert.Equal(t, len(result.StructLogs), 2)
+	for _, log := range result.StructLogs {
+		assert.Equal(t, log.Stack == nil && log.Memory == nil, true)
+	}
+
+	// reward tx and unknown tx
+	_, err = api.TraceTransaction(block.Transactions[0].Hash, nil)
+	assert.Error(t, err)
+	_, err = api.TraceTransaction(common.StringToHash("unknown"), nil)
+	assert.Error(t, err)
+
+	// unsupported tracer
+	_, err = api.TraceTransaction(createContractTx.Hash, &api2.TraceConfig{Tracer: "unknown"})
+	assert.Error(t, err)
+}
+
+func Test_PrivateDebugAPI_CallTracer(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".CallTracer")
+	seeleAPI := newTestAPI(t, dbPath)
+	defer func() {
+		seeleAPI.s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	statedb, _ := seeleAPI.s.chain.GetCurrentState()
+	from := getFromAddress(statedb)
+	putTestBlock(t, seeleAPI, statedb)
+
+	// the init code calls the account 1 with gas 0xffff, and then selfdestructs to account 2.
+	account1, account2 := common.BytesToAddress([]byte("account 1")), common.BytesToAddress([]byte("account 2"))
+	code := append([]byte{0x60, 0, 0x60, 0, 0x60, 0, 0x60, 0, 0x60, 0, 0x73}, account1.Bytes()...)
+	code = append(code, 0x61, 0xff, 0xff, 0xf1, 0x50, 0x73)
+	code = append(code, account2.Bytes()...)
+	code = append(code, 0xff)
+	createTx, _ := types.NewContractTransaction(from, big.NewInt(0), big.NewInt(1), 500000, 0, code)
+	transferTx, _ := types.NewTransaction(from, account1, big.NewInt(1), big.NewInt(1), 1)
+	block := putTestBlock(t, seeleAPI, statedb, createTx, transferTx)
+
+	api := NewPrivateDebugAPI(seeleAPI.s)
+	trace, err := api.TraceTransaction(createTx.Hash, &api2.TraceConfig{Tracer: "callTracer"})
+	assert.Equal(t, err, nil)
+
+	frame := trace.(*vm.CallFrame)
+	assert.Equal(t, frame.Type, "CREATE")
+	assert.Equal(t, frame.From, from)
+	assert.Equal(t, uint64(frame.Gas), uint64(500000))
+	assert.Equal(t, frame.Error, "")
+	assert.Equal(t, len(frame.Calls), 2)
+	assert.Equal(t, frame.Calls[0].Type, "CALL")
+	assert.Equal(t, frame.Calls[0].From, frame.To)
+	assert.Equal(t, frame.Calls[0].To, account1)
+	assert.Equal(t, uint64(frame.Calls[0].Gas), uint64(0xffff))
+	assert.Equal(t, frame.Calls[1].Type, "SELFDESTRUCT")
+	assert.Equal(t, frame.Calls[1].From, frame.To)
+	assert.Equal(t, frame.Calls[1].To, account2)
+
+	results, err := api.TraceBlock(int64(block.Header.Height), &api2.TraceConfig{Tracer: "callTracer"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(results), 2)
+	assert.Equal(t, results[0].TxHash, createTx.Hash)
+	assert.Equal(t, results[0].Result, frame)
+	assert.Equal(t, results[1].TxHash, transferTx.Hash)
+	assert.Equal(t, results[1].Result.(*vm.CallFrame).Type, "CALL")
+	assert.Equal(t, results[1].Result.(*vm.CallFrame).To, account1)
+	assert.Equal(t, results[1].Result.(*vm.CallFrame).Value.ToInt(), big.NewInt(1))
+	assert.Equal(t, len(results[1].Result.(*vm.CallFrame).Calls), 0)
+
+	// genesis block cannot be replayed
+	_, err = api.TraceBlock(0, nil)
+	assert.Error(t, err)
+}
+
+func Test_PrivateDebugAPI_DumpState(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".DumpState")
+	seeleAPI := newTestAPI(t, dbPath)
+	defer func() {
+		seeleAPI.s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	statedb, _ := seeleAPI.s.chain.GetCurrentState()
+	for i := 0; i < 5; i++ {
+		account := *crypto.MustGenerateRandomAddress()
+		statedb.CreateAccount(account)
+		statedb.SetBalance(account, big.NewInt(int64(i+1)))
+	}
+	block := putTestBlock(t, seeleAPI, statedb)
+
+	var expected []*state.DumpAccount
+	assert.Equal(t, state.DumpState(block.Header.StateHash, seeleAPI.s.accountStateDB, func(account *state.DumpAccount) error {
+		expected = append(expected, account)
+		return nil
+	}), nil)
+	assert.Equal(t, len(expected), 5)
+
+	// dump in pages of 2 accounts
+	api := NewPrivateDebugAPI(seeleAPI.s)
+	var dumped []*state.DumpAccount
+	start := common.EmptyHash
+	for {
+		page, err := api.DumpState(int64(block.Header.Height), start, 2)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, len(page.Accounts) <= 2, true)
+		dumped = append(dumped, page.Accounts...)
+
+		if page.Next == nil {
+			break
+		}
+
+		start = *page.Next
+	}
+
+	assert.Equal(t, dumped, expected)
+
+	_, err := api.DumpState(100, common.EmptyHash, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,755 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	downloader "github.com/seeleteam/go-seele/seele/download"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+)
+
+// emptyUncleHash is the hash of RLP encoded empty uncle list in Ethereum, since Seele has no uncle blocks.
+const emptyUncleHash = "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"
+
+var errInvalidEthBlockNumber = errors.New("invalid block number, should be latest, earliest, pending or hex quantity")
+
+// PublicEthAPI provides an Ethereum-compatible API on top of the Seele full node, so that
+// Ethereum tools could talk to the node. Quantities are encoded in hex, and block number
+// accepts the tags latest, earliest and pending (same as latest). Note, the raw transaction
+// is RLP encoded signed Seele transaction, and addresses are Seele addresses with shard info.
+type PublicEthAPI struct {
+	s *SeeleService
+}
+
+// NewPublicEthAPI creates a new PublicEthAPI object for rpc service.
+func NewPublicEthAPI(s *SeeleService) *PublicEthAPI {
+	return &PublicEthAPI{s}
+}
+
+// EthCallArgs represents the arguments to call or estimate gas of a message.
+type EthCallArgs struct {
+	From     *common.Address `json:"from"` // random account in local shard if not specified
+	To       *common.Address `json:"to"`   // nil to create contract
+	Gas      string          `json:"gas"`
+	GasPrice string          `json:"gasPrice"`
+	Value    string          `json:"value"`
+	Data     string          `json:"data"`
+	Input    string          `json:"input"` // alias of data
+}
+
+// EthLogFilter is the Ethereum-compatible criteria to filter logs.
+type EthLogFilter struct {
+	FromBlock *string         `json:"fromBlock"`
+	ToBlock   *string         `json:"toBlock"`
+	BlockHash *common.Hash    `json:"blockHash"` // overrides the block range if specified
+	Address   ethAddresses    `json:"address"`
+	Topics    [][]common.Hash `json:"-"`
+}
+
+// ethAddresses is a single address or an array of addresses in JSON.
+type ethAddresses []common.Address
+
+// UnmarshalJSON unmarshals a single address or an array of addresses.
+func (addrs *ethAddresses) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var addr common.Address
+		if err := json.Unmarshal(data, &addr); err != nil {
+			return err
+		}
+
+		*addrs = ethAddresses{addr}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]common.Address)(addrs))
+}
+
+// UnmarshalJSON unmarshals the log filter, where each topic position is null,
+// a single topic or an array of topics.
+func (filter *EthLogFilter) UnmarshalJSON(data []byte) error {
+	type plainFilter EthLogFilter
+	var raw struct {
+		plainFilter
+		Topics []json.RawMessage `json:"topics"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*filter = EthLogFilter(raw.plainFilter)
+	filter.Topics = make([][]common.Hash, len(raw.Topics))
+	for i, topics := range raw.Topics {
+		var topic *common.Hash
+		if err := json.Unmarshal(topics, &topic); err == nil {
+			if topic != nil {
+				filter.Topics[i] = []common.Hash{*topic}
+			}
+
+			continue
+		}
+
+		if err := json.Unmarshal(topics, &filter.Topics[i]); err != nil {
+			return fmt.Errorf("invalid topics in position %v, %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+// parseEthBlockNumber returns the block height of the specified block number,
+// and -1 for the HEAD block if tag is nil, latest or pending.
+func parseEthBlockNumber(tag *string) (int64, error) {
+	if tag == nil {
+		return -1, nil
+	}
+
+	switch *tag {
+	case "latest", "pending":
+		return -1, nil
+	case "earliest":
+		return 0, nil
+	}
+
+	height, err := hexutil.DecodeUint64(*tag)
+	if err != nil || height > math.MaxInt64 {
+		return 0, errInvalidEthBlockNumber
+	}
+
+	return int64(height), nil
+}
+
+// checkLocalShard returns error if the specified account is not in local shard.
+func checkLocalShard(account common.Address) error {
+	if shard := account.Shard(); shard != common.LocalShardNumber {
+		return fmt.Errorf("local shard is %d, but the shard of account %v is %d", common.LocalShardNumber, account.Hex(), shard)
+	}
+
+	return nil
+}
+
+// ChainId returns the chain id configured for Ethereum-compatible namespaces.
+func (api *PublicEthAPI) ChainId() string {
+	return hexutil.EncodeUint64(api.s.ethChainID)
+}
+
+// BlockNumber returns the height of HEAD block.
+func (api *PublicEthAPI) BlockNumber() string {
+	return hexutil.EncodeUint64(api.s.chain.CurrentHeader().Height)
+}
+
+// Syncing returns false if not syncing, otherwise the sync progress.
+func (api *PublicEthAPI) Syncing() interface{} {
+	d := api.s.seeleProtocol.downloader
+	if d.IsSyncStatusNone() {
+		return false
+	}
+
+	status := downloader.NewPrivatedownloaderAPI(d).GetStatus()
+
+	return map[string]interface{}{
+		"startingBlock": hexutil.EncodeUint64(status.StartNum),
+		"currentBlock":  hexutil.EncodeUint64(api.s.chain.CurrentHeader().Height),
+		"highestBlock":  hexutil.EncodeUint64(status.StartNum + status.Amount),
+	}
+}
+
+// GasPrice returns the gas price suggested by gas price oracle.
+func (api *PublicEthAPI) GasPrice() (string, error) {
+	price, err := api.s.gasPriceOracle.suggestPrice()
+	if err != nil {
+		return "", err
+	}
+
+	return hexutil.EncodeBig(price), nil
+}
+
+// Accounts returns empty, since the node does not manage accounts.
+func (api *PublicEthAPI) Accounts() []common.Address {
+	return []common.Address{}
+}
+
+// getState returns the statedb of the specified block number.
+func (api *PublicEthAPI) getState(blockNumber *string) (*types.Block, *state.Statedb, error) {
+	height, err := parseEthBlockNumber(blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, err := getBlock(api.s.chain, height)
+	if err != nil {
+		return nil, nil, errors.NewStackedErrorf(err, "failed to get block by height %v", height)
+	}
+
+	statedb, err := state.NewStatedb(block.Header.StateHash, api.s.accountStateDB)
+	if err != nil {
+		return nil, nil, errors.NewStackedErrorf(err, "failed to get statedb of block %v", block.HeaderHash.Hex())
+	}
+
+	return block, statedb, nil
+}
+
+// GetBalance returns the balance of the specified account in local shard.
+func (api *PublicEthAPI) GetBalance(account common.Address, blockNumber *string) (string, error) {
+	if err := checkLocalShard(account); err != nil {
+		return "", err
+	}
+
+	_, statedb, err := api.getState(blockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	balance := statedb.GetBalance(account)
+	if err = statedb.GetDbErr(); err != nil {
+		return "", errors.NewStackedError(err, "failed to get balance, db error occurred")
+	}
+
+	return hexutil.EncodeBig(balance), nil
+}
+
+// GetTransactionCount returns the nonce of the specified account in local shard.
+func (api *PublicEthAPI) GetTransactionCount(account common.Address, blockNumber *string) (string, error) {
+	if err := checkLocalShard(account); err != nil {
+		return "", err
+	}
+
+	_, statedb, err := api.getState(blockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := statedb.GetNonce(account)
+	if err = statedb.GetDbErr(); err != nil {
+		return "", errors.NewStackedError(err, "failed to get nonce, db error occurred")
+	}
+
+	return hexutil.EncodeUint64(nonce), nil
+}
+
+// GetCode returns the code of the specified contract in local shard.
+func (api *PublicEthAPI) GetCode(contract common.Address, blockNumber *string) (string, error) {
+	if err := checkLocalShard(contract); err != nil {
+		return "", err
+	}
+
+	_, statedb, err := api.getState(blockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return hexutil.BytesToHex(statedb.GetCode(contract)), nil
+}
+
+// GetStorageAt returns the storage value of the specified contract and key in local shard.
+func (api *PublicEthAPI) GetStorageAt(contract common.Address, key common.Hash, blockNumber *string) (string, error) {
+	if err := checkLocalShard(contract); err != nil {
+		return "", err
+	}
+
+	_, statedb, err := api.getState(blockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return common.BytesToHash(statedb.GetData(contract, key)).Hex(), nil
+}
+
+// Call executes a message call on the state of the specified block number without creating
+// a transaction on chain, and returns the result data.
+func (api *PublicEthAPI) Call(args EthCallArgs, blockNumber *string) (string, error) {
+	receipt, err := api.doCall(args, blockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return hexutil.BytesToHex(receipt.Result), nil
+}
+
+// EstimateGas returns the lowest gas limit that the message call executes successfully on the
+// state of HEAD block. The caller is funded for the call, so the search is capped at 1 Seele in Fan.
+func (api *PublicEthAPI) EstimateGas(args EthCallArgs) (string, error) {
+	block, statedb, err := api.getState(nil)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := newEthCallTx(args, statedb)
+	if err != nil {
+		return "", err
+	}
+
+	gasCap := common.SeeleToFan.Uint64()
+	gas, err := estimateGas(api.s.chain, block.Header, api.s.miner.GetCoinbase(), tx, gasCap, func() (*state.Statedb, error) {
+		statedb, err := state.NewStatedb(block.Header.StateHash, api.s.accountStateDB)
+		if err != nil {
+			return nil, err
+		}
+
+		fundEthCaller(statedb, tx.Data.From, tx.Data.Amount, tx.Data.GasPrice, gasCap)
+
+		return statedb, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hexutil.EncodeUint64(gas), nil
+}
+
+func (api *PublicEthAPI) doCall(args EthCallArgs, blockNumber *string) (*types.Receipt, error) {
+	block, statedb, err := api.getState(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := newEthCallTx(args, statedb)
+	if err != nil {
+		return nil, err
+	}
+
+	fundEthCaller(statedb, tx.Data.From, tx.Data.Amount, tx.Data.GasPrice, tx.Data.GasLimit)
+
+	receipt, err := api.s.chain.ApplyTransaction(tx, 0, api.s.miner.GetCoinbase(), statedb, block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Failed {
+		return nil, errors.New(string(receipt.Result))
+	}
+
+	return receipt, nil
+}
+
+// newEthCallTx returns the tx of the message call args, which is sent from a random account
+// of local shard if not specified.
+func newEthCallTx(args EthCallArgs, statedb *state.Statedb) (*types.Transaction, error) {
+	from := args.From
+	if from == nil {
+		from = crypto.MustGenerateShardAddress(common.LocalShardNumber)
+	}
+
+	if err := checkLocalShard(*from); err != nil {
+		return nil, err
+	}
+
+	var err error
+	gasLimit, price, amount := common.SeeleToFan.Uint64(), big.NewInt(1), big.NewInt(0)
+	if len(args.Gas) > 0 {
+		if gasLimit, err = hexutil.DecodeUint64(args.Gas); err != nil {
+			return nil, fmt.Errorf("invalid gas, %v", err)
+		}
+	}
+
+	if len(args.GasPrice) > 0 {
+		if price, err = hexutil.DecodeBig(args.GasPrice); err != nil {
+			return nil, fmt.Errorf("invalid gas price, %v", err)
+		}
+	}
+
+	if len(args.Value) > 0 {
+		if amount, err = hexutil.DecodeBig(args.Value); err != nil {
+			return nil, fmt.Errorf("invalid value, %v", err)
+		}
+	}
+
+	input := args.Data
+	if len(input) == 0 {
+		input = args.Input
+	}
+
+	var payload []byte
+	if len(input) > 0 {
+		if payload, err = hexutil.HexToBytes(input); err != nil {
+			return nil, fmt.Errorf("invalid data, %v", err)
+		}
+	}
+
+	nonce := statedb.GetNonce(*from)
+
+	var tx *types.Transaction
+	if args.To == nil {
+		tx, err = types.NewContractTransaction(*from, amount, price, gasLimit, nonce, payload)
+	} else {
+		tx, err = types.NewMessageTransaction(*from, *args.To, amount, price, gasLimit, nonce, payload)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction, %v", err)
+	}
+
+	return tx, nil
+}
+
+// fundEthCaller funds the caller for the amount and tx fee of the specified gas limit,
+// and the statedb will not be committed.
+func fundEthCaller(statedb *state.Statedb, from common.Address, amount, price *big.Int, gasLimit uint64) {
+	if !statedb.Exist(from) {
+		statedb.CreateAccount(from)
+	}
+
+	fee := new(big.Int).Mul(price, new(big.Int).SetUint64(gasLimit))
+	statedb.AddBalance(from, fee.Add(fee, amount))
+}
+
+// SendRawTransaction adds the RLP encoded signed Seele transaction into tx pool, or
+// sends it to other shard, and returns the tx hash.
+func (api *PublicEthAPI) SendRawTransaction(data string) (common.Hash, error) {
+	encoded, err := hexutil.HexToBytes(data)
+	if err != nil {
+		return common.EmptyHash, fmt.Errorf("invalid data, %v", err)
+	}
+
+	var tx types.Transaction
+	if err = common.Deserialize(encoded, &tx); err != nil {
+		return common.EmptyHash, fmt.Errorf("invalid transaction, %v", err)
+	}
+
+	if _, err = api2.NewPublicSeeleAPI(NewSeeleBackend(api.s)).AddTx(tx); err != nil {
+		return common.EmptyHash, err
+	}
+
+	return tx.Hash, nil
+}
+
+// getTransaction returns the transaction in tx pool or canonical chain, and nil if not found.
+func (api *PublicEthAPI) getTransaction(hash common.Hash) (*types.Transaction, *api2.BlockIndex, error) {
+	tx, idx, err := api2.GetTransaction(api.s.txPool, api.s.chain.GetStore(), hash)
+	if errors.IsOrContains(err, leveldbErrors.ErrNotFound) {
+		return nil, nil, nil
+	}
+
+	return tx, idx, err
+}
+
+// GetTransactionByHash returns the transaction in tx pool or canonical chain, and null if not found.
+func (api *PublicEthAPI) GetTransactionByHash(hash common.Hash) (map[string]interface{}, error) {
+	tx, idx, err := api.getTransaction(hash)
+	if tx == nil || err != nil {
+		return nil, err
+	}
+
+	return ethTransaction(tx, idx), nil
+}
+
+// GetTransactionReceipt returns the receipt of transaction in canonical chain, and null if not found.
+func (api *PublicEthAPI) GetTransactionReceipt(hash common.Hash) (map[string]interface{}, error) {
+	tx, idx, err := api.getTransaction(hash)
+	if tx == nil || idx == nil || err != nil {
+		return nil, err
+	}
+
+	receipts, err := api.blockReceipts(idx.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(idx.Index) >= len(receipts) {
+		return nil, fmt.Errorf("receipt not found in block %v, index = %v", idx.BlockHash.Hex(), idx.Index)
+	}
+
+	var cumulativeGasUsed uint64
+	var logIndex uint
+	for _, receipt := range receipts[:idx.Index] {
+		cumulativeGasUsed += receipt.UsedGas
+		logIndex += uint(len(receipt.Logs))
+	}
+
+	receipt := receipts[idx.Index]
+	logs := make([]map[string]interface{}, len(receipt.Logs))
+	for i, log := range receipt.Logs {
+		logs[i] = ethLog(&api2.FilteredLog{
+			Log:       log,
+			BlockHash: idx.BlockHash,
+			TxHash:    receipt.TxHash,
+			LogIndex:  logIndex + uint(i),
+		})
+		logs[i]["blockNumber"] = hexutil.EncodeUint64(idx.BlockHeight)
+	}
+
+	output := map[string]interface{}{
+		"transactionHash":   receipt.TxHash.Hex(),
+		"transactionIndex":  hexutil.EncodeUint64(uint64(idx.Index)),
+		"blockHash":         idx.BlockHash.Hex(),
+		"blockNumber":       hexutil.EncodeUint64(idx.BlockHeight),
+		"from":              tx.Data.From.Hex(),
+		"to":                nil,
+		"cumulativeGasUsed": hexutil.EncodeUint64(cumulativeGasUsed + receipt.UsedGas),
+		"gasUsed":           hexutil.EncodeUint64(receipt.UsedGas),
+		"contractAddress":   nil,
+		"logs":              logs,
+		"logsBloom":         hexutil.BytesToHex(types.ReceiptsBloom([]*types.Receipt{receipt}).Bytes()),
+		"status":            "0x1",
+	}
+
+	if !tx.Data.To.IsEmpty() {
+		output["to"] = tx.Data.To.Hex()
+	}
+
+	if len(receipt.ContractAddress) > 0 {
+		output["contractAddress"] = common.BytesToAddress(receipt.ContractAddress).Hex()
+	}
+
+	if receipt.Failed {
+		output["status"] = "0x0"
+	}
+
+	return output, nil
+}
+
+// blockReceipts returns the receipts of the specified block, and empty if not found, e.g. genesis block.
+func (api *PublicEthAPI) blockReceipts(hash common.Hash) ([]*types.Receipt, error) {
+	receipts, err := api.s.chain.GetStore().GetReceiptsByBlockHash(hash)
+	if errors.IsOrContains(err, leveldbErrors.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to get receipts by block hash %v", hash.Hex())
+	}
+
+	return receipts, nil
+}
+
+// GetBlockByNumber returns the canonical block of the specified block number, and null if not found.
+// If fullTx is true, all transactions in block are returned in full detail, otherwise tx hashes only.
+func (api *PublicEthAPI) GetBlockByNumber(blockNumber string, fullTx bool) (map[string]interface{}, error) {
+	height, err := parseEthBlockNumber(&blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := getBlock(api.s.chain, height)
+	if errors.IsOrContains(err, leveldbErrors.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ethBlock(block, fullTx)
+}
+
+// GetBlockByHash returns the block of the specified hash, and null if not found.
+// If fullTx is true, all transactions in block are returned in full detail, otherwise tx hashes only.
+func (api *PublicEthAPI) GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	block, err := api.s.chain.GetStore().GetBlock(hash)
+	if errors.IsOrContains(err, leveldbErrors.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ethBlock(block, fullTx)
+}
+
+// GetLogs returns the logs that match the filter in canonical chain.
+func (api *PublicEthAPI) GetLogs(filter EthLogFilter) ([]map[string]interface{}, error) {
+	criteria := api2.LogFilter{
+		Addresses: filter.Address,
+		Topics:    filter.Topics,
+	}
+
+	var err error
+	if filter.BlockHash != nil {
+		header, err := api.s.chain.GetStore().GetBlockHeader(*filter.BlockHash)
+		if err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get block header by hash %v", filter.BlockHash.Hex())
+		}
+
+		criteria.FromBlock, criteria.ToBlock = int64(header.Height), int64(header.Height)
+	} else {
+		if criteria.FromBlock, err = parseEthBlockNumber(filter.FromBlock); err != nil {
+			return nil, err
+		}
+
+		if criteria.ToBlock, err = parseEthBlockNumber(filter.ToBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	logs, err := api2.FilterLogs(api.s.chain.GetStore(), api.s.chain.CurrentHeader().Height, &criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0, len(logs))
+	for _, log := range logs {
+		// the block of hash may be not in canonical chain
+		if filter.BlockHash != nil && !log.BlockHash.Equal(*filter.BlockHash) {
+			continue
+		}
+
+		result = append(result, ethLog(log))
+	}
+
+	return result, nil
+}
+
+func (api *PublicEthAPI) ethBlock(block *types.Block, fullTx bool) (map[string]interface{}, error) {
+	bcStore := api.s.chain.GetStore()
+	td, err := bcStore.GetBlockTotalDifficulty(block.HeaderHash)
+	if err != nil {
+		return nil, errors.NewStackedErrorf(err, "failed to get block TD by hash %v", block.HeaderHash.Hex())
+	}
+
+	receipts, err := api.blockReceipts(block.HeaderHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var gasUsed, gasLimit uint64
+	for _, receipt := range receipts {
+		gasUsed += receipt.UsedGas
+	}
+
+	txs := make([]interface{}, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		// Seele has no block gas limit, so use the sum of tx gas limits instead.
+		gasLimit += tx.Data.GasLimit
+
+		if fullTx {
+			txs[i] = ethTransaction(tx, &api2.BlockIndex{BlockHash: block.HeaderHash, BlockHeight: block.Header.Height, Index: uint(i)})
+		} else {
+			txs[i] = tx.Hash.Hex()
+		}
+	}
+
+	header := block.Header
+	return map[string]interface{}{
+		"number":           hexutil.EncodeUint64(header.Height),
+		"hash":             block.HeaderHash.Hex(),
+		"parentHash":       header.PreviousBlockHash.Hex(),
+		"nonce":            "0x0000000000000000",
+		"sha3Uncles":       emptyUncleHash,
+		"logsBloom":        hexutil.BytesToHex(types.ReceiptsBloom(receipts).Bytes()),
+		"transactionsRoot": header.TxHash.Hex(),
+		"stateRoot":        header.StateHash.Hex(),
+		"receiptsRoot":     header.ReceiptHash.Hex(),
+		"miner":            header.Creator.Hex(),
+		"difficulty":       hexutil.EncodeBig(header.Difficulty),
+		"totalDifficulty":  hexutil.EncodeBig(td),
+		"extraData":        hexutil.BytesToHex(header.ExtraData),
+		"size":             hexutil.EncodeUint64(uint64(len(common.SerializePanic(block)))),
+		"gasLimit":         hexutil.EncodeUint64(gasLimit),
+		"gasUsed":          hexutil.EncodeUint64(gasUsed),
+		"timestamp":        hexutil.EncodeBig(header.CreateTimestamp),
+		"transactions":     txs,
+		"uncles":           []string{},
+	}, nil
+}
+
+// ethTransaction returns the Ethereum-compatible output of transaction, and idx is nil if tx in pool.
+func ethTransaction(tx *types.Transaction, idx *api2.BlockIndex) map[string]interface{} {
+	output := map[string]interface{}{
+		"hash":             tx.Hash.Hex(),
+		"nonce":            hexutil.EncodeUint64(tx.Data.AccountNonce),
+		"blockHash":        nil,
+		"blockNumber":      nil,
+		"transactionIndex": nil,
+		"from":             tx.Data.From.Hex(),
+		"to":               nil,
+		"value":            hexutil.EncodeBig(tx.Data.Amount),
+		"gasPrice":         hexutil.EncodeBig(tx.Data.GasPrice),
+		"gas":              hexutil.EncodeUint64(tx.Data.GasLimit),
+		"input":            hexutil.BytesToHex(tx.Data.Payload),
+	}
+
+	if !tx.Data.To.IsEmpty() {
+		output["to"] = tx.Data.To.Hex()
+	}
+
+	if idx != nil {
+		output["blockHash"] = idx.BlockHash.Hex()
+		output["blockNumber"] = hexutil.EncodeUint64(idx.BlockHeight)
+		output["transactionIndex"] = hexutil.EncodeUint64(uint64(idx.Index))
+	}
+
+	return output
+}
+
+// ethLog returns the Ethereum-compatible output of log.
+func ethLog(log *api2.FilteredLog) map[string]interface{} {
+	topics := make([]string, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = topic.Hex()
+	}
+
+	return map[string]interface{}{
+		"address":          log.Address.Hex(),
+		"topics":           topics,
+		"data":             hexutil.BytesToHex(log.Data),
+		"blockNumber":      hexutil.EncodeUint64(log.BlockNumber),
+		"blockHash":        log.BlockHash.Hex(),
+		"transactionHash":  log.TxHash.Hex(),
+		"transactionIndex": hexutil.EncodeUint64(uint64(log.TxIndex)),
+		"logIndex":         hexutil.EncodeUint64(uint64(log.LogIndex)),
+		"removed":          log.Removed,
+	}
+}
+
+// PublicNetAPI provides an Ethereum-compatible API of network information.
+type PublicNetAPI struct {
+	s *SeeleService
+}
+
+// NewPublicNetAPI creates a new PublicNetAPI object for rpc service.
+func NewPublicNetAPI(s *SeeleService) *PublicNetAPI {
+	return &PublicNetAPI{s}
+}
+
+// Version returns the chain id in decimal.
+func (api *PublicNetAPI) Version() string {
+	return strconv.FormatUint(api.s.ethChainID, 10)
+}
+
+// Listening returns true since the node is always listening for network connections.
+func (api *PublicNetAPI) Listening() bool {
+	return true
+}
+
+// PeerCount returns the number of connected peers.
+func (api *PublicNetAPI) PeerCount() string {
+	if api.s.p2pServer == nil {
+		return hexutil.EncodeUint64(0)
+	}
+
+	return hexutil.EncodeUint64(uint64(api.s.p2pServer.PeerCount()))
+}
+
+// PublicWeb3API provides an Ethereum-compatible API of node utilities.
+type PublicWeb3API struct{}
+
+// ClientVersion returns the node version.
+func (api *PublicWeb3API) ClientVersion() string {
+	return "Seele/" + common.SeeleNodeVersion
+}
+
+// Sha3 returns the Keccak-256 hash of the specified data.
+func (api *PublicWeb3API) Sha3(data string) (string, error) {
+	input, err := hexutil.HexToBytes(data)
+	if err != nil {
+		return "", err
+	}
+
+	return crypto.Keccak256Hash(input).Hex(), nil
+}
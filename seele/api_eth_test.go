@@ -0,0 +1,151 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseEthBlockNumber(t *testing.T) {
+	tags := map[string]int64{"latest": -1, "pending": -1, "earliest": 0, "0x0": 0, "0x1f": 31}
+	for tag, expected := range tags {
+		height, err := parseEthBlockNumber(&tag)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, height, expected)
+	}
+
+	height, err := parseEthBlockNumber(nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, height, int64(-1))
+
+	for _, tag := range []string{"", "1f", "0x", "0x01", "safe"} {
+		_, err = parseEthBlockNumber(&tag)
+		assert.Equal(t, err, errInvalidEthBlockNumber)
+	}
+}
+
+func Test_EthLogFilter_UnmarshalJSON(t *testing.T) {
+	addr := *crypto.MustGenerateRandomAddress()
+	topic1, topic2 := common.StringToHash("topic 1"), common.StringToHash("topic 2")
+
+	data := `{"fromBlock":"0x1","address":"` + addr.Hex() + `","topics":[null,"` + topic1.Hex() + `",["` + topic1.Hex() + `","` + topic2.Hex() + `"]]}`
+	var filter EthLogFilter
+	assert.Nil(t, json.Unmarshal([]byte(data), &filter))
+	assert.Equal(t, *filter.FromBlock, "0x1")
+	assert.Equal(t, filter.ToBlock == nil, true)
+	assert.Equal(t, []common.Address(filter.Address), []common.Address{addr})
+	assert.Equal(t, filter.Topics, [][]common.Hash{nil, []common.Hash{topic1}, []common.Hash{topic1, topic2}})
+
+	data = `{"address":["` + addr.Hex() + `"],"topics":[1]}`
+	assert.Error(t, json.Unmarshal([]byte(data), &filter))
+}
+
+func Test_PublicEthAPI(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".PublicEthAPI")
+	seeleAPI := newTestAPI(t, dbPath)
+	defer func() {
+		seeleAPI.s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	api := NewPublicEthAPI(seeleAPI.s)
+
+	// Create a contract/solidity/simple_storage.sol contract, get = 5
+	bytecode, _ := hexutil.HexToBytes("0x608060405234801561001057600080fd5b50600560008190555060df806100276000396000f3006080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806360fe47b114604e5780636d4ce63c146078575b600080fd5b348015605957600080fd5b5060766004803603810190808035906020019092919050505060a0565b005b348015608357600080fd5b50608a60aa565b6040518082815260200191505060405180910390f35b8060008190555050565b600080549050905600a165627a7a723058207f6dc43a0d648e9f5a0cad5071cde46657de72eb87ab4cded53a7f1090f51e6d0029")
+	statedb, _ := seeleAPI.s.chain.GetCurrentState()
+	from := getFromAddress(statedb)
+	createContractTx, _ := types.NewContractTransaction(from, big.NewInt(0), big.NewInt(1), 500000, 0, bytecode)
+	contract := common.BytesToAddress(sendTx(t, seeleAPI, statedb, createContractTx))
+
+	// accounts of other shards are not supported
+	originalShard := common.LocalShardNumber
+	defer func() { common.LocalShardNumber = originalShard }()
+	common.LocalShardNumber = from.Shard()%common.ShardCount + 1
+	_, err := api.GetBalance(from, nil)
+	assert.Error(t, err)
+
+	common.LocalShardNumber = from.Shard()
+	assert.Equal(t, api.BlockNumber(), "0x1")
+
+	balance, err := api.GetBalance(from, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, balance, hexutil.EncodeBig(statedb.GetBalance(from)))
+
+	nonce, err := api.GetTransactionCount(from, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, nonce, "0x1")
+
+	earliest := "earliest"
+	nonce, err = api.GetTransactionCount(from, &earliest)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, nonce, "0x0")
+
+	code, err := api.GetCode(contract, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, code, hexutil.BytesToHex(statedb.GetCode(contract)))
+
+	// call get()
+	result, err := api.Call(EthCallArgs{From: &from, To: &contract, Data: "0x6d4ce63c"}, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result, common.BigToHash(big.NewInt(5)).Hex())
+
+	gas, err := api.EstimateGas(EthCallArgs{From: &from, To: &contract, Input: "0x6d4ce63c"})
+	assert.Equal(t, err, nil)
+	assert.NotEqual(t, gas, "0x0")
+
+	// set(0) is refunded for clearing the slot, so the gas limit needed is more than the used gas.
+	setArgs := EthCallArgs{To: &contract, Data: "0x60fe47b1" + common.EmptyHash.Hex()[2:]}
+	gas, err = api.EstimateGas(setArgs)
+	assert.Equal(t, err, nil)
+	gasLimit, _ := hexutil.DecodeUint64(gas)
+
+	setArgs.Gas = gas
+	_, err = api.Call(setArgs, nil)
+	assert.Equal(t, err, nil)
+
+	setArgs.Gas = hexutil.EncodeUint64(gasLimit - 1)
+	_, err = api.Call(setArgs, nil)
+	assert.Error(t, err)
+
+	// transfer value from an unfunded caller
+	to := crypto.MustGenerateShardAddress(from.Shard())
+	transferArgs := EthCallArgs{To: to, Value: "0x3e8"}
+	_, err = api.Call(transferArgs, nil)
+	assert.Equal(t, err, nil)
+
+	gas, err = api.EstimateGas(transferArgs)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, gas, hexutil.EncodeUint64(types.TransferAmountIntrinsicGas))
+
+	block, err := api.GetBlockByNumber("earliest", false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, block["number"], "0x0")
+	assert.Equal(t, block["transactions"], []interface{}{})
+
+	block, err = api.GetBlockByNumber("0x2", false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, block == nil, true)
+
+	tx, err := api.GetTransactionByHash(common.StringToHash("not found"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, tx == nil, true)
+}
+
+func Test_PublicWeb3API_Sha3(t *testing.T) {
+	hash, err := (&PublicWeb3API{}).Sha3("0x68656c6c6f20776f726c64")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, hash, "0x47173285a8d7341e5e972fc677286384f802f8ef42a5ec5f03bbfa254cb01fad")
+}
@@ -0,0 +1,90 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"context"
+
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// PublicFilterAPI provides subscriptions of chain, txpool and sync events, which
+// requires connection that supports notifications, e.g. WebSocket.
+type PublicFilterAPI struct {
+	fs *filterSystem
+}
+
+// NewPublicFilterAPI creates a new PublicFilterAPI object for rpc service.
+func NewPublicFilterAPI(fs *filterSystem) *PublicFilterAPI {
+	return &PublicFilterAPI{fs}
+}
+
+// NewHeads sends a notification each time a new block is appended to the canonical chain.
+func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribe(ctx, headsSubscription, nil)
+}
+
+// Logs sends a notification for each log that matches the filter in new canonical blocks.
+// When the chain reorganized, the logs in reverted blocks are sent again with removed set.
+// Note, the block range of filter is ignored.
+func (api *PublicFilterAPI) Logs(ctx context.Context, filter api2.LogFilter) (*rpc.Subscription, error) {
+	return api.subscribe(ctx, logsSubscription, &filter)
+}
+
+// NewPendingTransactions sends a notification with tx hash each time a tx is inserted into tx pool.
+func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribe(ctx, pendingTxsSubscription, nil)
+}
+
+// ReplacedTransactions sends a notification with the hashes of replaced tx and its replacement each time
+// a tx in tx pool is replaced by another one with the same nonce.
+func (api *PublicFilterAPI) ReplacedTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribe(ctx, replacedTxsSubscription, nil)
+}
+
+// NewDebts sends a notification with debt hash each time a debt is inserted into debt pool.
+func (api *PublicFilterAPI) NewDebts(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribe(ctx, debtsSubscription, nil)
+}
+
+// Syncing sends a notification each time the block synchronization started, done or failed.
+func (api *PublicFilterAPI) Syncing(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribe(ctx, syncingSubscription, nil)
+}
+
+func (api *PublicFilterAPI) subscribe(ctx context.Context, kind subscriptionKind, filter *api2.LogFilter) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	sub, err := api.fs.subscribe(kind, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer api.fs.unsubscribe(sub)
+
+		for {
+			select {
+			case data := <-sub.ch:
+				if err := notifier.Notify(rpcSub.ID, data); err != nil {
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -0,0 +1,59 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/common/hexutil"
+)
+
+// GetProof returns the account state and the values of the specified storage keys with merkle
+// proofs against the state root of the specified block, which could be "latest" (default), block
+// hash or block height. The proofs could be verified via api.ProofResult.Verify with a trusted
+// block header.
+func (api *PublicSeeleAPI) GetProof(account common.Address, storageKeys []common.Hash, block string) (*api2.ProofResult, error) {
+	if block == pendingBlock {
+		return nil, errors.New("pending block is not supported")
+	}
+
+	header, statedb, err := api.blockState(block)
+	if err != nil {
+		return nil, err
+	}
+
+	accountProof, err := statedb.GetAccountProof(account)
+	if err != nil {
+		return nil, errors.NewStackedError(err, "failed to get account proof")
+	}
+
+	result := &api2.ProofResult{
+		Address:      account,
+		BlockHash:    header.Hash(),
+		StateHash:    header.StateHash,
+		Balance:      statedb.GetBalance(account),
+		Nonce:        statedb.GetNonce(account),
+		CodeHash:     statedb.GetCodeHash(account),
+		AccountProof: api2.EncodeProof(accountProof),
+		StorageProof: make([]api2.StorageProofResult, len(storageKeys)),
+	}
+
+	for i, key := range storageKeys {
+		proof, err := statedb.GetStorageProof(account, key)
+		if err != nil {
+			return nil, errors.NewStackedErrorf(err, "failed to get storage proof of key %v", key.Hex())
+		}
+
+		result.StorageProof[i] = api2.StorageProofResult{
+			Key:   key,
+			Value: hexutil.BytesToHex(statedb.GetData(account, key)),
+			Proof: api2.EncodeProof(proof),
+		}
+	}
+
+	return result, nil
+}
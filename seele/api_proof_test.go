@@ -0,0 +1,74 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PublicSeeleAPI_GetProof(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".GetProof")
+	api := newTestAPI(t, dbPath)
+	defer func() {
+		api.s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	statedb, _ := api.s.chain.GetCurrentState()
+	from := getFromAddress(statedb)
+	contract := crypto.CreateAddress(from, 0)
+	statedb.CreateAccount(contract)
+	statedb.SetCode(contract, []byte("code"))
+	statedb.SetData(contract, common.StringToHash("key"), []byte("value"))
+	block := putTestBlock(t, api, statedb)
+
+	// account proof
+	result, err := api.GetProof(from, nil, "1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.BlockHash, block.HeaderHash)
+	assert.Equal(t, result.Balance, common.SeeleToFan)
+	assert.Equal(t, len(result.StorageProof), 0)
+	assert.Equal(t, result.Verify(block.Header.StateHash), nil)
+
+	// storage proofs after JSON round trip
+	keys := []common.Hash{common.StringToHash("key"), common.StringToHash("unknown")}
+	result, err = api.GetProof(contract, keys, block.HeaderHash.Hex())
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.CodeHash, crypto.HashBytes([]byte("code")))
+	assert.Equal(t, result.StorageProof[0].Value, "0x76616c7565")
+	assert.Equal(t, result.StorageProof[1].Value, "0x")
+
+	encoded, err := json.Marshal(result)
+	assert.Equal(t, err, nil)
+	var decoded api2.ProofResult
+	assert.Equal(t, json.Unmarshal(encoded, &decoded), nil)
+	assert.Equal(t, decoded.Verify(block.Header.StateHash), nil)
+
+	// faked state
+	decoded.StorageProof[1].Value = "0x01"
+	assert.Error(t, decoded.Verify(block.Header.StateHash))
+	decoded.Balance = big.NewInt(1)
+	assert.Error(t, decoded.Verify(block.Header.StateHash))
+	assert.Error(t, result.Verify(common.StringToHash("untrusted")))
+
+	// account not found
+	result, err = api.GetProof(*crypto.MustGenerateRandomAddress(), keys, "1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result.Balance, big.NewInt(0))
+	assert.Equal(t, result.Verify(block.Header.StateHash), nil)
+
+	_, err = api.GetProof(from, nil, "pending")
+	assert.Error(t, err)
+}
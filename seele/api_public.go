@@ -19,6 +19,7 @@ import (
 	"github.com/seeleteam/go-seele/core"
 	"github.com/seeleteam/go-seele/core/state"
 	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/core/vm"
 	"github.com/seeleteam/go-seele/crypto"
 )
 
@@ -34,8 +35,10 @@ func NewPublicSeeleAPI(s *SeeleService) *PublicSeeleAPI {
 	return &PublicSeeleAPI{s}
 }
 
-// EstimateGas returns an estimate of the amount of gas needed to execute the
-// given transaction against the current pending block.
+// EstimateGas returns the lowest gas limit that the given transaction executes successfully
+// against the current block. The search is capped at the gas affordable by sender, and 1 Seele
+// in Fan since there is no gas limit of block, so the gas limit of tx is not required.
+// If the transaction always fails, the ABI decoded revert reason is returned in error if any.
 func (api *PublicSeeleAPI) EstimateGas(tx *types.Transaction) (uint64, error) {
 	// Get the block by block height, if the height is less than zero, get the current block.
 	block, err := getBlock(api.s.chain, -1)
@@ -49,16 +52,81 @@ func (api *PublicSeeleAPI) EstimateGas(tx *types.Transaction) (uint64, error) {
 		return 0, err
 	}
 
-	coinbase := api.s.miner.GetCoinbase()
-	// Get the transaction receipt, and the fee give to the miner coinbase
-	receipt, err := api.s.chain.ApplyTransaction(tx, 0, coinbase, statedb, block.Header)
+	if tx.Data.Amount == nil || tx.Data.GasPrice == nil || tx.Data.GasPrice.Sign() <= 0 {
+		return 0, errors.New("amount and positive gas price are required")
+	}
+
+	gasCap := affordableGas(statedb, tx.Data.From, tx.Data.Amount, tx.Data.GasPrice)
+	if intrGas := tx.IntrinsicGas(); gasCap < intrGas {
+		return 0, fmt.Errorf("balance is not enough to pay the intrinsic gas %v", intrGas)
+	}
+
+	return estimateGas(api.s.chain, block.Header, api.s.miner.GetCoinbase(), tx, gasCap, func() (*state.Statedb, error) {
+		return state.NewStatedb(block.Header.StateHash, api.s.accountStateDB)
+	})
+}
+
+// estimateGas returns the lowest gas limit that the tx executes successfully on the statedb
+// created by newState, which is found by binary search between the intrinsic gas and the gas cap.
+// If the tx fails with the gas cap, the ABI decoded revert reason is returned in error if any.
+func estimateGas(chain *core.Blockchain, header *types.BlockHeader, coinbase common.Address, tx *types.Transaction,
+	gasCap uint64, newState func() (*state.Statedb, error)) (uint64, error) {
+	intrGas := tx.IntrinsicGas()
+	if gasCap < intrGas {
+		return 0, fmt.Errorf("gas required exceeds allowance %v", gasCap)
+	}
+
+	// execute applies the tx with the specified gas limit on a new statedb
+	execute := func(gasLimit uint64) (*types.Receipt, *vm.CallTracer, error) {
+		statedb, err := newState()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		estimateTx := *tx
+		estimateTx.Data.GasLimit = gasLimit
+		tracer := vm.NewCallTracer()
+		receipt, err := chain.ApplyTransactionWithVMConfig(&estimateTx, 0, coinbase, statedb, header, &vm.Config{Debug: true, Tracer: tracer})
+
+		return receipt, tracer, err
+	}
+
+	// the transaction always fails if it fails with the gas cap
+	hi := gasCap
+	receipt, tracer, err := execute(hi)
 	if err != nil {
 		return 0, err
 	}
+
 	if receipt.Failed {
+		if reason := revertReason(receipt, tracer); len(reason) > 0 {
+			return 0, fmt.Errorf("execution reverted: %v", reason)
+		}
+
 		return 0, errors.New(string(receipt.Result))
 	}
-	return receipt.UsedGas, nil
+
+	usedGas := receipt.UsedGas
+	for lo := intrGas - 1; lo+1 < hi; {
+		mid := (lo + hi) / 2
+		receipt, _, err := execute(mid)
+		if err != nil && !errors.IsOrContains(err, types.ErrIntrinsicGas) {
+			return 0, err
+		}
+
+		if err != nil || receipt.Failed {
+			lo = mid
+		} else {
+			hi, usedGas = mid, receipt.UsedGas
+		}
+	}
+
+	// the used gas of cross shard tx is more than its gas limit, since the debt is charged.
+	if usedGas > hi {
+		return usedGas, nil
+	}
+
+	return hi, nil
 }
 
 // GetInfo gets the account address that mining rewards will be send to.
@@ -194,6 +262,12 @@ func (api *PublicSeeleAPI) GetLogs(height int64, contractAddress common.Address,
 	return logs, nil
 }
 
+// FilterLogs returns the logs that match the filter in the canonical blocks of the specified height range.
+// At most 10000 blocks could be filtered in one query, and the logs are decoded if ABI provided.
+func (api *PublicSeeleAPI) FilterLogs(filter api2.LogFilter) ([]*api2.FilteredLog, error) {
+	return api2.FilterLogs(api.s.chain.GetStore(), api.s.chain.CurrentHeader().Height, &filter)
+}
+
 // getBlock returns block by height,when height is less than 0 the chain head is returned
 func getBlock(chain *core.Blockchain, height int64) (*types.Block, error) {
 	var block *types.Block
@@ -210,6 +284,12 @@ func getBlock(chain *core.Blockchain, height int64) (*types.Block, error) {
 	return block, nil
 }
 
+// SuggestGasPrice returns the gas price suggested to get txs included in time, which is the
+// percentile of gas prices in the recent blocks, raised if the pending txs in pool exceed a block.
+func (api *PublicSeeleAPI) SuggestGasPrice() (*big.Int, error) {
+	return api.s.gasPriceOracle.suggestPrice()
+}
+
 // GetShardNum gets the account shard number .
 // if the address is valid, return the corresponding shard number, otherwise return 0
 func (api *PublicSeeleAPI) GetShardNum(account common.Address) (uint, error) {
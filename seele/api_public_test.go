@@ -273,6 +273,70 @@ func Test_EstimateGas(t *testing.T) {
 	assert.NotZero(t, estimateGas4)
 }
 
+func Test_EstimateGas_BinarySearch(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".EstimateGasBinarySearch")
+	api := newTestAPI(t, dbPath)
+	defer func() {
+		api.s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	// simple storage contract with slot 0 = 7, and revert contract
+	statedb, _ := api.s.chain.GetCurrentState()
+	from := getFromAddress(statedb)
+	storageContract, revertContract := crypto.CreateAddress(from, 0), crypto.CreateAddress(from, 1)
+	storageCode, _ := hexutil.HexToBytes(testSimpleStorageCode)
+	statedb.CreateAccount(storageContract)
+	statedb.SetCode(storageContract, storageCode)
+	statedb.SetData(storageContract, common.EmptyHash, common.BigToHash(big.NewInt(7)).Bytes())
+	revertCode, _ := hexutil.HexToBytes(testRevertCode)
+	statedb.CreateAccount(revertContract)
+	statedb.SetCode(revertContract, revertCode)
+
+	batch := api.s.accountStateDB.NewBatch()
+	block := api.s.chain.CurrentBlock()
+	block.Header.StateHash, _ = statedb.Commit(batch)
+	assert.Nil(t, batch.Commit())
+
+	// set(0) is refunded for clearing the slot, so the gas limit needed is more than the used gas.
+	payload := append([]byte{0x60, 0xfe, 0x47, 0xb1}, common.EmptyHash.Bytes()...)
+	setTx, err := types.NewMessageTransaction(from, storageContract, big.NewInt(0), big.NewInt(1), 500000, 0, payload)
+	assert.Equal(t, err, nil)
+	setTx.Data.GasLimit = 0 // not specified
+	gas, err := api.EstimateGas(setTx)
+	assert.Equal(t, err, nil)
+
+	apply := func(gasLimit uint64) *types.Receipt {
+		statedb, _ := state.NewStatedb(block.Header.StateHash, api.s.accountStateDB)
+		tx := *setTx
+		tx.Data.GasLimit = gasLimit
+		receipt, err := api.s.chain.ApplyTransaction(&tx, 0, api.s.miner.GetCoinbase(), statedb, block.Header)
+		assert.Equal(t, err, nil)
+		return receipt
+	}
+	receipt := apply(gas)
+	assert.Equal(t, receipt.Failed, false)
+	assert.Equal(t, receipt.UsedGas < gas, true)
+	assert.Equal(t, apply(gas-1).Failed, true)
+
+	// the specified gas limit is not enough, but the search is capped at balance
+	setTx.Data.GasLimit = gas - 1
+	estimated, err := api.EstimateGas(setTx)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, estimated, gas)
+
+	// always reverted
+	revertTx, err := types.NewMessageTransaction(from, revertContract, big.NewInt(0), big.NewInt(1), 500000, 0, []byte{1})
+	assert.Equal(t, err, nil)
+	_, err = api.EstimateGas(revertTx)
+	assert.Equal(t, err.Error(), "execution reverted: revert reason")
+
+	// balance is not enough to pay the intrinsic gas
+	revertTx.Data.GasPrice = common.SeeleToFan
+	_, err = api.EstimateGas(revertTx)
+	assert.Error(t, err)
+}
+
 func Test_GetInfo(t *testing.T) {
 	dbPath := filepath.Join(common.GetTempFolder(), ".GetLogs")
 	api := newTestAPI(t, dbPath)
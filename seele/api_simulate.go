@@ -0,0 +1,234 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seeleteam/go-seele/accounts/abi"
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/state"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/core/vm"
+	"github.com/seeleteam/go-seele/crypto"
+)
+
+const (
+	latestBlock  = "latest"
+	pendingBlock = "pending"
+)
+
+// Simulate executes the call object on the state of the specified block with the state overrides,
+// and returns the result, used gas, logs and the decoded revert reason if reverted. The block could be
+// "latest" (default), "pending", block hash or block height, where the pending state is the HEAD state
+// with the txs in tx pool applied. It does not affect the statedb and blockchain.
+func (api *PublicSeeleAPI) Simulate(args api2.SimulateArgs, block string, overrides map[common.Address]api2.AccountOverride) (map[string]interface{}, error) {
+	header, statedb, err := api.blockState(block)
+	if err != nil {
+		return nil, err
+	}
+
+	for account, override := range overrides {
+		applyOverride(statedb, account, override)
+	}
+
+	coinbase := api.s.miner.GetCoinbase()
+	tx, err := newSimulateTx(&args, statedb, coinbase.Shard())
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := vm.NewCallTracer()
+	vmConfig := &vm.Config{Debug: true, Tracer: tracer}
+	receipt, err := api.s.chain.ApplyTransactionWithVMConfig(tx, 0, header.Creator, statedb, header, vmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := api2.PrintableReceipt(receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason := revertReason(receipt, tracer); len(reason) > 0 {
+		result["revertReason"] = reason
+	}
+
+	return result, nil
+}
+
+// blockState returns the block header and statedb of the specified block, which could be "latest"
+// (default), "pending", block hash or block height.
+func (api *PublicSeeleAPI) blockState(block string) (*types.BlockHeader, *state.Statedb, error) {
+	bcStore := api.s.chain.GetStore()
+
+	var header *types.BlockHeader
+	var err error
+	switch {
+	case len(block) == 0 || block == latestBlock || block == pendingBlock:
+		header = api.s.chain.CurrentHeader()
+	case strings.HasPrefix(block, "0x"):
+		hash, err := common.HexToHash(block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid block hash, %v", err)
+		}
+
+		if header, err = bcStore.GetBlockHeader(hash); err != nil {
+			return nil, nil, errors.NewStackedErrorf(err, "failed to get block header by hash %v", block)
+		}
+	default:
+		height, err := strconv.ParseInt(block, 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid block, should be latest, pending, block hash or height")
+		}
+
+		b, err := getBlock(api.s.chain, height)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		header = b.Header
+	}
+
+	statedb, err := state.NewStatedb(header.StateHash, api.s.accountStateDB)
+	if err != nil {
+		return nil, nil, errors.NewStackedErrorf(err, "failed to get statedb of block %v", header.Height)
+	}
+
+	if block != pendingBlock {
+		return header, statedb, nil
+	}
+
+	return api.pendingState(header, statedb), statedb, nil
+}
+
+// pendingState applies the txs in tx pool on the statedb of HEAD block, and returns
+// the pending block header. Note, the txs that failed to apply are ignored.
+func (api *PublicSeeleAPI) pendingState(head *types.BlockHeader, statedb *state.Statedb) *types.BlockHeader {
+	header := head.Clone()
+	header.PreviousBlockHash = head.Hash()
+	header.Height++
+	header.Creator = api.s.miner.GetCoinbase()
+	header.CreateTimestamp = big.NewInt(time.Now().Unix())
+
+	// txs of the same account are applied in nonce order
+	txs := api.s.txPool.GetTransactions(true, true)
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Data.AccountNonce < txs[j].Data.AccountNonce
+	})
+
+	for i, tx := range txs {
+		if _, err := api.s.chain.ApplyTransaction(tx, i+1, header.Creator, statedb, header); err != nil {
+			api.s.log.Debug("failed to apply pending tx %v for simulation, %v", tx.Hash.Hex(), err)
+		}
+	}
+
+	return header
+}
+
+// applyOverride overrides the state of the specified account.
+func applyOverride(statedb *state.Statedb, account common.Address, override api2.AccountOverride) {
+	if !statedb.Exist(account) {
+		statedb.CreateAccount(account)
+	}
+
+	if override.Balance != nil {
+		statedb.SetBalance(account, override.Balance)
+	}
+
+	if override.Nonce != nil {
+		statedb.SetNonce(account, *override.Nonce)
+	}
+
+	if override.Code != nil {
+		statedb.SetCode(account, *override.Code)
+	}
+
+	for key, value := range override.Storage {
+		statedb.SetData(account, key, value.Bytes())
+	}
+}
+
+// newSimulateTx creates a tx of the call object with the sender nonce in statedb, and
+// the random sender is in the specified shard if not specified.
+func newSimulateTx(args *api2.SimulateArgs, statedb *state.Statedb, shard uint) (*types.Transaction, error) {
+	amount, price := args.Amount, args.GasPrice
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+
+	if price == nil || price.Sign() == 0 {
+		price = big.NewInt(1)
+	}
+
+	from := args.From
+	if from.IsEmpty() {
+		from = *crypto.MustGenerateShardAddress(shard)
+		statedb.CreateAccount(from)
+		statedb.SetBalance(from, new(big.Int).Add(amount, new(big.Int).Mul(price, common.SeeleToFan)))
+	}
+
+	gasLimit := args.GasLimit
+	if gasLimit == 0 {
+		if gasLimit = affordableGas(statedb, from, amount, price); gasLimit == 0 {
+			return nil, errors.New("balance is not enough to pay the gas")
+		}
+	}
+
+	nonce := statedb.GetNonce(from)
+
+	var tx *types.Transaction
+	var err error
+	if args.To.IsEmpty() {
+		tx, err = types.NewContractTransaction(from, amount, price, gasLimit, nonce, args.Payload)
+	} else {
+		tx, err = types.NewMessageTransaction(from, args.To, amount, price, gasLimit, nonce, args.Payload)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction, %v", err)
+	}
+
+	return tx, nil
+}
+
+// affordableGas returns the max gas that the sender could afford with the specified gas price
+// after the amount transferred, capped to 1 Seele in Fan.
+func affordableGas(statedb *state.Statedb, from common.Address, amount, price *big.Int) uint64 {
+	available := new(big.Int).Sub(statedb.GetBalance(from), amount)
+	if available.Sign() <= 0 {
+		return 0
+	}
+
+	gas := available.Div(available, price)
+	if gas.Cmp(common.SeeleToFan) > 0 {
+		return common.SeeleToFan.Uint64()
+	}
+
+	return gas.Uint64()
+}
+
+// revertReason returns the ABI decoded revert reason of the failed tx, or empty if unavailable.
+func revertReason(receipt *types.Receipt, tracer *vm.CallTracer) string {
+	frame := tracer.Result()
+	if !receipt.Failed || frame == nil || len(frame.Output) == 0 {
+		return ""
+	}
+
+	reason, err := abi.UnpackRevert(frame.Output)
+	if err != nil {
+		return ""
+	}
+
+	return reason
+}
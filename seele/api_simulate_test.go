@@ -0,0 +1,83 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// runtime code of contract/solidity/simple_storage.sol, where get() returns the slot 0.
+const testSimpleStorageCode = "0x6080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806360fe47b114604e5780636d4ce63c146078575b600080fd5b348015605957600080fd5b5060766004803603810190808035906020019092919050505060a0565b005b348015608357600080fd5b50608a60aa565b6040518082815260200191505060405180910390f35b8060008190555050565b600080549050905600a165627a7a723058207f6dc43a0d648e9f5a0cad5071cde46657de72eb87ab4cded53a7f1090f51e6d0029"
+
+// runtime code that always reverts with reason "revert reason".
+const testRevertCode = "0x6064600c60003960646000fd08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000d72657665727420726561736f6e00000000000000000000000000000000000000"
+
+func Test_PublicSeeleAPI_Simulate(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".Simulate")
+	api := newTestAPI(t, dbPath)
+	defer func() {
+		api.s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	// genesis timestamp is not specified in test config, and block 1 is not HEAD.
+	api.s.chain.CurrentHeader().CreateTimestamp = big.NewInt(0)
+	statedb, _ := api.s.chain.GetCurrentState()
+	block1 := putTestBlock(t, api, statedb)
+
+	contract := crypto.CreateAddress(api.s.miner.GetCoinbase(), 0)
+	storageCode, _ := hexutil.HexToBytes(testSimpleStorageCode)
+	code := common.Bytes(storageCode)
+	overrides := map[common.Address]api2.AccountOverride{
+		contract: api2.AccountOverride{
+			Code:    &code,
+			Storage: map[common.Hash]common.Hash{common.EmptyHash: common.BigToHash(big.NewInt(7))},
+		},
+	}
+
+	// call get() on latest, pending and block 1
+	getter := api2.SimulateArgs{To: contract, Payload: common.Bytes{0x6d, 0x4c, 0xe6, 0x3c}}
+	for _, block := range []string{"", "latest", "pending", "1", block1.HeaderHash.Hex()} {
+		result, err := api.Simulate(getter, block, overrides)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, result["failed"], false)
+		assert.Equal(t, result["result"], common.BigToHash(big.NewInt(7)).Hex())
+		assert.Equal(t, result["usedGas"].(uint64) > 0, true)
+		assert.Equal(t, result["revertReason"], nil)
+	}
+
+	_, err := api.Simulate(getter, "invalid", overrides)
+	assert.Error(t, err)
+
+	// revert reason decoded
+	revertCode, _ := hexutil.HexToBytes(testRevertCode)
+	code = common.Bytes(revertCode)
+	result, err := api.Simulate(getter, "", overrides)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result["failed"], true)
+	assert.Equal(t, result["revertReason"], "revert reason")
+
+	// sender without balance
+	getter.From = *crypto.MustGenerateRandomAddress()
+	_, err = api.Simulate(getter, "", nil)
+	assert.Error(t, err)
+
+	// balance and nonce overridden
+	nonce := uint64(3)
+	overrides[getter.From] = api2.AccountOverride{Balance: common.SeeleToFan, Nonce: &nonce}
+	result, err = api.Simulate(getter, "", overrides)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result["failed"], true)
+}
@@ -6,6 +6,9 @@
 package seele
 
 import (
+	"fmt"
+	"math/big"
+
 	api2 "github.com/seeleteam/go-seele/api"
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/common/hexutil"
@@ -27,6 +30,48 @@ func (api *TransactionPoolAPI) GetPendingDebts() ([]*types.Debt, error) {
 	return api.s.DebtPool().GetDebts(false, true), nil
 }
 
+// ReplaceTransaction replaces the pending or queued tx with the same nonce in tx pool by the specified tx,
+// which requires the gas price bumped enough.
+func (api *TransactionPoolAPI) ReplaceTransaction(tx types.Transaction) (bool, error) {
+	shard := tx.Data.From.Shard()
+	if shard != common.LocalShardNumber {
+		return false, fmt.Errorf("local shard is: %d, your shard is: %d, you need to change to shard %d to replace your transaction", common.LocalShardNumber, shard, shard)
+	}
+
+	if err := api.s.txPool.ReplaceTransaction(&tx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetCancelTransaction returns the unsigned tx to cancel the specified pending or queued tx in tx pool,
+// which is a zero-value transfer to sender itself with the same nonce and the minimum replacement gas
+// price. It should be signed by sender and sent via ReplaceTransaction.
+func (api *TransactionPoolAPI) GetCancelTransaction(txHash string) (*types.TransactionData, error) {
+	hash, err := common.HexToHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := api.s.txPool.GetTransaction(hash)
+	if tx == nil || api.s.txPool.GetReplaceableTransaction(tx.Data.From, tx.Data.AccountNonce) != tx {
+		return nil, fmt.Errorf("transaction %v not found in pending or queued transactions", txHash)
+	}
+
+	cancel, err := types.NewTransaction(tx.Data.From, tx.Data.From, big.NewInt(0), api.s.txPool.ReplacementPrice(tx.Data.GasPrice), tx.Data.AccountNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cancel.Data, nil
+}
+
+// GetPriceHistogram returns the number of pending txs in pool per gas price in ascending order.
+func (api *TransactionPoolAPI) GetPriceHistogram() []api2.PriceBucket {
+	return api.s.gasPriceOracle.priceHistogram()
+}
+
 // GetDebtByHash return the debt info by debt hash
 func (api *TransactionPoolAPI) GetDebtByHash(debtHash string) (map[string]interface{}, error) {
 	hashByte, err := hexutil.HexToBytes(debtHash)
@@ -28,6 +28,12 @@ const (
 
 	// BlockChainRecoveryPointFile is used to store the recovery point info of blockchain.
 	BlockChainRecoveryPointFile = "recoveryPoint.json"
+
+	// TxJournalFile is used to persist the txs in tx pool across restarts.
+	TxJournalFile = "transactions.rlp"
+
+	// DebtJournalFile is used to persist the in-flight debts in debt pool across restarts.
+	DebtJournalFile = "debts.rlp"
 )
 
 // statusData the structure for peers to exchange status
@@ -0,0 +1,295 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"sync"
+
+	"github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/store"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/event"
+	"github.com/seeleteam/go-seele/log"
+)
+
+const (
+	// filterEventBuffSize is the buffer size of events to dispatch.
+	filterEventBuffSize = 1024
+
+	// subscriptionBuffSize is the buffer size of notifications for a subscription.
+	// Notifications are dropped if the subscriber cannot keep up.
+	subscriptionBuffSize = 1024
+
+	// maxNewHeadsBackfill is the max number of blocks to walk back on both the old and new branches
+	// for a new HEAD block, e.g. the HEAD block changed to another fork.
+	maxNewHeadsBackfill = 64
+)
+
+type subscriptionKind byte
+
+const (
+	headsSubscription subscriptionKind = iota
+	logsSubscription
+	pendingTxsSubscription
+	debtsSubscription
+	syncingSubscription
+	replacedTxsSubscription
+)
+
+// filterChain is the blockchain interface required by filter system.
+type filterChain interface {
+	CurrentHeader() *types.BlockHeader
+	GetStore() store.BlockchainStore
+}
+
+// subscription receives the notifications of a specified kind from filter system.
+type subscription struct {
+	kind    subscriptionKind
+	filter  *api.LogFilter // only for logs subscription
+	decoder api.LogDecoder
+	ch      chan interface{}
+}
+
+// filterSystem dispatches chain, txpool and downloader events to subscriptions.
+type filterSystem struct {
+	chain filterChain
+	log   *log.SeeleLog
+
+	lock sync.RWMutex
+	subs map[*subscription]struct{}
+
+	eventCh  chan event.Event
+	quit     chan struct{}
+	lastHead *types.BlockHeader // the last HEAD block header notified
+}
+
+func newFilterSystem(chain filterChain, log *log.SeeleLog) *filterSystem {
+	return &filterSystem{
+		chain:    chain,
+		log:      log,
+		subs:     make(map[*subscription]struct{}),
+		eventCh:  make(chan event.Event, filterEventBuffSize),
+		quit:     make(chan struct{}),
+		lastHead: chain.CurrentHeader(),
+	}
+}
+
+// Start registers event listeners and starts to dispatch events.
+func (fs *filterSystem) Start() {
+	event.ChainHeaderChangedEventMananger.AddListener(fs.onEvent)
+	event.TransactionInsertedEventManager.AddListener(fs.onEvent)
+	event.TransactionReplacedEventManager.AddListener(fs.onEvent)
+	event.DebtsInsertedEventManager.AddListener(fs.onEvent)
+	event.BlockDownloaderEventManager.AddListener(fs.onEvent)
+
+	go fs.loop()
+}
+
+// Stop removes event listeners and stops to dispatch events.
+func (fs *filterSystem) Stop() {
+	event.ChainHeaderChangedEventMananger.RemoveListener(fs.onEvent)
+	event.TransactionInsertedEventManager.RemoveListener(fs.onEvent)
+	event.TransactionReplacedEventManager.RemoveListener(fs.onEvent)
+	event.DebtsInsertedEventManager.RemoveListener(fs.onEvent)
+	event.BlockDownloaderEventManager.RemoveListener(fs.onEvent)
+
+	close(fs.quit)
+}
+
+// subscribe creates a subscription of the specified kind, and the filter is only for logs subscription.
+func (fs *filterSystem) subscribe(kind subscriptionKind, filter *api.LogFilter) (*subscription, error) {
+	sub := &subscription{
+		kind:   kind,
+		filter: filter,
+		ch:     make(chan interface{}, subscriptionBuffSize),
+	}
+
+	if filter != nil {
+		decoder, err := api.NewLogDecoder(filter.AbiJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		sub.decoder = decoder
+	}
+
+	fs.lock.Lock()
+	fs.subs[sub] = struct{}{}
+	fs.lock.Unlock()
+
+	return sub, nil
+}
+
+// unsubscribe removes the specified subscription.
+func (fs *filterSystem) unsubscribe(sub *subscription) {
+	fs.lock.Lock()
+	delete(fs.subs, sub)
+	fs.lock.Unlock()
+}
+
+// onEvent handles events synchronously in order, so it only queues events to dispatch.
+func (fs *filterSystem) onEvent(e event.Event) {
+	select {
+	case fs.eventCh <- e:
+	default:
+		fs.log.Warn("filter system event queue is full, event dropped")
+	}
+}
+
+func (fs *filterSystem) loop() {
+	for {
+		select {
+		case e := <-fs.eventCh:
+			fs.dispatch(e)
+		case <-fs.quit:
+			return
+		}
+	}
+}
+
+func (fs *filterSystem) dispatch(e event.Event) {
+	switch v := e.(type) {
+	case *types.Block:
+		if v == nil || v.Header == nil {
+			return
+		}
+
+		reverted, added := fs.reorg(v.Header)
+		for _, header := range reverted {
+			fs.notifyLogs(header, true)
+		}
+
+		for _, header := range added {
+			fs.notifyHead(header)
+		}
+	case *types.Transaction:
+		fs.notify(pendingTxsSubscription, v.Hash.Hex())
+	case *core.TransactionReplaced:
+		fs.notify(replacedTxsSubscription, &api.ReplacedTransaction{Hash: v.Old.Hash, Replacement: v.New.Hash})
+	case *types.Debt:
+		fs.notify(debtsSubscription, v.Hash.Hex())
+	case int:
+		fs.notifySyncing(v)
+	}
+}
+
+// reorg walks back from the last HEAD block notified and the specified new HEAD block to their
+// common ancestor, and returns the headers of blocks reverted from the canonical chain in descending
+// order of height, and the headers of new canonical blocks in ascending order of height. At most
+// maxNewHeadsBackfill blocks are walked back on each branch.
+func (fs *filterSystem) reorg(head *types.BlockHeader) (reverted, added []*types.BlockHeader) {
+	oldHeader, newHeader := fs.lastHead, head
+	fs.lastHead = head
+
+	if oldHeader == nil {
+		return nil, []*types.BlockHeader{head}
+	}
+
+	bcStore := fs.chain.GetStore()
+	for oldHeader.Hash() != newHeader.Hash() {
+		if len(reverted) >= maxNewHeadsBackfill || len(added) >= maxNewHeadsBackfill {
+			fs.log.Warn("common ancestor not found in %v blocks for new HEAD block %v", maxNewHeadsBackfill, head.Hash().Hex())
+			break
+		}
+
+		var err error
+		if newHeader.Height >= oldHeader.Height {
+			added = append(added, newHeader)
+			newHeader, err = bcStore.GetBlockHeader(newHeader.PreviousBlockHash)
+		} else {
+			reverted = append(reverted, oldHeader)
+			oldHeader, err = bcStore.GetBlockHeader(oldHeader.PreviousBlockHash)
+		}
+
+		if err != nil {
+			fs.log.Warn("failed to get parent block header for new HEAD block %v, %v", head.Hash().Hex(), err)
+			break
+		}
+	}
+
+	// reverse the new canonical headers in ascending order
+	for i, j := 0, len(added)-1; i < j; i, j = i+1, j-1 {
+		added[i], added[j] = added[j], added[i]
+	}
+
+	return reverted, added
+}
+
+func (fs *filterSystem) notifyHead(header *types.BlockHeader) {
+	fs.notify(headsSubscription, map[string]interface{}{
+		"header": header,
+		"hash":   header.Hash().Hex(),
+	})
+
+	fs.notifyLogs(header, false)
+}
+
+// notifyLogs sends the matched logs in the specified block to logs subscriptions, and the logs
+// are marked as removed if the block is reverted from the canonical chain.
+func (fs *filterSystem) notifyLogs(header *types.BlockHeader, removed bool) {
+	hash := header.Hash()
+
+	fs.lock.RLock()
+	defer fs.lock.RUnlock()
+
+	for sub := range fs.subs {
+		if sub.kind != logsSubscription {
+			continue
+		}
+
+		logs, err := api.BlockLogs(fs.chain.GetStore(), hash, header.Height, sub.filter, sub.decoder)
+		if err != nil {
+			fs.log.Warn("failed to filter logs in block %v, %v", hash.Hex(), err)
+			continue
+		}
+
+		for _, log := range logs {
+			log.Removed = removed
+			fs.send(sub, log)
+		}
+	}
+}
+
+func (fs *filterSystem) notifySyncing(e int) {
+	status := api.SyncingStatus{
+		Syncing:      e == event.DownloaderStartEvent,
+		CurrentBlock: fs.chain.CurrentHeader().Height,
+	}
+
+	switch e {
+	case event.DownloaderStartEvent:
+		status.Status = "started"
+	case event.DownloaderDoneEvent:
+		status.Status = "done"
+	case event.DownloaderFailedEvent:
+		status.Status = "failed"
+	default:
+		return
+	}
+
+	fs.notify(syncingSubscription, &status)
+}
+
+// notify sends the data to all subscriptions of the specified kind.
+func (fs *filterSystem) notify(kind subscriptionKind, data interface{}) {
+	fs.lock.RLock()
+	defer fs.lock.RUnlock()
+
+	for sub := range fs.subs {
+		if sub.kind == kind {
+			fs.send(sub, data)
+		}
+	}
+}
+
+func (fs *filterSystem) send(sub *subscription, data interface{}) {
+	select {
+	case sub.ch <- data:
+	default:
+		fs.log.Debug("subscription notification queue is full, notification dropped")
+	}
+}
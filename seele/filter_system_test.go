@@ -0,0 +1,205 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/event"
+	"github.com/seeleteam/go-seele/log"
+	"github.com/seeleteam/go-seele/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestFilterSystem returns a filter system with chain of api.NewTestFilterChain, and HEAD at the specified height.
+func newTestFilterSystem(head uint64) (*filterSystem, *api.TestFilterChain, func()) {
+	chain, dispose := api.NewTestFilterChain(head)
+	return newFilterSystem(chain, log.GetLogger("filter")), chain, dispose
+}
+
+func receiveNotifications(sub *subscription) []interface{} {
+	var result []interface{}
+	for {
+		select {
+		case data := <-sub.ch:
+			result = append(result, data)
+		default:
+			return result
+		}
+	}
+}
+
+func Test_FilterSystem_NewHeads(t *testing.T) {
+	fs, chain, dispose := newTestFilterSystem(1)
+	defer dispose()
+
+	heads, _ := fs.subscribe(headsSubscription, nil)
+	logs, _ := fs.subscribe(logsSubscription, &api.LogFilter{Addresses: []common.Address{api.TestFilterAddr2}})
+
+	// new HEAD block 2
+	fs.dispatch(chain.GetBlock(2))
+	notifications := receiveNotifications(heads)
+	assert.Equal(t, len(notifications), 1)
+	assert.Equal(t, notifications[0].(map[string]interface{})["header"].(*types.BlockHeader).Height, uint64(2))
+
+	notifications = receiveNotifications(logs)
+	assert.Equal(t, len(notifications), 1)
+	assert.Equal(t, notifications[0].(*api.FilteredLog).Address, api.TestFilterAddr2)
+	assert.Equal(t, notifications[0].(*api.FilteredLog).BlockNumber, uint64(2))
+
+	// new HEAD block 4, and block 3 not notified before
+	fs.dispatch(chain.GetBlock(4))
+	notifications = receiveNotifications(heads)
+	assert.Equal(t, len(notifications), 2)
+	assert.Equal(t, notifications[0].(map[string]interface{})["header"].(*types.BlockHeader).Height, uint64(3))
+	assert.Equal(t, notifications[1].(map[string]interface{})["header"].(*types.BlockHeader).Height, uint64(4))
+
+	notifications = receiveNotifications(logs)
+	assert.Equal(t, len(notifications), 1)
+	assert.Equal(t, notifications[0].(*api.FilteredLog).BlockNumber, uint64(4))
+
+	// unsubscribed
+	fs.unsubscribe(heads)
+	fs.unsubscribe(logs)
+	fs.dispatch(chain.GetBlock(3))
+	assert.Equal(t, len(receiveNotifications(heads)), 0)
+	assert.Equal(t, len(receiveNotifications(logs)), 0)
+}
+
+func Test_FilterSystem_Reorg(t *testing.T) {
+	fs, chain, dispose := newTestFilterSystem(4)
+	defer dispose()
+
+	heads, _ := fs.subscribe(headsSubscription, nil)
+	logs, _ := fs.subscribe(logsSubscription, &api.LogFilter{})
+
+	// fork at block 2 with the same height, blocks 3 and 4 reverted
+	reverted := chain.Fork(2, &types.Log{Address: api.TestFilterAddr1}, &types.Log{Address: api.TestFilterAddr2})
+	fs.dispatch(&types.Block{HeaderHash: chain.CurrentHeader().Hash(), Header: chain.CurrentHeader()})
+
+	notifications := receiveNotifications(heads)
+	assert.Equal(t, len(notifications), 2)
+	assert.Equal(t, notifications[0].(map[string]interface{})["hash"], chain.GetBlock(3).HeaderHash.Hex())
+	assert.Equal(t, notifications[1].(map[string]interface{})["hash"], chain.GetBlock(4).HeaderHash.Hex())
+
+	// removed logs of the old branch in descending order, and then logs of the new branch
+	notifications = receiveNotifications(logs)
+	assert.Equal(t, len(notifications), 4)
+	expected := []struct {
+		hash    common.Hash
+		removed bool
+	}{
+		{reverted[1], true},
+		{reverted[0], true},
+		{chain.GetBlock(3).HeaderHash, false},
+		{chain.GetBlock(4).HeaderHash, false},
+	}
+
+	for i, e := range expected {
+		log := notifications[i].(*api.FilteredLog)
+		assert.Equal(t, log.BlockHash, e.hash)
+		assert.Equal(t, log.Removed, e.removed)
+	}
+
+	// fork at block 1 with a lower height, blocks 2, 3 and 4 reverted
+	reverted = chain.Fork(1, &types.Log{Address: api.TestFilterAddr1})
+	fs.dispatch(&types.Block{HeaderHash: chain.CurrentHeader().Hash(), Header: chain.CurrentHeader()})
+
+	notifications = receiveNotifications(heads)
+	assert.Equal(t, len(notifications), 1)
+	assert.Equal(t, notifications[0].(map[string]interface{})["hash"], chain.GetBlock(2).HeaderHash.Hex())
+
+	notifications = receiveNotifications(logs)
+	assert.Equal(t, len(notifications), 4)
+	for i, hash := range reverted {
+		log := notifications[len(reverted)-1-i].(*api.FilteredLog)
+		assert.Equal(t, log.BlockHash, hash)
+		assert.Equal(t, log.Removed, true)
+	}
+
+	assert.Equal(t, notifications[3].(*api.FilteredLog).BlockHash, chain.GetBlock(2).HeaderHash)
+	assert.Equal(t, notifications[3].(*api.FilteredLog).Removed, false)
+}
+
+func Test_FilterSystem_PoolAndSyncing(t *testing.T) {
+	fs, chain, dispose := newTestFilterSystem(4)
+	defer dispose()
+
+	txs, _ := fs.subscribe(pendingTxsSubscription, nil)
+	debts, _ := fs.subscribe(debtsSubscription, nil)
+	syncing, _ := fs.subscribe(syncingSubscription, nil)
+	replaced, _ := fs.subscribe(replacedTxsSubscription, nil)
+
+	tx := types.NewTestTransaction()
+	fs.dispatch(tx)
+	assert.Equal(t, receiveNotifications(txs), []interface{}{tx.Hash.Hex()})
+	assert.Equal(t, len(receiveNotifications(debts)), 0)
+
+	replacement := types.NewTestTransaction()
+	fs.dispatch(&core.TransactionReplaced{Old: tx, New: replacement})
+	assert.Equal(t, receiveNotifications(replaced), []interface{}{&api.ReplacedTransaction{Hash: tx.Hash, Replacement: replacement.Hash}})
+	assert.Equal(t, len(receiveNotifications(txs)), 0)
+
+	debt := types.NewTestDebt()
+	fs.dispatch(debt)
+	assert.Equal(t, receiveNotifications(debts), []interface{}{debt.Hash.Hex()})
+	assert.Equal(t, len(receiveNotifications(txs)), 0)
+
+	fs.dispatch(event.DownloaderStartEvent)
+	fs.dispatch(event.DownloaderDoneEvent)
+	assert.Equal(t, receiveNotifications(syncing), []interface{}{
+		&api.SyncingStatus{Syncing: true, Status: "started", CurrentBlock: chain.CurrentHeader().Height},
+		&api.SyncingStatus{Syncing: false, Status: "done", CurrentBlock: chain.CurrentHeader().Height},
+	})
+
+	// invalid ABI for logs subscription
+	_, err := fs.subscribe(logsSubscription, &api.LogFilter{AbiJSON: "invalid"})
+	assert.Error(t, err)
+}
+
+func Test_PublicFilterAPI_Subscribe(t *testing.T) {
+	fs, chain, dispose := newTestFilterSystem(1)
+	defer dispose()
+
+	fs.Start()
+	defer fs.Stop()
+
+	server := rpc.NewServer()
+	assert.Nil(t, server.RegisterName("seele", NewPublicFilterAPI(fs)))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ch := make(chan json.RawMessage, 1)
+	sub, err := client.Subscribe(context.Background(), "seele", ch, "newHeads")
+	assert.Equal(t, err, nil)
+	defer sub.Unsubscribe()
+
+	// wait for the subscription activated
+	time.Sleep(100 * time.Millisecond)
+
+	block := chain.GetBlock(2)
+	fs.onEvent(block)
+
+	select {
+	case data := <-ch:
+		var head map[string]interface{}
+		assert.Nil(t, json.Unmarshal(data, &head))
+		assert.Equal(t, head["hash"], block.HeaderHash.Hex())
+	case <-time.After(5 * time.Second):
+		t.Fatal("new head not notified")
+	}
+
+	// notifications unsupported in HTTP or inproc call without subscription
+	_, err = NewPublicFilterAPI(fs).NewHeads(context.Background())
+	assert.Equal(t, err, rpc.ErrNotificationsUnsupported)
+}
@@ -0,0 +1,155 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+const (
+	// DefaultGasPriceBlocks is the default number of recent blocks sampled by gas price oracle.
+	DefaultGasPriceBlocks = 20
+
+	// DefaultGasPricePercentile is the default percentile of sampled prices suggested by gas price oracle.
+	DefaultGasPricePercentile = 60
+)
+
+// minGasPrice is the price suggested if no tx sampled, which is the minimum price accepted by tx pool.
+var minGasPrice = big.NewInt(1)
+
+// gasPriceOracle suggests the gas price to get txs included in time, based on the gas prices of
+// txs in the recent blocks and the pending txs in tx pool. The suggestion is cached per chain head.
+type gasPriceOracle struct {
+	chain      *core.Blockchain
+	pool       *core.TransactionPool
+	blocks     int
+	percentile int
+
+	mutex     sync.Mutex
+	lastHead  common.Hash
+	lastPrice *big.Int
+}
+
+func newGasPriceOracle(chain *core.Blockchain, pool *core.TransactionPool, blocks, percentile int) *gasPriceOracle {
+	if blocks <= 0 {
+		blocks = DefaultGasPriceBlocks
+	}
+
+	if percentile <= 0 || percentile > 100 {
+		percentile = DefaultGasPricePercentile
+	}
+
+	return &gasPriceOracle{
+		chain:      chain,
+		pool:       pool,
+		blocks:     blocks,
+		percentile: percentile,
+	}
+}
+
+// suggestPrice returns the percentile of gas prices of txs in the recent blocks, and raises it to
+// the lowest price that fits into the next block if the pending txs in pool exceed a block.
+func (oracle *gasPriceOracle) suggestPrice() (*big.Int, error) {
+	head := oracle.chain.CurrentBlock()
+
+	oracle.mutex.Lock()
+	defer oracle.mutex.Unlock()
+
+	if oracle.lastPrice != nil && oracle.lastHead == head.HeaderHash {
+		return new(big.Int).Set(oracle.lastPrice), nil
+	}
+
+	var prices []*big.Int
+	for i, block := 0, head; i < oracle.blocks; i++ {
+		for _, tx := range block.GetExcludeRewardTransactions() {
+			prices = append(prices, tx.Data.GasPrice)
+		}
+
+		if block.Header.Height == 0 {
+			break
+		}
+
+		var err error
+		if block, err = oracle.chain.GetStore().GetBlock(block.Header.PreviousBlockHash); err != nil {
+			return nil, err
+		}
+	}
+
+	price := minGasPrice
+	if len(prices) > 0 {
+		sortPrices(prices)
+		price = prices[(len(prices)-1)*oracle.percentile/100]
+	}
+
+	if pending := oracle.pool.GetTransactions(false, true); len(pending) > 0 {
+		if competitive := competitivePrice(pending); competitive != nil && competitive.Cmp(price) > 0 {
+			price = competitive
+		}
+	}
+
+	oracle.lastHead, oracle.lastPrice = head.HeaderHash, new(big.Int).Set(price)
+
+	return new(big.Int).Set(price), nil
+}
+
+// priceHistogram returns the number of pending txs in pool per gas price in ascending order.
+func (oracle *gasPriceOracle) priceHistogram() []api2.PriceBucket {
+	return priceHistogram(oracle.pool.GetTransactions(false, true))
+}
+
+// competitivePrice returns the lowest price of pending txs that fits into the next block, or nil
+// if all pending txs fit.
+func competitivePrice(pending []*types.Transaction) *big.Int {
+	txsPerBlock := core.BlockByteLimit / types.TransactionPreSize
+	if len(pending) <= txsPerBlock {
+		return nil
+	}
+
+	prices := make([]*big.Int, len(pending))
+	for i, tx := range pending {
+		prices[i] = tx.Data.GasPrice
+	}
+
+	sortPrices(prices)
+
+	return prices[len(prices)-txsPerBlock]
+}
+
+func priceHistogram(txs []*types.Transaction) []api2.PriceBucket {
+	counts := make(map[string]*api2.PriceBucket)
+	for _, tx := range txs {
+		key := tx.Data.GasPrice.String()
+		if bucket := counts[key]; bucket != nil {
+			bucket.Count++
+		} else {
+			counts[key] = &api2.PriceBucket{Price: new(big.Int).Set(tx.Data.GasPrice), Count: 1}
+		}
+	}
+
+	histogram := make([]api2.PriceBucket, 0, len(counts))
+	for _, bucket := range counts {
+		histogram = append(histogram, *bucket)
+	}
+
+	sort.Slice(histogram, func(i, j int) bool {
+		return histogram[i].Price.Cmp(histogram[j].Price) < 0
+	})
+
+	return histogram
+}
+
+func sortPrices(prices []*big.Int) {
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+}
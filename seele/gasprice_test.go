@@ -0,0 +1,74 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api2 "github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPriceTxs(prices ...int64) []*types.Transaction {
+	var txs []*types.Transaction
+	for _, price := range prices {
+		txs = append(txs, &types.Transaction{Data: types.TransactionData{GasPrice: big.NewInt(price)}})
+	}
+
+	return txs
+}
+
+func Test_priceHistogram(t *testing.T) {
+	assert.Equal(t, len(priceHistogram(nil)), 0)
+
+	histogram := priceHistogram(newTestPriceTxs(5, 1, 5, 10, 1, 5))
+	assert.Equal(t, histogram, []api2.PriceBucket{
+		{Price: big.NewInt(1), Count: 2},
+		{Price: big.NewInt(5), Count: 3},
+		{Price: big.NewInt(10), Count: 1},
+	})
+}
+
+func Test_competitivePrice(t *testing.T) {
+	txsPerBlock := core.BlockByteLimit / types.TransactionPreSize
+
+	// all pending txs fit into a block
+	assert.Nil(t, competitivePrice(newTestPriceTxs(make([]int64, txsPerBlock)...)))
+
+	prices := make([]int64, txsPerBlock+2)
+	for i := range prices {
+		prices[i] = int64(i + 1)
+	}
+
+	assert.Equal(t, competitivePrice(newTestPriceTxs(prices...)), big.NewInt(3))
+}
+
+func Test_PublicSeeleAPI_SuggestGasPrice(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".SuggestGasPrice")
+	defer os.RemoveAll(dbPath)
+
+	api := newTestAPI(t, dbPath)
+	defer api.s.Stop()
+
+	// no tx in genesis block
+	price, err := api.SuggestGasPrice()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, price, minGasPrice)
+
+	// cached per head
+	api.s.gasPriceOracle.lastPrice = big.NewInt(8)
+	price, err = api.SuggestGasPrice()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, price, big.NewInt(8))
+
+	assert.Equal(t, len(NewTransactionPoolAPI(api.s).GetPriceHistogram()), 0)
+}
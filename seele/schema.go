@@ -0,0 +1,14 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seele
+
+import "github.com/seeleteam/go-seele/database/migration"
+
+// DebtManagerSchema is the schema of debt manager database.
+// Append migration here when the key layout changed.
+var DebtManagerSchema = migration.NewSchema("debt manager",
+	&migration.Migration{Version: 1, Description: "initial versioned layout"},
+)
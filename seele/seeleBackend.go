@@ -1,6 +1,7 @@
 package seele
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/seeleteam/go-seele/api"
@@ -81,11 +82,16 @@ func (sd *SeeleBackend) GetBlockTotalDifficulty(hash common.Hash) (*big.Int, err
 	return store.GetBlockTotalDifficulty(hash)
 }
 
-// GetReceiptByTxHash get receipt by transaction hash
+// GetReceiptByTxHash get receipt by transaction hash, and returns error with the replacement if the
+// transaction was replaced in tx pool recently.
 func (sd *SeeleBackend) GetReceiptByTxHash(hash common.Hash) (*types.Receipt, error) {
 	store := sd.s.chain.GetStore()
 	receipt, err := store.GetReceiptByTxHash(hash)
 	if err != nil {
+		if replacement, ok := sd.s.txPool.GetReplacement(hash); ok {
+			return nil, fmt.Errorf("transaction replaced by %v", replacement.Hex())
+		}
+
 		return nil, err
 	}
 	return receipt, nil
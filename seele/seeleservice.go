@@ -14,10 +14,12 @@ import (
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/consensus"
 	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/state"
 	"github.com/seeleteam/go-seele/core/store"
 	"github.com/seeleteam/go-seele/core/types"
 	"github.com/seeleteam/go-seele/database"
 	"github.com/seeleteam/go-seele/database/leveldb"
+	"github.com/seeleteam/go-seele/database/migration"
 	"github.com/seeleteam/go-seele/event"
 	"github.com/seeleteam/go-seele/log"
 	"github.com/seeleteam/go-seele/miner"
@@ -48,11 +50,15 @@ type SeeleService struct {
 	debtManagerDB      database.Database // database used to store debts in debt manager.
 	debtManagerDBPath  string
 	miner              *miner.Miner
+	filterSystem       *filterSystem
+	gasPriceOracle     *gasPriceOracle
 
 	lastHeader               common.Hash
 	chainHeaderChangeChannel chan common.Hash
 
 	debtVerifier types.DebtVerifier
+
+	ethChainID uint64 // Ethereum-compatible namespaces are disabled if 0
 }
 
 // ServiceContext is a collection of service configuration inherited from node
@@ -96,24 +102,27 @@ func NewSeeleService(ctx context.Context, conf *node.Config, log *log.SeeleLog,
 		networkID:    conf.P2PConfig.NetworkID,
 		netVersion:   conf.BasicConfig.Version,
 		debtVerifier: verifier,
+		ethChainID:   conf.BasicConfig.EthChainID,
 	}
 
 	serviceContext := ctx.Value("ServiceContext").(ServiceContext)
 
 	// Initialize blockchain DB.
-	if err = s.initBlockchainDB(&serviceContext); err != nil {
+	if err = s.initBlockchainDB(&serviceContext, conf); err != nil {
 		return nil, err
 	}
 
-	leveldb.StartMetrics(s.chainDB, "chaindb", log)
+	if !conf.BasicConfig.IsMemoryMode() {
+		leveldb.StartMetrics(s.chainDB, "chaindb", log)
+	}
 
 	// Initialize account state info DB.
-	if err = s.initAccountStateDB(&serviceContext); err != nil {
+	if err = s.initAccountStateDB(&serviceContext, conf); err != nil {
 		return nil, err
 	}
 
 	// Initialize debt manager DB.
-	if err = s.initDebtManagerDB(&serviceContext); err != nil {
+	if err = s.initDebtManagerDB(&serviceContext, conf); err != nil {
 		return nil, err
 	}
 
@@ -124,7 +133,7 @@ func NewSeeleService(ctx context.Context, conf *node.Config, log *log.SeeleLog,
 		return nil, err
 	}
 
-	if err = s.initPool(conf); err != nil {
+	if err = s.initPool(&serviceContext, conf); err != nil {
 		return nil, err
 	}
 
@@ -134,49 +143,75 @@ func NewSeeleService(ctx context.Context, conf *node.Config, log *log.SeeleLog,
 		return nil, err
 	}
 
+	s.filterSystem = newFilterSystem(s.chain, log)
+	s.filterSystem.Start()
+
 	return s, nil
 }
 
-func (s *SeeleService) initBlockchainDB(serviceContext *ServiceContext) (err error) {
+func (s *SeeleService) initBlockchainDB(serviceContext *ServiceContext, conf *node.Config) (err error) {
 	s.chainDBPath = filepath.Join(serviceContext.DataDir, BlockChainDir)
 	s.log.Info("NewSeeleService BlockChain datadir is %s", s.chainDBPath)
 
-	if s.chainDB, err = leveldb.NewLevelDB(s.chainDBPath); err != nil {
+	if s.chainDB, err = conf.BasicConfig.OpenDatabase(s.chainDBPath); err != nil {
 		s.log.Error("NewSeeleService Create BlockChain err. %s", err)
 		return err
 	}
 
-	return nil
+	return s.migrateDatabase(s.chainDB, store.ChainSchema)
 }
 
-func (s *SeeleService) initAccountStateDB(serviceContext *ServiceContext) (err error) {
+func (s *SeeleService) initAccountStateDB(serviceContext *ServiceContext, conf *node.Config) (err error) {
 	s.accountStateDBPath = filepath.Join(serviceContext.DataDir, AccountStateDir)
 	s.log.Info("NewSeeleService account state datadir is %s", s.accountStateDBPath)
 
-	if s.accountStateDB, err = leveldb.NewLevelDB(s.accountStateDBPath); err != nil {
+	if s.accountStateDB, err = conf.BasicConfig.OpenDatabase(s.accountStateDBPath); err != nil {
 		s.Stop()
 		s.log.Error("NewSeeleService Create BlockChain err: failed to create account state DB, %s", err)
 		return err
 	}
 
-	return nil
+	return s.migrateDatabase(s.accountStateDB, state.AccountStateSchema)
 }
 
-func (s *SeeleService) initDebtManagerDB(serviceContext *ServiceContext) (err error) {
+func (s *SeeleService) initDebtManagerDB(serviceContext *ServiceContext, conf *node.Config) (err error) {
 	s.debtManagerDBPath = filepath.Join(serviceContext.DataDir, DebtManagerDir)
 	s.log.Info("NewSeeleService debt manager datadir is %s", s.debtManagerDBPath)
 
-	if s.debtManagerDB, err = leveldb.NewLevelDB(s.debtManagerDBPath); err != nil {
+	if s.debtManagerDB, err = conf.BasicConfig.OpenDatabase(s.debtManagerDBPath); err != nil {
 		s.Stop()
 		s.log.Error("NewSeeleService Create BlockChain err: failed to create debt manager DB, %s", err)
 		return err
 	}
 
+	return s.migrateDatabase(s.debtManagerDB, DebtManagerSchema)
+}
+
+// migrateDatabase upgrades the database to the latest schema version, and refuses
+// to start if the schema version is unknown.
+func (s *SeeleService) migrateDatabase(db database.Database, schema *migration.Schema) error {
+	applied, err := schema.Migrate(db)
+	if err != nil {
+		s.Stop()
+		s.log.Error("NewSeeleService failed to migrate %v database, %s", schema.Name(), err)
+		return err
+	}
+
+	if applied > 0 {
+		s.log.Info("NewSeeleService %v database migrated to schema version %v", schema.Name(), schema.LatestVersion())
+	}
+
 	return nil
 }
 
 func (s *SeeleService) initGenesisAndChain(serviceContext *ServiceContext, conf *node.Config, startHeight int) (err error) {
-	bcStore := store.NewCachedStore(store.NewBlockchainDatabase(s.chainDB))
+	var bcStore store.BlockchainStore
+	if conf.BasicConfig.AccountHistory {
+		bcStore = store.NewCachedStore(store.NewBlockchainDatabaseWithAccountHistory(s.chainDB))
+	} else {
+		bcStore = store.NewCachedStore(store.NewBlockchainDatabase(s.chainDB))
+	}
+
 	genesis := core.GetGenesis(&conf.SeeleConfig.GenesisConfig)
 
 	if err = genesis.InitializeAndValidate(bcStore, s.accountStateDB); err != nil {
@@ -185,25 +220,63 @@ func (s *SeeleService) initGenesisAndChain(serviceContext *ServiceContext, conf
 		return err
 	}
 
-	recoveryPointFile := filepath.Join(serviceContext.DataDir, BlockChainRecoveryPointFile)
+	// recovery point is useless if nothing persisted.
+	recoveryPointFile := ""
+	if !conf.BasicConfig.IsMemoryMode() {
+		recoveryPointFile = filepath.Join(serviceContext.DataDir, BlockChainRecoveryPointFile)
+	}
+
 	if s.chain, err = core.NewBlockchain(bcStore, s.accountStateDB, recoveryPointFile, s.miner.GetEngine(), s.debtVerifier, startHeight); err != nil {
 		s.Stop()
 		s.log.Error("failed to init chain in NewSeeleService. %s", err)
 		return err
 	}
 
+	switch conf.BasicConfig.StateGCMode {
+	case "", common.StateGCModeArchive:
+	case common.StateGCModePrune:
+		s.chain.EnableStatePruning(conf.BasicConfig.GetStateRetained())
+		s.log.Info("NewSeeleService state pruning enabled, retained %v confirmed states", conf.BasicConfig.GetStateRetained())
+	default:
+		s.Stop()
+		return fmt.Errorf("unknown state gc mode %v", conf.BasicConfig.StateGCMode)
+	}
+
 	return nil
 }
 
-func (s *SeeleService) initPool(conf *node.Config) (err error) {
+func (s *SeeleService) initPool(serviceContext *ServiceContext, conf *node.Config) (err error) {
 	if s.lastHeader, err = s.chain.GetStore().GetHeadBlockHash(); err != nil {
 		s.Stop()
 		return fmt.Errorf("failed to get chain header, %s", err)
 	}
 
 	s.chainHeaderChangeChannel = make(chan common.Hash, chainHeaderChangeBuffSize)
+	txConf := conf.SeeleConfig.TxConf
+	txConf.Locals = append(txConf.Locals, conf.BasicConfig.TxLocals...)
+	if conf.BasicConfig.TxPriceLimit > 0 {
+		txConf.PriceLimit = conf.BasicConfig.TxPriceLimit
+	}
+
+	if conf.BasicConfig.TxPriceBump > 0 {
+		txConf.PriceBump = conf.BasicConfig.TxPriceBump
+	}
+
+	journaled := !conf.BasicConfig.IsMemoryMode() && !conf.BasicConfig.NoTxJournal
+	if journaled {
+		txConf.Journal = filepath.Join(serviceContext.DataDir, TxJournalFile)
+		txConf.JournalRemotes = conf.BasicConfig.TxJournalRemotes
+	}
+
 	s.debtPool = core.NewDebtPool(s.chain, s.debtVerifier)
-	s.txPool = core.NewTransactionPool(conf.SeeleConfig.TxConf, s.chain)
+	if journaled {
+		if err = s.debtPool.StartJournal(filepath.Join(serviceContext.DataDir, DebtJournalFile), txConf.Rejournal); err != nil {
+			s.log.Warn("failed to start debt journal, %s", err)
+		}
+	}
+
+	s.txPool = core.NewTransactionPool(txConf, s.chain)
+	s.gasPriceOracle = newGasPriceOracle(s.chain, s.txPool, conf.BasicConfig.GasPriceBlocks, conf.BasicConfig.GasPricePercentile)
 
 	event.ChainHeaderChangedEventMananger.AddAsyncListener(s.chainHeaderChanged)
 	go s.MonitorChainHeaderChange()
@@ -259,13 +332,26 @@ func (s *SeeleService) Start(srvr *p2p.Server) error {
 // Stop implements node.Service, terminating all internal goroutines.
 func (s *SeeleService) Stop() error {
 	//TODO
-	// s.txPool.Stop() s.chain.Stop()
+	// s.chain.Stop()
 	// retries? leave it to future
 	if s.seeleProtocol != nil {
 		s.seeleProtocol.Stop()
 		s.seeleProtocol = nil
 	}
 
+	if s.filterSystem != nil {
+		s.filterSystem.Stop()
+		s.filterSystem = nil
+	}
+
+	if s.txPool != nil {
+		s.txPool.Stop()
+	}
+
+	if s.debtPool != nil {
+		s.debtPool.Stop()
+	}
+
 	if s.chainDB != nil {
 		s.chainDB.Close()
 		s.chainDB = nil
@@ -319,10 +405,39 @@ func (s *SeeleService) APIs() (apis []rpc.API) {
 			Service:   NewTransactionPoolAPI(s),
 			Public:    true,
 		},
+		{
+			Namespace: "seele",
+			Version:   "1.0",
+			Service:   NewPublicFilterAPI(s.filterSystem),
+			Public:    true,
+		},
 	}...)
 
 	minerApis := s.miner.GetEngine().APIs(s.chain)
 	apis = append(apis, minerApis...)
 
+	if s.ethChainID > 0 {
+		apis = append(apis, []rpc.API{
+			{
+				Namespace: "eth",
+				Version:   "1.0",
+				Service:   NewPublicEthAPI(s),
+				Public:    true,
+			},
+			{
+				Namespace: "net",
+				Version:   "1.0",
+				Service:   NewPublicNetAPI(s),
+				Public:    true,
+			},
+			{
+				Namespace: "web3",
+				Version:   "1.0",
+				Service:   &PublicWeb3API{},
+				Public:    true,
+			},
+		}...)
+	}
+
 	return apis
 }
@@ -86,14 +86,38 @@ func Test_SeeleService_APIs(t *testing.T) {
 	s := newTestSeeleService()
 	apis := s.APIs()
 
-	assert.Equal(t, len(apis), 10)
-	assert.Equal(t, apis[0].Namespace, "seele")
-	assert.Equal(t, apis[1].Namespace, "txpool")
-	assert.Equal(t, apis[2].Namespace, "network")
-	assert.Equal(t, apis[3].Namespace, "debug")
-	assert.Equal(t, apis[4].Namespace, "seele")
-	assert.Equal(t, apis[5].Namespace, "download")
-	assert.Equal(t, apis[6].Namespace, "debug")
-	assert.Equal(t, apis[7].Namespace, "miner")
-	assert.Equal(t, apis[8].Namespace, "txpool")
+	namespaces := make(map[string]int)
+	for _, api := range apis {
+		namespaces[api.Namespace]++
+	}
+
+	assert.Equal(t, len(apis), 11)
+	assert.Equal(t, namespaces, map[string]int{
+		"seele":    3,
+		"txpool":   2,
+		"network":  1,
+		"filter":   1,
+		"download": 1,
+		"debug":    1,
+		"miner":    2,
+	})
+}
+
+func Test_SeeleService_MemoryMode(t *testing.T) {
+	conf := getTmpConfig()
+	conf.BasicConfig.DataDirMode = node.MemoryDataDirMode
+	dataDir := filepath.Join(common.GetTempFolder(), "memory-mode-node")
+	serviceContext := ServiceContext{
+		DataDir: dataDir,
+	}
+
+	var key interface{} = "ServiceContext"
+	ctx := context.WithValue(context.Background(), key, serviceContext)
+
+	s, err := NewSeeleService(ctx, conf, log.GetLogger("seele"), factory.MustGetConsensusEngine(common.Sha256Algorithm), nil, -1)
+	assert.Equal(t, err, nil)
+	defer s.Stop()
+
+	assert.Equal(t, s.chain.CurrentBlock().Header.Height, uint64(0))
+	assert.Equal(t, common.FileOrFolderExists(dataDir), false)
 }
@@ -0,0 +1,229 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+// Package seeleclient provides a client with strongly typed methods of the node RPC APIs.
+package seeleclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// Client is a client of the node RPC APIs, which is safe for concurrent use.
+type Client struct {
+	c *rpc.Client
+}
+
+// Dial connects to the node RPC service via TCP with the specified endpoint, e.g. 127.0.0.1:8027.
+func Dial(ctx context.Context, endpoint string) (*Client, error) {
+	c, err := rpc.DialTCP(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(c), nil
+}
+
+// NewClient creates a client with the specified RPC client, e.g. connected via HTTP or WebSocket.
+func NewClient(c *rpc.Client) *Client {
+	return &Client{c}
+}
+
+// Close closes the underlying RPC connection.
+func (c *Client) Close() {
+	c.c.Close()
+}
+
+// Call invokes the specified RPC method with raw arguments, e.g. the methods without typed wrapper,
+// and the result is unmarshaled into the given value if not nil.
+func (c *Client) Call(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.c.CallContext(ctx, result, method, args...)
+}
+
+// Block is the block with its total difficulty.
+type Block struct {
+	*types.Block
+	TotalDifficulty *big.Int
+}
+
+// rpcBlock is the RPC output of block with full transactions.
+type rpcBlock struct {
+	Hash            common.Hash        `json:"hash"`
+	Header          *types.BlockHeader `json:"header"`
+	Transactions    []*rpcTransaction  `json:"transactions"`
+	Debts           []*types.Debt      `json:"debts"`
+	TotalDifficulty *big.Int           `json:"totalDifficulty"`
+}
+
+func (b *rpcBlock) toBlock() (*Block, error) {
+	txs := make([]*types.Transaction, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		var err error
+		if txs[i], err = tx.toTransaction(); err != nil {
+			return nil, err
+		}
+	}
+
+	block := &types.Block{
+		HeaderHash:   b.Hash,
+		Header:       b.Header,
+		Transactions: txs,
+		Debts:        b.Debts,
+	}
+
+	return &Block{block, b.TotalDifficulty}, nil
+}
+
+// rpcTransaction is the RPC output of transaction, where the receiver is empty string to create contract.
+type rpcTransaction struct {
+	Hash         common.Hash      `json:"hash"`
+	From         common.Address   `json:"from"`
+	To           string           `json:"to"`
+	Amount       *big.Int         `json:"amount"`
+	AccountNonce uint64           `json:"accountNonce"`
+	Payload      common.Bytes     `json:"payload"`
+	GasPrice     *big.Int         `json:"gasPrice"`
+	GasLimit     uint64           `json:"gasLimit"`
+	Signature    crypto.Signature `json:"signature"`
+}
+
+func (tx *rpcTransaction) toTransaction() (*types.Transaction, error) {
+	var to common.Address
+	if len(tx.To) > 0 {
+		var err error
+		if to, err = common.HexToAddress(tx.To); err != nil {
+			return nil, errors.NewStackedErrorf(err, "invalid receiver of tx %v", tx.Hash.Hex())
+		}
+	}
+
+	return &types.Transaction{
+		Hash: tx.Hash,
+		Data: types.TransactionData{
+			From:         tx.From,
+			To:           to,
+			Amount:       tx.Amount,
+			AccountNonce: tx.AccountNonce,
+			GasPrice:     tx.GasPrice,
+			GasLimit:     tx.GasLimit,
+			Payload:      tx.Payload,
+		},
+		Signature: tx.Signature,
+	}, nil
+}
+
+func toTransactions(rpcTxs []*rpcTransaction) ([]*types.Transaction, error) {
+	txs := make([]*types.Transaction, len(rpcTxs))
+	for i, tx := range rpcTxs {
+		var err error
+		if txs[i], err = tx.toTransaction(); err != nil {
+			return nil, err
+		}
+	}
+
+	return txs, nil
+}
+
+// toIndexedTransactions converts the RPC output of transactions that each tx is keyed by
+// "transaction <index>" in a separate object.
+func toIndexedTransactions(indexedTxs []map[string]*rpcTransaction) ([]*types.Transaction, error) {
+	var rpcTxs []*rpcTransaction
+	for _, indexed := range indexedTxs {
+		for _, tx := range indexed {
+			rpcTxs = append(rpcTxs, tx)
+		}
+	}
+
+	return toTransactions(rpcTxs)
+}
+
+// rpcReceipt is the RPC output of receipt, where the result is error message if failed.
+type rpcReceipt struct {
+	Result    string      `json:"result"`
+	PostState common.Hash `json:"poststate"`
+	TxHash    common.Hash `json:"txhash"`
+	Contract  string      `json:"contract"`
+	Failed    bool        `json:"failed"`
+	UsedGas   uint64      `json:"usedGas"`
+	TotalFee  uint64      `json:"totalFee"`
+	Logs      []*rpcLog   `json:"logs"`
+}
+
+func (r *rpcReceipt) toReceipt() (*types.Receipt, error) {
+	receipt := &types.Receipt{
+		Failed:    r.Failed,
+		UsedGas:   r.UsedGas,
+		PostState: r.PostState,
+		Logs:      make([]*types.Log, len(r.Logs)),
+		TxHash:    r.TxHash,
+		TotalFee:  r.TotalFee,
+	}
+
+	if r.Failed {
+		receipt.Result = []byte(r.Result)
+	} else if len(r.Result) > 0 {
+		var err error
+		if receipt.Result, err = hexutil.HexToBytes(r.Result); err != nil {
+			return nil, errors.NewStackedError(err, "invalid receipt result")
+		}
+	}
+
+	for i, log := range r.Logs {
+		var err error
+		if receipt.Logs[i], err = log.toLog(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.Contract) > 0 && r.Contract != "0x" {
+		contract, err := common.HexToAddress(r.Contract)
+		if err != nil {
+			return nil, errors.NewStackedError(err, "invalid contract address")
+		}
+
+		receipt.ContractAddress = contract.Bytes()
+	}
+
+	return receipt, nil
+}
+
+// rpcLog is the RPC output of log, where the data is in hex format.
+type rpcLog struct {
+	Address     common.Address `json:"address"`
+	Topics      []common.Hash  `json:"topics"`
+	Data        string         `json:"data"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TxIndex     uint           `json:"transactionIndex"`
+}
+
+func (log *rpcLog) toLog() (*types.Log, error) {
+	data, err := hexutil.HexToBytes(log.Data)
+	if err != nil {
+		return nil, errors.NewStackedError(err, "invalid log data")
+	}
+
+	return &types.Log{
+		Address:     log.Address,
+		Topics:      log.Topics,
+		Data:        data,
+		BlockNumber: log.BlockNumber,
+		TxIndex:     log.TxIndex,
+	}, nil
+}
+
+// blockHashArg returns the block hash argument of RPC, which is empty to specify block by height.
+func blockHashArg(blockHash common.Hash) string {
+	if blockHash.IsEmpty() {
+		return ""
+	}
+
+	return blockHash.Hex()
+}
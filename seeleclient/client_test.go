@@ -0,0 +1,170 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/consensus/factory"
+	"github.com/seeleteam/go-seele/core"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/log"
+	"github.com/seeleteam/go-seele/node"
+	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/seele"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, dbPath string) (*Client, *seele.SeeleService) {
+	conf := &node.Config{
+		SeeleConfig: node.SeeleConfig{
+			TxConf:   *core.DefaultTxPoolConfig(),
+			Coinbase: *crypto.MustGenerateRandomAddress(),
+		},
+	}
+
+	var key interface{} = "ServiceContext"
+	ctx := context.WithValue(context.Background(), key, seele.ServiceContext{DataDir: dbPath})
+	s, err := seele.NewSeeleService(ctx, conf, log.GetLogger("seele"), factory.MustGetConsensusEngine(common.Sha256Algorithm), nil, -1)
+	assert.Equal(t, err, nil)
+
+	server := rpc.NewServer()
+	for _, api := range s.APIs() {
+		assert.Equal(t, server.RegisterName(api.Namespace, api.Service), nil)
+	}
+
+	return NewClient(rpc.DialInProc(server)), s
+}
+
+func Test_Client_Chain(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".seeleclient")
+	client, s := newTestClient(t, dbPath)
+	defer func() {
+		client.Close()
+		s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	ctx := context.Background()
+	genesis := s.BlockChain().CurrentBlock()
+
+	height, err := client.GetBlockHeight(ctx)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, height, uint64(0))
+
+	block, err := client.GetBlock(ctx, common.EmptyHash, -1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, block.HeaderHash, genesis.HeaderHash)
+	assert.Equal(t, block.Header.Hash(), genesis.HeaderHash)
+	assert.Equal(t, len(block.Transactions), 0)
+
+	block, err = client.GetBlock(ctx, genesis.HeaderHash, 0)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, block.HeaderHash, genesis.HeaderHash)
+
+	shard, err := client.GetShardNum(ctx, *crypto.MustGenerateShardAddress(2))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, shard, uint(2))
+
+	count, err := client.GetTxPoolTxCount(ctx)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, count, uint64(0))
+
+	price, err := client.SuggestGasPrice(ctx)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, price.Sign() > 0, true)
+
+	// raw call
+	var rawHeight uint64
+	assert.Equal(t, client.Call(ctx, &rawHeight, "seele_getBlockHeight"), nil)
+	assert.Equal(t, rawHeight, uint64(0))
+}
+
+func Test_Client_Filter(t *testing.T) {
+	dbPath := filepath.Join(common.GetTempFolder(), ".seeleclientFilter")
+	client, s := newTestClient(t, dbPath)
+	defer func() {
+		client.Close()
+		s.Stop()
+		os.RemoveAll(dbPath)
+	}()
+
+	ctx := context.Background()
+	id, err := client.NewBlockFilter(ctx)
+	assert.Equal(t, err, nil)
+
+	hashes, err := client.GetFilterHashChanges(ctx, id)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(hashes), 0)
+
+	uninstalled, err := client.UninstallFilter(ctx, id)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, uninstalled, true)
+
+	uninstalled, err = client.UninstallFilter(ctx, id)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, uninstalled, false)
+}
+
+func Test_rpcTransaction_toTransaction(t *testing.T) {
+	from, privKey := crypto.MustGenerateShardKeyPair(1)
+	to := crypto.MustGenerateShardAddress(1)
+
+	for _, receiver := range []common.Address{*to, common.EmptyAddress} {
+		tx, err := types.NewMessageTransaction(*from, receiver, big.NewInt(3), big.NewInt(1), 50000, 5, []byte("payload"))
+		assert.Equal(t, err, nil)
+		tx.Sign(privKey)
+
+		encoded, err := json.Marshal(api.PrintableOutputTx(tx))
+		assert.Equal(t, err, nil)
+
+		var rpcTx rpcTransaction
+		assert.Equal(t, json.Unmarshal(encoded, &rpcTx), nil)
+
+		decoded, err := rpcTx.toTransaction()
+		assert.Equal(t, err, nil)
+		assert.Equal(t, decoded.Hash, tx.Hash)
+		assert.Equal(t, decoded.Data.To, receiver)
+		assert.Equal(t, decoded.Data.Payload, tx.Data.Payload)
+		assert.Equal(t, decoded.Signature, tx.Signature)
+	}
+}
+
+func Test_rpcReceipt_toReceipt(t *testing.T) {
+	contract := crypto.MustGenerateShardAddress(1)
+	receipts := []*types.Receipt{
+		{Result: []byte{1, 2}, Logs: []*types.Log{{Address: *contract, Data: []byte{3}, TxIndex: 1}}, ContractAddress: contract.Bytes(), UsedGas: 21000, TotalFee: 21000, TxHash: common.StringToHash("tx")},
+		{Result: []byte("out of gas"), Failed: true, UsedGas: 50000, TxHash: common.StringToHash("failed")},
+	}
+
+	for _, receipt := range receipts {
+		printable, err := api.PrintableReceipt(receipt)
+		assert.Equal(t, err, nil)
+
+		encoded, err := json.Marshal(printable)
+		assert.Equal(t, err, nil)
+
+		var r rpcReceipt
+		assert.Equal(t, json.Unmarshal(encoded, &r), nil)
+
+		decoded, err := r.toReceipt()
+		assert.Equal(t, err, nil)
+		assert.Equal(t, decoded.Result, receipt.Result)
+		assert.Equal(t, decoded.Failed, receipt.Failed)
+		assert.Equal(t, decoded.UsedGas, receipt.UsedGas)
+		assert.Equal(t, decoded.TxHash, receipt.TxHash)
+		assert.Equal(t, decoded.ContractAddress, receipt.ContractAddress)
+		assert.Equal(t, len(decoded.Logs), len(receipt.Logs))
+	}
+}
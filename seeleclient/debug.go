@@ -0,0 +1,102 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import (
+	"context"
+
+	"github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/core/vm"
+)
+
+const callTracer = "callTracer"
+
+// TxStructTrace is the per-opcode traces of a transaction in block.
+type TxStructTrace struct {
+	TxHash common.Hash          `json:"txHash"`
+	Result *api.ExecutionResult `json:"result"`
+}
+
+// TxCallTrace is the tree of call frames of a transaction in block.
+type TxCallTrace struct {
+	TxHash common.Hash   `json:"txHash"`
+	Result *vm.CallFrame `json:"result"`
+}
+
+// PrintBlock returns the block with the specified height, and negative height for the HEAD block.
+func (c *Client) PrintBlock(ctx context.Context, height int64) (*types.Block, error) {
+	var block types.Block
+	if err := c.c.CallContext(ctx, &block, "debug_printBlock", height); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// GetTPS returns the tps info of recent blocks, which is nil if only the genesis block exists.
+func (c *Client) GetTPS(ctx context.Context) (*api.TpsInfo, error) {
+	var info *api.TpsInfo
+	err := c.c.CallContext(ctx, &info, "debug_getTPS")
+	return info, err
+}
+
+// DumpHeap dumps the heap profile into file in node, and returns the file path.
+func (c *Client) DumpHeap(ctx context.Context, fileName string, gcBeforeDump bool) (string, error) {
+	var path string
+	err := c.c.CallContext(ctx, &path, "debug_dumpHeap", fileName, gcBeforeDump)
+	return path, err
+}
+
+// DumpState returns a page of accounts of the state at the specified block height in the order of address hash,
+// starting from the specified address hash. The next page starts from the Next of result, which is nil if no more.
+func (c *Client) DumpState(ctx context.Context, height int64, start common.Hash, limit int) (*api.StateDump, error) {
+	var dump api.StateDump
+	if err := c.c.CallContext(ctx, &dump, "debug_dumpState", height, start, limit); err != nil {
+		return nil, err
+	}
+
+	return &dump, nil
+}
+
+// TraceTransaction returns the per-opcode traces of the specified tx, and the tracer in config is ignored.
+func (c *Client) TraceTransaction(ctx context.Context, txHash common.Hash, config api.TraceConfig) (*api.ExecutionResult, error) {
+	config.Tracer = ""
+
+	var result api.ExecutionResult
+	if err := c.c.CallContext(ctx, &result, "debug_traceTransaction", txHash, &config); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// TraceTransactionCalls returns the tree of call frames of the specified tx.
+func (c *Client) TraceTransactionCalls(ctx context.Context, txHash common.Hash) (*vm.CallFrame, error) {
+	var frame vm.CallFrame
+	if err := c.c.CallContext(ctx, &frame, "debug_traceTransaction", txHash, &api.TraceConfig{Tracer: callTracer}); err != nil {
+		return nil, err
+	}
+
+	return &frame, nil
+}
+
+// TraceBlock returns the per-opcode traces of all txs in the specified block, and the tracer in config is ignored.
+func (c *Client) TraceBlock(ctx context.Context, height int64, config api.TraceConfig) ([]*TxStructTrace, error) {
+	config.Tracer = ""
+
+	var results []*TxStructTrace
+	err := c.c.CallContext(ctx, &results, "debug_traceBlock", height, &config)
+	return results, err
+}
+
+// TraceBlockCalls returns the tree of call frames of all txs in the specified block.
+func (c *Client) TraceBlockCalls(ctx context.Context, height int64) ([]*TxCallTrace, error) {
+	var results []*TxCallTrace
+	err := c.c.CallContext(ctx, &results, "debug_traceBlock", height, &api.TraceConfig{Tracer: callTracer})
+	return results, err
+}
@@ -0,0 +1,29 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import (
+	"context"
+
+	downloader "github.com/seeleteam/go-seele/seele/download"
+)
+
+// GetSyncStatus returns the status of block synchronization.
+func (c *Client) GetSyncStatus(ctx context.Context) (*downloader.SyncInfo, error) {
+	var info downloader.SyncInfo
+	if err := c.c.CallContext(ctx, &info, "download_getStatus"); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// IsDownloading returns whether the downloader is synchronizing blocks.
+func (c *Client) IsDownloading(ctx context.Context) (bool, error) {
+	var syncing bool
+	err := c.c.CallContext(ctx, &syncing, "download_isSyncing")
+	return syncing, err
+}
@@ -0,0 +1,103 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import (
+	"context"
+
+	"github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/core/types"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// Head is the notification of a new block appended to the canonical chain.
+type Head struct {
+	Header *types.BlockHeader `json:"header"`
+	Hash   common.Hash        `json:"hash"`
+}
+
+// NewFilter installs a filter of logs that match the criteria in new canonical blocks, and returns the filter id.
+func (c *Client) NewFilter(ctx context.Context, criteria api.LogFilter) (rpc.ID, error) {
+	var id rpc.ID
+	err := c.c.CallContext(ctx, &id, "filter_newFilter", criteria)
+	return id, err
+}
+
+// NewBlockFilter installs a filter of new canonical blocks, and returns the filter id.
+func (c *Client) NewBlockFilter(ctx context.Context) (rpc.ID, error) {
+	var id rpc.ID
+	err := c.c.CallContext(ctx, &id, "filter_newBlockFilter")
+	return id, err
+}
+
+// NewPendingTransactionFilter installs a filter of txs inserted into tx pool, and returns the filter id.
+func (c *Client) NewPendingTransactionFilter(ctx context.Context) (rpc.ID, error) {
+	var id rpc.ID
+	err := c.c.CallContext(ctx, &id, "filter_newPendingTransactionFilter")
+	return id, err
+}
+
+// GetFilterHashChanges returns the block or tx hashes since the last poll of the specified block
+// or pending tx filter.
+func (c *Client) GetFilterHashChanges(ctx context.Context, id rpc.ID) ([]common.Hash, error) {
+	var hashes []common.Hash
+	err := c.c.CallContext(ctx, &hashes, "filter_getFilterChanges", id)
+	return hashes, err
+}
+
+// GetFilterLogChanges returns the logs since the last poll of the specified log filter, including
+// the logs removed due to chain reorganization.
+func (c *Client) GetFilterLogChanges(ctx context.Context, id rpc.ID) ([]*api.FilteredLog, error) {
+	var logs []*api.FilteredLog
+	err := c.c.CallContext(ctx, &logs, "filter_getFilterChanges", id)
+	return logs, err
+}
+
+// GetFilterLogs returns all the logs that match the criteria of the specified log filter.
+func (c *Client) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*api.FilteredLog, error) {
+	var logs []*api.FilteredLog
+	err := c.c.CallContext(ctx, &logs, "filter_getFilterLogs", id)
+	return logs, err
+}
+
+// UninstallFilter uninstalls the specified filter, and returns false if the filter not found.
+func (c *Client) UninstallFilter(ctx context.Context, id rpc.ID) (bool, error) {
+	var uninstalled bool
+	err := c.c.CallContext(ctx, &uninstalled, "filter_uninstallFilter", id)
+	return uninstalled, err
+}
+
+// SubscribeNewHead subscribes the new blocks appended to the canonical chain, which requires
+// connection that supports notifications, e.g. TCP or WebSocket.
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *Head) (*rpc.ClientSubscription, error) {
+	return c.c.Subscribe(ctx, "seele", ch, "newHeads")
+}
+
+// SubscribeLogs subscribes the logs that match the filter in new canonical blocks.
+func (c *Client) SubscribeLogs(ctx context.Context, filter api.LogFilter, ch chan<- *api.FilteredLog) (*rpc.ClientSubscription, error) {
+	return c.c.Subscribe(ctx, "seele", ch, "logs", filter)
+}
+
+// SubscribePendingTransactions subscribes the hashes of txs inserted into tx pool.
+func (c *Client) SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (*rpc.ClientSubscription, error) {
+	return c.c.Subscribe(ctx, "seele", ch, "newPendingTransactions")
+}
+
+// SubscribeReplacedTransactions subscribes the txs replaced by another one with the same nonce in tx pool.
+func (c *Client) SubscribeReplacedTransactions(ctx context.Context, ch chan<- *api.ReplacedTransaction) (*rpc.ClientSubscription, error) {
+	return c.c.Subscribe(ctx, "seele", ch, "replacedTransactions")
+}
+
+// SubscribeNewDebts subscribes the hashes of debts inserted into debt pool.
+func (c *Client) SubscribeNewDebts(ctx context.Context, ch chan<- common.Hash) (*rpc.ClientSubscription, error) {
+	return c.c.Subscribe(ctx, "seele", ch, "newDebts")
+}
+
+// SubscribeSyncing subscribes the block synchronization status changes.
+func (c *Client) SubscribeSyncing(ctx context.Context, ch chan<- *api.SyncingStatus) (*rpc.ClientSubscription, error) {
+	return c.c.Subscribe(ctx, "seele", ch, "syncing")
+}
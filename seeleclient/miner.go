@@ -0,0 +1,80 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import (
+	"context"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// MinerStart starts the miner, which only works for local node.
+func (c *Client) MinerStart(ctx context.Context) (bool, error) {
+	var started bool
+	err := c.c.CallContext(ctx, &started, "miner_start")
+	return started, err
+}
+
+// MinerStop stops the miner, which only works for local node.
+func (c *Client) MinerStop(ctx context.Context) (bool, error) {
+	var stopped bool
+	err := c.c.CallContext(ctx, &stopped, "miner_stop")
+	return stopped, err
+}
+
+// MinerStatus returns the miner status, e.g. Running or Stopped.
+func (c *Client) MinerStatus(ctx context.Context) (string, error) {
+	var status string
+	err := c.c.CallContext(ctx, &status, "miner_status")
+	return status, err
+}
+
+// MinerSetThreads sets the thread number of miner.
+func (c *Client) MinerSetThreads(ctx context.Context, threads int) error {
+	var result bool
+	return c.c.CallContext(ctx, &result, "miner_setThreads", threads)
+}
+
+// MinerGetThreads returns the thread number of miner.
+func (c *Client) MinerGetThreads(ctx context.Context) (int, error) {
+	var threads int
+	err := c.c.CallContext(ctx, &threads, "miner_getThreads")
+	return threads, err
+}
+
+// MinerSetCoinbase sets the coinbase of miner.
+func (c *Client) MinerSetCoinbase(ctx context.Context, coinbase common.Address) error {
+	var result bool
+	return c.c.CallContext(ctx, &result, "miner_setCoinbase", coinbase.Hex())
+}
+
+// MinerGetCoinbase returns the coinbase of miner.
+func (c *Client) MinerGetCoinbase(ctx context.Context) (common.Address, error) {
+	var coinbase common.Address
+	err := c.c.CallContext(ctx, &coinbase, "miner_getCoinbase")
+	return coinbase, err
+}
+
+// MinerGetHashrate returns the hash rate of miner.
+func (c *Client) MinerGetHashrate(ctx context.Context) (uint64, error) {
+	var hashrate uint64
+	err := c.c.CallContext(ctx, &hashrate, "miner_getHashrate")
+	return hashrate, err
+}
+
+// MinerGetWork returns the current mining task of miner.
+func (c *Client) MinerGetWork(ctx context.Context) (map[string]interface{}, error) {
+	var work map[string]interface{}
+	err := c.c.CallContext(ctx, &work, "miner_getWork")
+	return work, err
+}
+
+// MinerGetTarget returns the current mining target of miner.
+func (c *Client) MinerGetTarget(ctx context.Context) (string, error) {
+	var target string
+	err := c.c.CallContext(ctx, &target, "miner_getTarget")
+	return target, err
+}
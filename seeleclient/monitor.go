@@ -0,0 +1,52 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import "context"
+
+// NodeInfo is the meta information of node returned by monitor_nodeInfo, the same as monitor.NodeInfo,
+// which is redefined to avoid depending on the full node packages.
+type NodeInfo struct {
+	Name       string `json:"name"`
+	Node       string `json:"node"`
+	Port       int    `json:"port"`
+	NetVersion string `json:"netVersion"`
+	Protocol   string `json:"protocol"`
+	API        string `json:"api"`
+	Os         string `json:"os"`
+	OsVer      string `json:"os_v"`
+	Client     string `json:"client"`
+	History    bool   `json:"canUpdateHistory"`
+	Shard      uint   `json:"shard"`
+}
+
+// NodeStats is the state of node returned by monitor_nodeStats, the same as monitor.NodeStats.
+type NodeStats struct {
+	Active  bool `json:"active"`
+	Syncing bool `json:"syncing"`
+	Mining  bool `json:"mining"`
+	Peers   int  `json:"peers"`
+}
+
+// GetNodeInfo returns the node info, which requires the monitor service enabled in node.
+func (c *Client) GetNodeInfo(ctx context.Context) (*NodeInfo, error) {
+	var info NodeInfo
+	if err := c.c.CallContext(ctx, &info, "monitor_nodeInfo"); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetNodeStats returns the node stats, which requires the monitor service enabled in node.
+func (c *Client) GetNodeStats(ctx context.Context) (*NodeStats, error) {
+	var stats NodeStats
+	if err := c.c.CallContext(ctx, &stats, "monitor_nodeStats"); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
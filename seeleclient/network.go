@@ -0,0 +1,54 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import (
+	"context"
+
+	"github.com/seeleteam/go-seele/p2p"
+)
+
+// GetPeersInfo returns the information of connected peers.
+func (c *Client) GetPeersInfo(ctx context.Context) ([]p2p.PeerInfo, error) {
+	var peers []p2p.PeerInfo
+	err := c.c.CallContext(ctx, &peers, "network_getPeersInfo")
+	return peers, err
+}
+
+// GetPeerCount returns the number of connected peers.
+func (c *Client) GetPeerCount(ctx context.Context) (int, error) {
+	var count int
+	err := c.c.CallContext(ctx, &count, "network_getPeerCount")
+	return count, err
+}
+
+// GetNetVersion returns the net version.
+func (c *Client) GetNetVersion(ctx context.Context) (string, error) {
+	var version string
+	err := c.c.CallContext(ctx, &version, "network_getNetVersion")
+	return version, err
+}
+
+// GetNetworkID returns the network id.
+func (c *Client) GetNetworkID(ctx context.Context) (string, error) {
+	var networkID string
+	err := c.c.CallContext(ctx, &networkID, "network_getNetworkID")
+	return networkID, err
+}
+
+// GetProtocolVersion returns the seele protocol version.
+func (c *Client) GetProtocolVersion(ctx context.Context) (uint, error) {
+	var version uint
+	err := c.c.CallContext(ctx, &version, "network_getProtocolVersion")
+	return version, err
+}
+
+// IsListening returns whether the node is listening for connections.
+func (c *Client) IsListening(ctx context.Context) (bool, error) {
+	var listening bool
+	err := c.c.CallContext(ctx, &listening, "network_isListening")
+	return listening, err
+}
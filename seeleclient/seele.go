@@ -0,0 +1,302 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/common/hexutil"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+// AccountHistoryEntry is a tx or debt that an account involved in canonical blocks.
+type AccountHistoryEntry struct {
+	Hash      common.Hash `json:"hash"`
+	BlockHash common.Hash `json:"blockHash"`
+	Height    uint64      `json:"height"`
+	Index     uint        `json:"index"`
+	Type      string      `json:"type"` // tx or debt
+}
+
+// GetInfo returns the node info, e.g. coinbase, HEAD block and miner status.
+func (c *Client) GetInfo(ctx context.Context) (*api.GetMinerInfo, error) {
+	var info api.GetMinerInfo
+	if err := c.c.CallContext(ctx, &info, "seele_getInfo"); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetBalance returns the balance of account in the block specified by hash, or by height
+// if the hash is empty, and negative height for the HEAD block.
+func (c *Client) GetBalance(ctx context.Context, account common.Address, blockHash common.Hash, height int64) (*big.Int, error) {
+	var result api.GetBalanceResponse
+	if err := c.c.CallContext(ctx, &result, "seele_getBalance", account, blockHashArg(blockHash), height); err != nil {
+		return nil, err
+	}
+
+	return result.Balance, nil
+}
+
+// GetAccountNonce returns the nonce of account in the block specified by hash, or by height
+// if the hash is empty, and negative height for the HEAD block.
+func (c *Client) GetAccountNonce(ctx context.Context, account common.Address, blockHash common.Hash, height int64) (uint64, error) {
+	var nonce uint64
+	err := c.c.CallContext(ctx, &nonce, "seele_getAccountNonce", account, blockHashArg(blockHash), height)
+	return nonce, err
+}
+
+// GetShardNum returns the shard number of account.
+func (c *Client) GetShardNum(ctx context.Context, account common.Address) (uint, error) {
+	var shard uint
+	err := c.c.CallContext(ctx, &shard, "seele_getShardNum", account)
+	return shard, err
+}
+
+// GetBlockHeight returns the height of HEAD block.
+func (c *Client) GetBlockHeight(ctx context.Context) (uint64, error) {
+	var height uint64
+	err := c.c.CallContext(ctx, &height, "seele_getBlockHeight")
+	return height, err
+}
+
+// GetBlock returns the block with full transactions specified by hash, or by height if
+// the hash is empty, and negative height for the HEAD block.
+func (c *Client) GetBlock(ctx context.Context, blockHash common.Hash, height int64) (*Block, error) {
+	var block rpcBlock
+	if err := c.c.CallContext(ctx, &block, "seele_getBlock", blockHashArg(blockHash), height, true); err != nil {
+		return nil, err
+	}
+
+	return block.toBlock()
+}
+
+// GetBlocks returns at most size (up to 64) blocks with full transactions in descending order
+// of height from the specified height, or only the HEAD block if the height is negative.
+func (c *Client) GetBlocks(ctx context.Context, height int64, size uint) ([]*Block, error) {
+	var rpcBlocks []*rpcBlock
+	if err := c.c.CallContext(ctx, &rpcBlocks, "seele_getBlocks", height, true, size); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*Block, len(rpcBlocks))
+	for i, b := range rpcBlocks {
+		var err error
+		if blocks[i], err = b.toBlock(); err != nil {
+			return nil, err
+		}
+	}
+
+	return blocks, nil
+}
+
+// GetBlockTransactionCount returns the number of txs in the block specified by hash,
+// or by height if the hash is empty.
+func (c *Client) GetBlockTransactionCount(ctx context.Context, blockHash common.Hash, height int64) (int, error) {
+	var count int
+	err := c.c.CallContext(ctx, &count, "seele_getBlockTransactionCount", blockHashArg(blockHash), height)
+	return count, err
+}
+
+// GetBlockDebtCount returns the number of debts in the block specified by hash,
+// or by height if the hash is empty.
+func (c *Client) GetBlockDebtCount(ctx context.Context, blockHash common.Hash, height int64) (int, error) {
+	var count int
+	err := c.c.CallContext(ctx, &count, "seele_getBlockDebtCount", blockHashArg(blockHash), height)
+	return count, err
+}
+
+// GetBlockTransactions returns the txs in the block specified by hash, or by height if the hash is empty.
+func (c *Client) GetBlockTransactions(ctx context.Context, blockHash common.Hash, height int64) ([]*types.Transaction, error) {
+	return c.getIndexedTransactions(ctx, "seele_getBlockTransactions", blockHashArg(blockHash), height)
+}
+
+// GetTransactionByBlockIndex returns the tx with the specified index in the block specified
+// by hash, or by height if the hash is empty.
+func (c *Client) GetTransactionByBlockIndex(ctx context.Context, blockHash common.Hash, height int64, index uint) (*types.Transaction, error) {
+	var tx rpcTransaction
+	if err := c.c.CallContext(ctx, &tx, "seele_getTransactionByBlockIndex", blockHashArg(blockHash), height, index); err != nil {
+		return nil, err
+	}
+
+	return tx.toTransaction()
+}
+
+// GetTransactionsFrom returns the txs sent from account in the block specified by hash,
+// or by height if the hash is empty.
+func (c *Client) GetTransactionsFrom(ctx context.Context, account common.Address, blockHash common.Hash, height int64) ([]*types.Transaction, error) {
+	return c.getIndexedTransactions(ctx, "seele_getTransactionsFrom", account, blockHashArg(blockHash), height)
+}
+
+// GetTransactionsTo returns the txs sent to account in the block specified by hash,
+// or by height if the hash is empty.
+func (c *Client) GetTransactionsTo(ctx context.Context, account common.Address, blockHash common.Hash, height int64) ([]*types.Transaction, error) {
+	return c.getIndexedTransactions(ctx, "seele_getTransactionsTo", account, blockHashArg(blockHash), height)
+}
+
+// GetAccountTransactions returns the txs sent from or to account in the block specified by hash,
+// or by height if the hash is empty.
+func (c *Client) GetAccountTransactions(ctx context.Context, account common.Address, blockHash common.Hash, height int64) ([]*types.Transaction, error) {
+	return c.getIndexedTransactions(ctx, "seele_getAccountTransactions", account, blockHashArg(blockHash), height)
+}
+
+func (c *Client) getIndexedTransactions(ctx context.Context, method string, args ...interface{}) ([]*types.Transaction, error) {
+	var indexedTxs []map[string]*rpcTransaction
+	if err := c.c.CallContext(ctx, &indexedTxs, method, args...); err != nil {
+		return nil, err
+	}
+
+	return toIndexedTransactions(indexedTxs)
+}
+
+// GetAccountHistory returns the txs and debts that account involved in canonical blocks with height
+// in range [fromHeight, toHeight], which requires account history index enabled in node.
+func (c *Client) GetAccountHistory(ctx context.Context, account common.Address, fromHeight, toHeight uint64, offset, limit uint) ([]*AccountHistoryEntry, error) {
+	var entries []*AccountHistoryEntry
+	err := c.c.CallContext(ctx, &entries, "seele_getAccountHistory", account, fromHeight, toHeight, offset, limit)
+	return entries, err
+}
+
+// GetReceiptByTxHash returns the receipt of the specified tx.
+func (c *Client) GetReceiptByTxHash(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt rpcReceipt
+	if err := c.c.CallContext(ctx, &receipt, "seele_getReceiptByTxHash", txHash.Hex(), ""); err != nil {
+		return nil, err
+	}
+
+	return receipt.toReceipt()
+}
+
+// GetReceiptsByBlockHash returns the receipts of txs in the specified block.
+func (c *Client) GetReceiptsByBlockHash(ctx context.Context, blockHash common.Hash) ([]*types.Receipt, error) {
+	var result struct {
+		Receipts []*rpcReceipt `json:"receipts"`
+	}
+
+	if err := c.c.CallContext(ctx, &result, "seele_getReceiptsByBlockHash", blockHash.Hex()); err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*types.Receipt, len(result.Receipts))
+	for i, r := range result.Receipts {
+		var err error
+		if receipts[i], err = r.toReceipt(); err != nil {
+			return nil, err
+		}
+	}
+
+	return receipts, nil
+}
+
+// GetCode returns the payload of tx that created the contract in the specified block,
+// and negative height for the HEAD block.
+func (c *Client) GetCode(ctx context.Context, contract common.Address, height int64) ([]byte, error) {
+	var code common.Bytes
+	err := c.c.CallContext(ctx, &code, "seele_getCode", contract, height)
+	return code, err
+}
+
+// AddTx sends the signed tx to node, which is added into tx pool or forwarded to the shard of sender.
+func (c *Client) AddTx(ctx context.Context, tx *types.Transaction) error {
+	var added bool
+	if err := c.c.CallContext(ctx, &added, "seele_addTx", *tx); err != nil {
+		return err
+	}
+
+	if !added {
+		return errors.New("failed to add tx")
+	}
+
+	return nil
+}
+
+// EstimateGas returns the lowest gas limit that the tx executes successfully against the HEAD block.
+func (c *Client) EstimateGas(ctx context.Context, tx *types.Transaction) (uint64, error) {
+	var gas uint64
+	err := c.c.CallContext(ctx, &gas, "seele_estimateGas", tx)
+	return gas, err
+}
+
+// SuggestGasPrice returns the gas price suggested to get txs included in time.
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	// big.Int result is encoded in hex by RPC server
+	var price string
+	if err := c.c.CallContext(ctx, &price, "seele_suggestGasPrice"); err != nil {
+		return nil, err
+	}
+
+	return hexutil.DecodeBig(price)
+}
+
+// CallContract executes the payload on the contract without creating a tx on chain in the
+// specified block, and negative height for the HEAD block.
+func (c *Client) CallContract(ctx context.Context, contract common.Address, payload []byte, height int64) (*types.Receipt, error) {
+	var receipt rpcReceipt
+	if err := c.c.CallContext(ctx, &receipt, "seele_call", contract.Hex(), hexutil.BytesToHex(payload), height); err != nil {
+		return nil, err
+	}
+
+	return receipt.toReceipt()
+}
+
+// Simulate executes the call object with state overrides on the specified block, which could be
+// "latest" (default), "pending", block hash or block height, and returns the receipt and the
+// decoded revert reason if reverted.
+func (c *Client) Simulate(ctx context.Context, args api.SimulateArgs, block string, overrides map[common.Address]api.AccountOverride) (*types.Receipt, string, error) {
+	var result struct {
+		rpcReceipt
+		RevertReason string `json:"revertReason"`
+	}
+
+	if err := c.c.CallContext(ctx, &result, "seele_simulate", args, block, overrides); err != nil {
+		return nil, "", err
+	}
+
+	receipt, err := result.toReceipt()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return receipt, result.RevertReason, nil
+}
+
+// GetProof returns the account state and storage values with merkle proofs in the specified
+// block, which could be "latest" (default), block hash or block height.
+func (c *Client) GetProof(ctx context.Context, account common.Address, storageKeys []common.Hash, block string) (*api.ProofResult, error) {
+	var result api.ProofResult
+	if err := c.c.CallContext(ctx, &result, "seele_getProof", account, storageKeys, block); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetLogs returns the logs generated by the contract in the specified block, which are
+// decoded by ABI if the event name specified.
+func (c *Client) GetLogs(ctx context.Context, height int64, contract common.Address, abiJSON, eventName string) ([]api.GetLogsResponse, error) {
+	var logs []api.GetLogsResponse
+	err := c.c.CallContext(ctx, &logs, "seele_getLogs", height, contract, abiJSON, eventName)
+	return logs, err
+}
+
+// FilterLogs returns the logs that match the filter in canonical blocks.
+func (c *Client) FilterLogs(ctx context.Context, filter api.LogFilter) ([]*api.FilteredLog, error) {
+	var logs []*api.FilteredLog
+	err := c.c.CallContext(ctx, &logs, "seele_filterLogs", filter)
+	return logs, err
+}
+
+// IsSyncing returns whether the node is synchronizing blocks.
+func (c *Client) IsSyncing(ctx context.Context) (bool, error) {
+	var syncing bool
+	err := c.c.CallContext(ctx, &syncing, "seele_isSyncing")
+	return syncing, err
+}
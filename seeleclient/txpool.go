@@ -0,0 +1,151 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package seeleclient
+
+import (
+	"context"
+
+	"github.com/seeleteam/go-seele/api"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/common/errors"
+	"github.com/seeleteam/go-seele/core/types"
+)
+
+// TransactionInfo is the tx with its status, where the block info is available if the tx is in block.
+type TransactionInfo struct {
+	Transaction *types.Transaction
+	Debt        *types.Debt // debt of cross shard tx
+	Status      string      // pool or block
+	BlockHash   common.Hash
+	BlockHeight uint64
+	TxIndex     uint
+}
+
+// DebtInfo is the debt with its status, where the block info is available if the debt is in block.
+type DebtInfo struct {
+	Debt        *types.Debt `json:"debt"`
+	Status      string      `json:"status"` // pool or block
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockHeight uint64      `json:"blockHeight"`
+	DebtIndex   uint        `json:"debtIndex"`
+}
+
+// GetTransactionByHash returns the tx in tx pool or canonical blocks, or nil if not found.
+func (c *Client) GetTransactionByHash(ctx context.Context, txHash common.Hash) (*TransactionInfo, error) {
+	var result *struct {
+		Transaction *rpcTransaction `json:"transaction"`
+		Debt        *types.Debt     `json:"debt"`
+		Status      string          `json:"status"`
+		BlockHash   common.Hash     `json:"blockHash"`
+		BlockHeight uint64          `json:"blockHeight"`
+		TxIndex     uint            `json:"txIndex"`
+	}
+
+	if err := c.c.CallContext(ctx, &result, "txpool_getTransactionByHash", txHash.Hex()); err != nil || result == nil {
+		return nil, err
+	}
+
+	tx, err := result.Transaction.toTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionInfo{
+		Transaction: tx,
+		Debt:        result.Debt,
+		Status:      result.Status,
+		BlockHash:   result.BlockHash,
+		BlockHeight: result.BlockHeight,
+		TxIndex:     result.TxIndex,
+	}, nil
+}
+
+// GetTxPoolContent returns the txs in tx pool grouped by sender.
+func (c *Client) GetTxPoolContent(ctx context.Context) (map[common.Address][]*types.Transaction, error) {
+	var content map[common.Address][]*rpcTransaction
+	if err := c.c.CallContext(ctx, &content, "txpool_getTxPoolContent"); err != nil {
+		return nil, err
+	}
+
+	result := make(map[common.Address][]*types.Transaction)
+	for from, rpcTxs := range content {
+		txs, err := toTransactions(rpcTxs)
+		if err != nil {
+			return nil, err
+		}
+
+		result[from] = txs
+	}
+
+	return result, nil
+}
+
+// GetTxPoolTxCount returns the number of txs in tx pool.
+func (c *Client) GetTxPoolTxCount(ctx context.Context) (uint64, error) {
+	var count uint64
+	err := c.c.CallContext(ctx, &count, "txpool_getTxPoolTxCount")
+	return count, err
+}
+
+// GetPendingTransactions returns the pending txs in tx pool.
+func (c *Client) GetPendingTransactions(ctx context.Context) ([]*types.Transaction, error) {
+	var rpcTxs []*rpcTransaction
+	if err := c.c.CallContext(ctx, &rpcTxs, "txpool_getPendingTransactions"); err != nil {
+		return nil, err
+	}
+
+	return toTransactions(rpcTxs)
+}
+
+// GetPendingDebts returns the pending debts in debt pool.
+func (c *Client) GetPendingDebts(ctx context.Context) ([]*types.Debt, error) {
+	var debts []*types.Debt
+	err := c.c.CallContext(ctx, &debts, "txpool_getPendingDebts")
+	return debts, err
+}
+
+// ReplaceTransaction replaces the pending or queued tx with the same nonce in tx pool by the specified tx,
+// which requires the gas price bumped enough.
+func (c *Client) ReplaceTransaction(ctx context.Context, tx *types.Transaction) error {
+	var replaced bool
+	if err := c.c.CallContext(ctx, &replaced, "txpool_replaceTransaction", *tx); err != nil {
+		return err
+	}
+
+	if !replaced {
+		return errors.New("failed to replace tx")
+	}
+
+	return nil
+}
+
+// GetCancelTransaction returns the unsigned tx to cancel the specified pending or queued tx in tx pool,
+// which should be signed by sender and sent via ReplaceTransaction.
+func (c *Client) GetCancelTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, error) {
+	var data types.TransactionData
+	if err := c.c.CallContext(ctx, &data, "txpool_getCancelTransaction", txHash.Hex()); err != nil {
+		return nil, err
+	}
+
+	return &types.Transaction{Data: data}, nil
+}
+
+// GetPriceHistogram returns the number of pending txs in tx pool per gas price in ascending order.
+func (c *Client) GetPriceHistogram(ctx context.Context) ([]api.PriceBucket, error) {
+	var histogram []api.PriceBucket
+	err := c.c.CallContext(ctx, &histogram, "txpool_getPriceHistogram")
+	return histogram, err
+}
+
+// GetDebtByHash returns the debt in debt pool or canonical blocks.
+func (c *Client) GetDebtByHash(ctx context.Context, debtHash common.Hash) (*DebtInfo, error) {
+	var info DebtInfo
+	if err := c.c.CallContext(ctx, &info, "txpool_getDebtByHash", debtHash.Hex()); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,164 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package trie
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Iterator iterates over the leaves of trie in ascending key order,
+// and loads the trie nodes from database on demand.
+type Iterator struct {
+	trie  *Trie
+	stack []*iteratorState
+	key   []byte // key bytes of the current leaf
+	value []byte // value of the current leaf
+	start []byte // key bytes of the first leaf to iterate, nil to iterate all leaves
+	err   error
+}
+
+// iteratorState is the state of a trie node in iteration.
+type iteratorState struct {
+	node noder
+	path []byte // hex nibbles from root to the node
+	pos  int    // position of the next child to iterate for branch node
+}
+
+// NewIterator returns an iterator over all the leaves of trie.
+func (t *Trie) NewIterator() *Iterator {
+	it := &Iterator{trie: t}
+
+	if t.root != nil {
+		it.stack = append(it.stack, &iteratorState{node: t.root})
+	}
+
+	return it
+}
+
+// NewIteratorFrom returns an iterator over the leaves of trie whose key is not less than the start key,
+// and the subtrees before the start key are skipped without loading from database.
+func (t *Trie) NewIteratorFrom(start []byte) *Iterator {
+	it := t.NewIterator()
+	it.start = start
+
+	return it
+}
+
+// Next moves the iterator to the next leaf, and returns false if no more leaf or any error occurred.
+func (it *Iterator) Next() bool {
+	for len(it.stack) > 0 && it.err == nil {
+		top := it.stack[len(it.stack)-1]
+
+		if hash, ok := top.node.(hashNode); ok {
+			loaded, err := it.trie.loadNode(hash)
+			if err != nil {
+				it.err = err
+				return false
+			}
+
+			top.node = loaded
+		}
+
+		switch n := top.node.(type) {
+		case *LeafNode:
+			it.pop()
+			key := hexToKeybytes(concatNibbles(top.path, n.Key))
+			if it.start != nil && bytes.Compare(key, it.start) < 0 {
+				continue
+			}
+
+			it.key, it.value = key, n.Value
+			return true
+		case *ExtensionNode:
+			it.pop()
+			it.push(n.NextNode, concatNibbles(top.path, n.Key))
+		case *BranchNode:
+			if top.pos >= numBranchChildren {
+				it.pop()
+				continue
+			}
+
+			// the terminator child comes first, whose key is shorter than others.
+			index := (top.pos + numBranchChildren - 1) % numBranchChildren
+			top.pos++
+
+			if child := n.Children[index]; child != nil {
+				it.push(child, concatNibbles(top.path, []byte{byte(index)}))
+			}
+		default:
+			panic(fmt.Sprintf("invalid node: %v", top.node))
+		}
+	}
+
+	it.key, it.value = nil, nil
+	return false
+}
+
+// Key returns the key of the current leaf.
+func (it *Iterator) Key() []byte {
+	return it.key
+}
+
+// Value returns the value of the current leaf.
+func (it *Iterator) Value() []byte {
+	return it.value
+}
+
+// Err returns the error occurred during iteration if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+func (it *Iterator) push(node noder, path []byte) {
+	if it.start != nil && it.before(path) {
+		return
+	}
+
+	it.stack = append(it.stack, &iteratorState{node: node, path: path})
+}
+
+func (it *Iterator) pop() {
+	it.stack = it.stack[:len(it.stack)-1]
+}
+
+// before returns true if all the leaves under the specified path are before the start key.
+func (it *Iterator) before(path []byte) bool {
+	start := keybytesToHex(it.start)
+	start = start[:len(start)-1] // trim the terminator
+
+	if len(path) > 0 && path[len(path)-1] == byte(numBranchChildren-1) {
+		path = path[:len(path)-1]
+	}
+
+	n := len(path)
+	if len(start) < n {
+		n = len(start)
+	}
+
+	return bytes.Compare(path[:n], start[:n]) < 0
+}
+
+func concatNibbles(a, b []byte) []byte {
+	result := make([]byte, len(a)+len(b))
+	copy(result, a)
+	copy(result[len(a):], b)
+	return result
+}
+
+// hexToKeybytes converts the hex nibbles with terminator to key bytes, see keybytesToHex.
+func hexToKeybytes(hex []byte) []byte {
+	if len(hex) > 0 && hex[len(hex)-1] == byte(numBranchChildren-1) {
+		hex = hex[:len(hex)-1]
+	}
+
+	key := make([]byte, len(hex)/2)
+	for i := range key {
+		key[i] = hex[i*2]*byte(numBranchChildren-1) + hex[i*2+1]
+	}
+
+	return key
+}
@@ -0,0 +1,86 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package trie
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Trie_Iterator(t *testing.T) {
+	db, trie, dispose := newTestTrie()
+	defer dispose()
+
+	// empty trie
+	it := trie.NewIterator()
+	assert.Equal(t, it.Next(), false)
+
+	entries := map[string]string{
+		"12345678": "test",
+		"12345557": "test1",
+		"12375879": "test2",
+		"02375879": "test3",
+		"1234":     "test4",
+		"123":      "test5",
+		"2":        "test6",
+	}
+
+	var keys []string
+	for k, v := range entries {
+		trie.Put([]byte(k), []byte(v))
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	check := func(it *Iterator) {
+		var iterated []string
+		for it.Next() {
+			iterated = append(iterated, string(it.Key()))
+			assert.Equal(t, string(it.Value()), entries[string(it.Key())])
+		}
+
+		assert.Equal(t, it.Err(), nil)
+		assert.Equal(t, iterated, keys)
+	}
+
+	// iterate the dirty nodes in memory
+	check(trie.NewIterator())
+
+	// iterate the nodes loaded from db
+	batch := db.NewBatch()
+	root := trie.Commit(batch)
+	batch.Commit()
+
+	loaded, err := NewTrie(root, []byte("trietest"), db)
+	assert.Equal(t, err, nil)
+	check(loaded.NewIterator())
+
+	// iterate from the start key
+	for _, start := range []string{"", "123", "1233", "12345678", "3"} {
+		var iterated []string
+		for it := loaded.NewIteratorFrom([]byte(start)); it.Next(); {
+			iterated = append(iterated, string(it.Key()))
+		}
+
+		var expected []string
+		for _, k := range keys {
+			if k >= start {
+				expected = append(expected, k)
+			}
+		}
+
+		assert.Equal(t, iterated, expected)
+	}
+}
+
+func Test_hexToKeybytes(t *testing.T) {
+	for _, key := range [][]byte{{}, {0}, {0xff, 0x12}, []byte("hello")} {
+		assert.Equal(t, bytes.Equal(hexToKeybytes(keybytesToHex(key)), key), true)
+	}
+}
@@ -7,12 +7,16 @@ package trie
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"hash"
 
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/crypto/sha3"
 )
 
+var errInvalidRange = errors.New("start key is greater than end key")
+
 // GetProof constructs a merkle proof for key. The result contains all encoded nodes
 // on the path to the value at key. The value itself is also included in the last
 // node and can be retrieved by verifying the proof.
@@ -53,28 +57,251 @@ func (t *Trie) GetProof(key []byte) (map[string][]byte, error) {
 				return proof, fmt.Errorf("unhandled trie error: %s", err)
 			}
 		case *LeafNode:
+			// the leaf node with different key proves the absence of key.
 			tn = nil
-			if len(key) >= len(n.Key) && bytes.Equal(n.Key, key[:len(n.Key)]) {
-				nodes = append(nodes, n)
-			}
+			nodes = append(nodes, n)
 		default:
 			panic(fmt.Sprintf("%T: invalid node: %v", tn, tn))
 		}
 	}
 
 	for _, n := range nodes {
-		buf := new(bytes.Buffer)
-		var sha = sha3.NewKeccak256()
-		sha.Reset()
-		hash := nodeHash(n, buf, sha, nil, nil)
-		encodeNode(n, buf, sha)
+		addProofNode(proof, n)
+	}
+
+	return proof, nil
+}
+
+// addProofNode adds the encoded node into proof with node hash as key.
+func addProofNode(proof map[string][]byte, n noder) {
+	buf := new(bytes.Buffer)
+	var sha = sha3.NewKeccak256()
+	sha.Reset()
+	hash := nodeHash(n, buf, sha, nil, nil)
+	encodeNode(n, buf, sha)
+
+	proof[string(hash)] = buf.Bytes()
+}
+
+// GetMultiProof constructs a merkle proof for multiple keys. The result contains
+// all encoded nodes on the paths to the values at keys, and the nodes shared by
+// different paths are included only once.
+func (t *Trie) GetMultiProof(keys [][]byte) (map[string][]byte, error) {
+	proof := make(map[string][]byte)
+
+	for _, key := range keys {
+		keyProof, err := t.GetProof(key)
+		if err != nil {
+			return proof, err
+		}
 
-		proof[string(hash)] = buf.Bytes()
+		for k, v := range keyProof {
+			proof[k] = v
+		}
 	}
 
 	return proof, nil
 }
 
+// VerifyMultiProof checks merkle proof of multiple keys, and returns the values
+// in order of keys. The value is nil if the trie doesn't contain the key.
+func VerifyMultiProof(rootHash common.Hash, keys [][]byte, proof map[string][]byte) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		value, err := VerifyProof(rootHash, key, proof)
+		if err != nil {
+			return nil, fmt.Errorf("key %x: %v", key, err)
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// GetRangeProof constructs a merkle proof for all the leaves with key in range [start, end].
+// The result contains all encoded nodes whose subtree may contain keys in range, including
+// the nodes on paths to start and end key, which prove the absence of keys at the edges.
+func (t *Trie) GetRangeProof(start, end []byte) (map[string][]byte, error) {
+	if bytes.Compare(start, end) > 0 {
+		return nil, errInvalidRange
+	}
+
+	proof := make(map[string][]byte)
+	if t.root == nil {
+		return proof, nil
+	}
+
+	r := &keyRange{keybytesToNibbles(start), keybytesToNibbles(end)}
+	if err := t.proveRange(t.root, nil, r, proof); err != nil {
+		return proof, fmt.Errorf("unhandled trie error: %s", err)
+	}
+
+	return proof, nil
+}
+
+func (t *Trie) proveRange(node noder, path []byte, r *keyRange, proof map[string][]byte) error {
+	if hash, ok := node.(hashNode); ok {
+		loaded, err := t.loadNode(hash)
+		if err != nil {
+			return err
+		}
+
+		node = loaded
+	}
+
+	addProofNode(proof, node)
+
+	switch n := node.(type) {
+	case *LeafNode:
+		return nil
+	case *ExtensionNode:
+		if childPath := concatNibbles(path, n.Key); r.overlap(childPath) {
+			return t.proveRange(n.NextNode, childPath, r, proof)
+		}
+	case *BranchNode:
+		for i, child := range n.Children {
+			if childPath := concatNibbles(path, []byte{byte(i)}); child != nil && r.overlap(childPath) {
+				if err := t.proveRange(child, childPath, r, proof); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		panic(fmt.Sprintf("%T: invalid node: %v", node, node))
+	}
+
+	return nil
+}
+
+// VerifyRangeProof checks the merkle proof of range [start, end], and returns all the keys
+// and values in range in ascending key order. It returns an error if the proof contains
+// invalid trie nodes, or misses any node that may contain keys in range.
+func VerifyRangeProof(rootHash common.Hash, start, end []byte, proof map[string][]byte) (keys [][]byte, values [][]byte, err error) {
+	if bytes.Compare(start, end) > 0 {
+		return nil, nil, errInvalidRange
+	}
+
+	// empty trie
+	if rootHash.IsEmpty() {
+		return nil, nil, nil
+	}
+
+	v := &rangeVerifier{
+		keyRange: keyRange{keybytesToNibbles(start), keybytesToNibbles(end)},
+		proof:    proof,
+		buf:      new(bytes.Buffer),
+		sha:      sha3.NewKeccak256(),
+	}
+
+	if err = v.verify(rootHash.Bytes(), nil); err != nil {
+		return nil, nil, err
+	}
+
+	return v.keys, v.values, nil
+}
+
+type rangeVerifier struct {
+	keyRange
+	proof  map[string][]byte
+	buf    *bytes.Buffer
+	sha    hash.Hash
+	keys   [][]byte
+	values [][]byte
+}
+
+func (v *rangeVerifier) verify(wantHash []byte, path []byte) error {
+	encoded := v.proof[string(wantHash)]
+	if encoded == nil {
+		return fmt.Errorf("proof node (hash %x) missing", wantHash)
+	}
+
+	n, err := decodeNode(common.CopyBytes(wantHash), encoded)
+	if err != nil {
+		return fmt.Errorf("bad proof node %x: %v", wantHash, err)
+	}
+
+	// verify node hash against proof key to avoid faked node.
+	n.SetStatus(nodeStatusDirty)
+	if h := nodeHash(n, v.buf, v.sha, nil, nil); !bytes.Equal(wantHash, h) {
+		return fmt.Errorf("proof node %x hash mismatch", wantHash)
+	}
+
+	switch n := n.(type) {
+	case *LeafNode:
+		if key := concatNibbles(path, n.Key); v.overlap(key) {
+			v.keys = append(v.keys, hexToKeybytes(trimTerminator(key)))
+			v.values = append(v.values, n.Value)
+		}
+	case *ExtensionNode:
+		if childPath := concatNibbles(path, n.Key); v.overlap(childPath) {
+			return v.verifyChild(n.NextNode, childPath)
+		}
+	case *BranchNode:
+		// the terminator child comes first, whose key is shorter than others.
+		for i := 0; i < numBranchChildren; i++ {
+			index := (i + numBranchChildren - 1) % numBranchChildren
+			if childPath := concatNibbles(path, []byte{byte(index)}); n.Children[index] != nil && v.overlap(childPath) {
+				if err := v.verifyChild(n.Children[index], childPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *rangeVerifier) verifyChild(child noder, path []byte) error {
+	hash, ok := child.(hashNode)
+	if !ok {
+		return fmt.Errorf("%T: invalid child node in proof", child)
+	}
+
+	return v.verify(hash, path)
+}
+
+// keyRange is the range [start, end] of keys in hex nibbles without terminator.
+type keyRange struct {
+	start []byte
+	end   []byte
+}
+
+// overlap returns true if the subtree of the specified path may contain keys in range.
+// If the path ends with terminator, it's a full key instead of prefix.
+func (r *keyRange) overlap(path []byte) bool {
+	key := trimTerminator(path)
+	if len(key) < len(path) {
+		return bytes.Compare(key, r.start) >= 0 && bytes.Compare(key, r.end) <= 0
+	}
+
+	// keys with the prefix are all greater than end.
+	if bytes.Compare(key, r.end) > 0 {
+		return false
+	}
+
+	// keys with the prefix are all less than start.
+	if bytes.Compare(key, r.start) < 0 && !bytes.HasPrefix(r.start, key) {
+		return false
+	}
+
+	return true
+}
+
+// keybytesToNibbles converts key bytes to hex nibbles without terminator.
+func keybytesToNibbles(key []byte) []byte {
+	return trimTerminator(keybytesToHex(key))
+}
+
+func trimTerminator(hex []byte) []byte {
+	for len(hex) > 0 && hex[len(hex)-1] == byte(numBranchChildren-1) {
+		hex = hex[:len(hex)-1]
+	}
+
+	return hex
+}
+
 // VerifyProof checks merkle proofs. The given proof must contain the value for
 // key in a trie with the given root hash. VerifyProof returns an error if the
 // proof contains invalid trie nodes or the wrong value.
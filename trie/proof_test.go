@@ -97,6 +97,122 @@ func TestVerifyBadProof(t *testing.T) {
 	}
 }
 
+func Test_MultiProof(t *testing.T) {
+	trie, vals, dispose := randomTrie(500)
+	defer dispose()
+
+	var keys, expected [][]byte
+	for _, kv := range vals {
+		keys = append(keys, kv.k)
+		expected = append(expected, kv.v)
+		if len(keys) == 20 {
+			break
+		}
+	}
+
+	// keys not in trie
+	keys = append(keys, randBytes(32), randBytes(10))
+	expected = append(expected, nil, nil)
+
+	proof, err := trie.GetMultiProof(keys)
+	assert.Equal(t, err, nil)
+
+	nodes := 0
+	for _, key := range keys {
+		single, err := trie.GetProof(key)
+		assert.Equal(t, err, nil)
+		nodes += len(single)
+	}
+	assert.Equal(t, len(proof) < nodes, true)
+
+	values, err := VerifyMultiProof(trie.Hash(), keys, proof)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, values, expected)
+
+	// proof of other keys
+	_, err = VerifyMultiProof(trie.Hash(), [][]byte{keys[0]}, map[string][]byte{})
+	assert.Equal(t, err != nil, true)
+}
+
+func sortedTrieKeys(trie *Trie) [][]byte {
+	var keys [][]byte
+	for it := trie.NewIterator(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+
+	return keys
+}
+
+func Test_RangeProof(t *testing.T) {
+	trie, vals, dispose := randomTrie(300)
+	defer dispose()
+
+	root := trie.Hash()
+	keys := sortedTrieKeys(trie)
+
+	ranges := [][2][]byte{
+		{keys[0], keys[len(keys)-1]},   // all keys
+		{keys[10], keys[50]},           // edges exist
+		{keys[10], keys[10]},           // single key
+		{randBytes(32), randBytes(32)}, // random edges
+		{[]byte{0xff, 0xff}, []byte{0xff, 0xff, 0xff}},
+	}
+
+	for _, r := range ranges {
+		start, end := r[0], r[1]
+		if bytes.Compare(start, end) > 0 {
+			start, end = end, start
+		}
+
+		var expected [][]byte
+		for _, k := range keys {
+			if bytes.Compare(k, start) >= 0 && bytes.Compare(k, end) <= 0 {
+				expected = append(expected, k)
+			}
+		}
+
+		proof, err := trie.GetRangeProof(start, end)
+		assert.Equal(t, err, nil)
+
+		rangeKeys, rangeValues, err := VerifyRangeProof(root, start, end, proof)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, rangeKeys, expected)
+		assert.Equal(t, len(rangeValues), len(expected))
+		for i, k := range rangeKeys {
+			assert.Equal(t, rangeValues[i], vals[string(k)].v)
+		}
+
+		// any missing node fails the verification
+		for k := range proof {
+			node := proof[k]
+			delete(proof, k)
+			_, _, err = VerifyRangeProof(root, start, end, proof)
+			assert.Equal(t, err != nil, true)
+			proof[k] = node
+		}
+	}
+
+	// invalid range
+	_, err := trie.GetRangeProof(keys[1], keys[0])
+	assert.Equal(t, err, errInvalidRange)
+	_, _, err = VerifyRangeProof(root, keys[1], keys[0], nil)
+	assert.Equal(t, err, errInvalidRange)
+}
+
+func Test_RangeProof_EmptyTrie(t *testing.T) {
+	_, trie, dispose := newTestTrie()
+	defer dispose()
+
+	proof, err := trie.GetRangeProof([]byte{1}, []byte{2})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(proof), 0)
+
+	keys, values, err := VerifyRangeProof(trie.Hash(), []byte{1}, []byte{2}, proof)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(keys), 0)
+	assert.Equal(t, len(values), 0)
+}
+
 // mutateByte changes one byte in b.
 func mutateByte(b []byte) {
 	for r := mrand.Intn(len(b)); ; {
@@ -0,0 +1,60 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package trie
+
+import "fmt"
+
+// WalkNodes visits the hash of all persisted nodes in the trie in depth-first order,
+// and loads the nodes from database if necessary. If the visit function returns false,
+// the children of the visited node will be skipped, e.g. the node already visited
+// when walks several tries that share sub-tries.
+func (t *Trie) WalkNodes(visit func(hash []byte) bool) error {
+	return t.walk(t.root, visit)
+}
+
+func (t *Trie) walk(node noder, visit func(hash []byte) bool) error {
+	if node == nil {
+		return nil
+	}
+
+	if hash, ok := node.(hashNode); ok {
+		if !visit(hash) {
+			return nil
+		}
+
+		loaded, err := t.loadNode(hash)
+		if err != nil {
+			return err
+		}
+
+		return t.walkChildren(loaded, visit)
+	}
+
+	if node.Status() == nodeStatusPersisted && !visit(node.Hash()) {
+		return nil
+	}
+
+	return t.walkChildren(node, visit)
+}
+
+func (t *Trie) walkChildren(node noder, visit func(hash []byte) bool) error {
+	switch n := node.(type) {
+	case *LeafNode:
+		return nil
+	case *ExtensionNode:
+		return t.walk(n.NextNode, visit)
+	case *BranchNode:
+		for _, child := range n.Children {
+			if err := t.walk(child, visit); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		panic(fmt.Sprintf("invalid node: %v", node))
+	}
+}
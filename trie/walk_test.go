@@ -0,0 +1,56 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Trie_WalkNodes(t *testing.T) {
+	db, trie, dispose := newTestTrie()
+	defer dispose()
+
+	trie.Put([]byte("12345678"), []byte("test"))
+	trie.Put([]byte("12345557"), []byte("test1"))
+	trie.Put([]byte("12375879"), []byte("test2"))
+	trie.Put([]byte("02375879"), []byte("test3"))
+
+	batch := db.NewBatch()
+	root := trie.Commit(batch)
+	assert.Equal(t, batch.Commit(), nil)
+
+	// walk all the nodes of a loaded trie
+	loaded, err := NewTrie(root, []byte("trietest"), db)
+	assert.Equal(t, err, nil)
+
+	visited := make(map[common.Hash]bool)
+	err = loaded.WalkNodes(func(hash []byte) bool {
+		visited[common.BytesToHash(hash)] = true
+		return true
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, visited[root], true)
+
+	it := db.NewIteratorWithPrefix([]byte("trietest"))
+	count := 0
+	for it.Next() {
+		count++
+		assert.Equal(t, visited[common.BytesToHash(it.Key()[len("trietest"):])], true)
+	}
+	it.Release()
+	assert.Equal(t, len(visited), count)
+
+	// skip the children of root
+	count = 0
+	loaded.WalkNodes(func(hash []byte) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, count, 1)
+}